@@ -31,6 +31,9 @@ import (
 	"github.com/richardwilkes/ui/widget"
 	"github.com/richardwilkes/ui/widget/button"
 	"github.com/richardwilkes/ui/widget/checkbox"
+	"github.com/richardwilkes/ui/widget/codeeditor"
+	"github.com/richardwilkes/ui/widget/combobox"
+	"github.com/richardwilkes/ui/widget/findbar"
 	"github.com/richardwilkes/ui/widget/imagebutton"
 	"github.com/richardwilkes/ui/widget/imagelabel"
 	"github.com/richardwilkes/ui/widget/label"
@@ -39,6 +42,7 @@ import (
 	"github.com/richardwilkes/ui/widget/radiobutton"
 	"github.com/richardwilkes/ui/widget/scrollarea"
 	"github.com/richardwilkes/ui/widget/separator"
+	"github.com/richardwilkes/ui/widget/spinner"
 	"github.com/richardwilkes/ui/widget/textfield"
 	"github.com/richardwilkes/ui/widget/tooltip"
 	"github.com/richardwilkes/ui/widget/webview"
@@ -78,8 +82,9 @@ func createMenus(evt event.Event) {
 }
 
 func newFileMenu() menu.Menu {
-	fileMenu := menu.NewMenu("File")
-	fileMenu.AppendItem(menu.NewItemWithKey("Open", keys.VirtualKeyO, nil))
+	fileMenu := menu.NewMenu("&File")
+	fileMenu.AppendItem(menu.NewItemWithKey("&Open...", keys.VirtualKeyO, nil))
+	fileMenu.AppendItem(menu.NewItemWithKey("New &Code Editor", keys.VirtualKeyN, createCodeEditorWindow))
 	fileMenu.AppendItem(menu.NewSeparator())
 	fileMenu.AppendItem(filemenu.NewCloseKeyWindowItem())
 	return fileMenu
@@ -390,6 +395,35 @@ func createTextFieldsPanel() ui.Widget {
 		}
 	})
 
+	field = createTextField("", panel)
+	field.SetWatermark("Enter a date (YYYY-MM-DD)")
+	field.SetMask("####-##-##")
+	field.SetFormatter(&textfield.DateFormatter{Layout: "2006-01-02"})
+
+	field = createTextField("", panel)
+	field.SetWatermark("Password")
+	field.SetSecure(true)
+	field.SetRevealToggle(true)
+
+	combo := combobox.New()
+	combo.SetWatermark("Choose or type a color")
+	combo.SetItems("Red", "Orange", "Yellow", "Green", "Blue", "Indigo", "Violet")
+	flexData := flex.NewData()
+	flexData.HAlign = align.Fill
+	flexData.HGrab = true
+	combo.SetLayoutData(flexData)
+	panel.AddChild(combo)
+
+	spin := spinner.New()
+	spin.SetRange(-10, 10)
+	spin.SetStep(0.25)
+	spin.SetPrecision(2)
+	flexData = flex.NewData()
+	flexData.HAlign = align.Fill
+	flexData.HGrab = true
+	spin.SetLayoutData(flexData)
+	panel.AddChild(spin)
+
 	return panel
 }
 
@@ -427,6 +461,43 @@ func createAboutWindow(evt event.Event) {
 	aboutWindow.ToFront()
 }
 
+func createCodeEditorWindow(evt event.Event) {
+	wnd := window.NewWindow(geom.Point{}, window.StdWindowMask)
+	wnd.SetTitle("Code Editor")
+	content := wnd.Content()
+	flex.NewLayout(content)
+	editor := codeeditor.New()
+	editor.SetLexer(codeeditor.NewGoLexer())
+	editor.SetText(sampleCode)
+	panel := findbar.NewPanel(editor, scrollarea.New(editor, scrollarea.Fill))
+	flexData := flex.NewData()
+	flexData.HAlign = align.Fill
+	flexData.VAlign = align.Fill
+	flexData.HGrab = true
+	flexData.VGrab = true
+	flexData.SizeHint = geom.Size{Width: 640, Height: 480}
+	panel.SetLayoutData(flexData)
+	content.AddChild(panel)
+	wnd.SetFocus(editor)
+	wnd.Pack()
+	wnd.ToFront()
+}
+
+const sampleCode = `package main
+
+import "fmt"
+
+/* A small sample to show off
+   the syntax highlighting. */
+func main() {
+	values := []int{1, 2, 3}
+	for i, v := range values {
+		// Print each value
+		fmt.Printf("%d: %d\n", i, v*2)
+	}
+}
+`
+
 func createPreferencesWindow(evt event.Event) {
 	fmt.Println("Preferences...")
 }
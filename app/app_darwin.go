@@ -6,6 +6,8 @@ import (
 	// #include "app_darwin.h"
 	"C"
 
+	"github.com/richardwilkes/ui/display"
+	"github.com/richardwilkes/ui/draw"
 	"github.com/richardwilkes/ui/event"
 	"github.com/richardwilkes/ui/menu/macmenus"
 )
@@ -59,6 +61,7 @@ func cbAppWillQuit() {
 
 //export cbAppWillFinishStartup
 func cbAppWillFinishStartup() {
+	draw.PreferredImageScale = display.MaxScaleFactor()
 	macmenus.Install()
 	event.SendAppWillFinishStartup()
 }
@@ -87,3 +90,9 @@ func cbAppWillResignActive() {
 func cbAppDidResignActive() {
 	event.SendAppDidDeactivate()
 }
+
+//export cbDisplaysChanged
+func cbDisplaysChanged() {
+	draw.PreferredImageScale = display.MaxScaleFactor()
+	event.SendDisplaysChanged()
+}
@@ -43,6 +43,10 @@
 	cbAppDidResignActive();
 }
 
+- (void)applicationDidChangeScreenParameters:(NSNotification *)aNotification {
+	cbDisplaysChanged();
+}
+
 @end
 
 void startUserInterface() {
@@ -0,0 +1,13 @@
+package ui
+
+import (
+	"github.com/richardwilkes/toolbox/xmath/geom"
+)
+
+// CaretLocator should be implemented by widgets that display a text caret
+// while they have the keyboard focus. Input methods use it to place their
+// candidate windows next to the caret.
+type CaretLocator interface {
+	// CaretRect returns the bounds of the text caret in local coordinates.
+	CaretRect() geom.Rect
+}
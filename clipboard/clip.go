@@ -1,10 +1,22 @@
 package clipboard
 
 import (
+	"time"
+
 	"github.com/richardwilkes/ui/clipboard/datatypes"
 )
 
 var (
+	// Timeout holds the maximum amount of time DataAsync waits for another
+	// application to supply the contents of the clipboard. Only used on
+	// platforms where the clipboard contents are supplied on demand by the
+	// application that owns them.
+	Timeout = time.Second * 2
+	// BlockingTimeout holds the maximum amount of time HasType, Types and
+	// Data wait for another application to supply the contents of the
+	// clipboard. The UI thread is blocked while they wait, so this is much
+	// shorter than Timeout. Only used on the same platforms as Timeout.
+	BlockingTimeout = time.Millisecond * 250
 	lastChangeCount = -1
 	dataTypes       []string
 )
@@ -35,11 +47,22 @@ func Types() []string {
 }
 
 // Data returns the bytes associated with the specified data type on the clipboard. An empty byte
-// slice will be returned if no such data type is present.
+// slice will be returned if no such data type is present. This may block for up to
+// BlockingTimeout; DataAsync does not.
 func Data(dataType string) []byte {
 	return platformGetData(dataType)
 }
 
+// DataAsync retrieves the bytes associated with the specified data type on
+// the clipboard without blocking. The callback will be called on the UI
+// thread once the data is available, or retrieval has failed. Calling the
+// returned function before then cancels the request, in which case the
+// callback will not be called. On platforms where the data is available
+// immediately, the callback may be called before DataAsync returns.
+func DataAsync(dataType string, callback func(data []byte, err error)) (cancel func()) {
+	return platformGetDataAsync(dataType, callback)
+}
+
 // SetData sets the data into the system clipboard.
 func SetData(data ...datatypes.Data) {
 	platformSetData(data)
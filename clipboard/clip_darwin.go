@@ -54,6 +54,11 @@ func platformGetData(dataType string) []byte {
 	return result
 }
 
+func platformGetDataAsync(dataType string, callback func(data []byte, err error)) (cancel func()) {
+	callback(platformGetData(dataType), nil)
+	return func() {}
+}
+
 func platformSetData(data []datatypes.Data) {
 	C.clearClipboard()
 	for _, one := range data {
@@ -6,21 +6,49 @@ import (
 )
 
 func platformChangeCount() int {
-	return x11.ClipboardChangeCount()
+	return x11.Clipboard.ChangeCount()
 }
 
 func platformClear() {
-	x11.ClipboardClear()
+	x11.Clipboard.Clear()
 }
 
 func platformTypes() []string {
-	return x11.ClipboardTypes()
+	return x11.Clipboard.Types(BlockingTimeout)
 }
 
 func platformGetData(dataType string) []byte {
-	return x11.GetClipboard(dataType)
+	return x11.Clipboard.Data(dataType, BlockingTimeout)
+}
+
+func platformGetDataAsync(dataType string, callback func(data []byte, err error)) (cancel func()) {
+	return x11.Clipboard.DataAsync(dataType, Timeout, callback)
 }
 
 func platformSetData(data []datatypes.Data) {
-	x11.SetClipboard(data)
+	x11.Clipboard.SetData(data)
+}
+
+func platformPrimaryChangeCount() int {
+	return x11.Primary.ChangeCount()
+}
+
+func platformPrimaryClear() {
+	x11.Primary.Clear()
+}
+
+func platformPrimaryTypes() []string {
+	return x11.Primary.Types(BlockingTimeout)
+}
+
+func platformPrimaryGetData(dataType string) []byte {
+	return x11.Primary.Data(dataType, BlockingTimeout)
+}
+
+func platformPrimaryGetDataAsync(dataType string, callback func(data []byte, err error)) (cancel func()) {
+	return x11.Primary.DataAsync(dataType, Timeout, callback)
+}
+
+func platformPrimarySetData(data []datatypes.Data) {
+	x11.Primary.SetData(data)
 }
@@ -36,6 +36,12 @@ func platformGetData(dataType string) []byte {
 	return clipData[dataType]
 }
 
+func platformGetDataAsync(dataType string, callback func(data []byte, err error)) (cancel func()) {
+	// RAW: Implement for Windows (i.e. cross-app support)
+	callback(platformGetData(dataType), nil)
+	return func() {}
+}
+
 func platformSetData(data []datatypes.Data) {
 	// RAW: Implement for Windows (i.e. cross-app support)
 	platformClear()
@@ -4,6 +4,9 @@ package datatypes
 const (
 	PlainText = `text/plain`
 	RTFText   = "text/rtf"
+	HTMLText  = "text/html"
+	URIList   = "text/uri-list"
+	PNGImage  = "image/png"
 )
 
 // Data holds the data for a clipboard.
@@ -0,0 +1,154 @@
+package clipboard
+
+import (
+	"bytes"
+	"encoding/gob"
+	"image"
+	gocolor "image/color"
+	_ "image/gif"  // Support pasting of GIF
+	_ "image/jpeg" // Support pasting of JPEG
+	"image/png"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/ui/clipboard/datatypes"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/draw"
+)
+
+var valueTypes = make(map[string]reflect.Type)
+
+// SetImage sets the image onto the clipboard as a PNG.
+func SetImage(img *draw.ImageData) error {
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		return errs.Wrap(err)
+	}
+	SetData(datatypes.Data{MimeType: datatypes.PNGImage, Bytes: buffer.Bytes()})
+	return nil
+}
+
+// Image returns the image on the clipboard, or nil if there isn't one.
+func Image() (*draw.ImageData, error) {
+	if !HasType(datatypes.PNGImage) {
+		return nil, nil
+	}
+	img, _, err := image.Decode(bytes.NewReader(Data(datatypes.PNGImage)))
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	bounds := img.Bounds()
+	data := &draw.ImageData{Width: bounds.Dx(), Height: bounds.Dy(), Pixels: make([]color.Color, bounds.Dx()*bounds.Dy())}
+	i := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := gocolor.NRGBAModel.Convert(img.At(x, y)).(gocolor.NRGBA)
+			data.Pixels[i] = color.RGBA(int(c.R), int(c.G), int(c.B), float64(c.A)/255)
+			i++
+		}
+	}
+	return data, nil
+}
+
+// SetHTML sets the HTML onto the clipboard, along with a plain text version
+// for applications that don't accept HTML.
+func SetHTML(html, plainText string) {
+	SetData(datatypes.Data{MimeType: datatypes.HTMLText, Bytes: []byte(html)}, datatypes.Data{MimeType: datatypes.PlainText, Bytes: []byte(plainText)})
+}
+
+// HTML returns the HTML on the clipboard, or an empty string if there isn't
+// any.
+func HTML() string {
+	if !HasType(datatypes.HTMLText) {
+		return ""
+	}
+	return string(Data(datatypes.HTMLText))
+}
+
+// SetFiles sets the list of file paths onto the clipboard, along with a
+// plain text version containing one path per line.
+func SetFiles(paths []string) {
+	uris := make([]string, len(paths))
+	for i, path := range paths {
+		if abs, err := filepath.Abs(path); err == nil {
+			path = abs
+		}
+		path = filepath.ToSlash(path)
+		if !strings.HasPrefix(path, "/") {
+			// Windows paths start with a drive letter
+			path = "/" + path
+		}
+		uris[i] = (&url.URL{Scheme: "file", Path: path}).String()
+	}
+	SetData(datatypes.Data{MimeType: datatypes.URIList, Bytes: []byte(strings.Join(uris, "\r\n") + "\r\n")}, datatypes.Data{MimeType: datatypes.PlainText, Bytes: []byte(strings.Join(paths, "\n"))})
+}
+
+// Files returns the list of file paths on the clipboard. Any URIs on the
+// clipboard that don't refer to local files are ignored.
+func Files() []string {
+	if !HasType(datatypes.URIList) {
+		return nil
+	}
+	var paths []string
+	for _, line := range strings.Split(string(Data(datatypes.URIList)), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if u, err := url.Parse(line); err == nil && u.Scheme == "file" && (u.Host == "" || u.Host == "localhost") {
+			path := u.Path
+			if len(path) > 2 && path[0] == '/' && path[2] == ':' {
+				// Windows paths start with a drive letter
+				path = path[1:]
+			}
+			paths = append(paths, filepath.FromSlash(path))
+		}
+	}
+	return paths
+}
+
+// RegisterType registers an application-private data type. Values placed on
+// the clipboard with this data type must have the same type as 'prototype'
+// and are serialized with encoding/gob. Using a vendor-specific MIME type,
+// such as "application/x-myapp-widget", is recommended.
+func RegisterType(dataType string, prototype interface{}) {
+	valueTypes[dataType] = reflect.TypeOf(prototype)
+}
+
+// SetValue serializes the value onto the clipboard using the registered data
+// type.
+func SetValue(dataType string, value interface{}) error {
+	valueType, ok := valueTypes[dataType]
+	if !ok {
+		return errs.Newf("data type '%s' has not been registered", dataType)
+	}
+	if reflect.TypeOf(value) != valueType {
+		return errs.Newf("data type '%s' requires a value of type %v", dataType, valueType)
+	}
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(value); err != nil {
+		return errs.Wrap(err)
+	}
+	SetData(datatypes.Data{MimeType: dataType, Bytes: buffer.Bytes()})
+	return nil
+}
+
+// Value returns the value on the clipboard with the registered data type, or
+// nil if there isn't one.
+func Value(dataType string) (interface{}, error) {
+	valueType, ok := valueTypes[dataType]
+	if !ok {
+		return nil, errs.Newf("data type '%s' has not been registered", dataType)
+	}
+	if !HasType(dataType) {
+		return nil, nil
+	}
+	ptr := reflect.New(valueType)
+	if err := gob.NewDecoder(bytes.NewReader(Data(dataType))).Decode(ptr.Interface()); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return ptr.Elem().Interface(), nil
+}
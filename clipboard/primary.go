@@ -0,0 +1,65 @@
+package clipboard
+
+import (
+	"github.com/richardwilkes/ui/clipboard/datatypes"
+)
+
+// PrimarySelection provides access to the primary selection. On X11, the
+// primary selection holds the most recently selected text and is pasted with
+// the middle mouse button. Other platforms have no equivalent, so there it is
+// private to the application.
+type PrimarySelection struct {
+	lastChangeCount int
+	dataTypes       []string
+}
+
+// Primary is the primary selection.
+var Primary = &PrimarySelection{lastChangeCount: -1}
+
+// Clear the primary selection contents.
+func (p *PrimarySelection) Clear() {
+	platformPrimaryClear()
+}
+
+// ChangeCount returns a number that changes whenever the contents of the primary selection may
+// have changed, such as when another application takes ownership of it.
+func (p *PrimarySelection) ChangeCount() int {
+	return platformPrimaryChangeCount()
+}
+
+// HasType returns true if the specified data type exists in the primary selection.
+func (p *PrimarySelection) HasType(dataType string) bool {
+	for _, one := range p.Types() {
+		if one == dataType {
+			return true
+		}
+	}
+	return false
+}
+
+// Types returns the types of data currently in the primary selection.
+func (p *PrimarySelection) Types() []string {
+	changeCount := platformPrimaryChangeCount()
+	if changeCount != p.lastChangeCount {
+		p.lastChangeCount = changeCount
+		p.dataTypes = platformPrimaryTypes()
+	}
+	return p.dataTypes
+}
+
+// Data returns the bytes associated with the specified data type in the primary selection. An
+// empty byte slice will be returned if no such data type is present.
+func (p *PrimarySelection) Data(dataType string) []byte {
+	return platformPrimaryGetData(dataType)
+}
+
+// DataAsync retrieves the bytes associated with the specified data type in the primary selection
+// without blocking. See DataAsync for details.
+func (p *PrimarySelection) DataAsync(dataType string, callback func(data []byte, err error)) (cancel func()) {
+	return platformPrimaryGetDataAsync(dataType, callback)
+}
+
+// SetData sets the data into the primary selection.
+func (p *PrimarySelection) SetData(data ...datatypes.Data) {
+	platformPrimarySetData(data)
+}
@@ -0,0 +1,45 @@
+package clipboard
+
+import (
+	"github.com/richardwilkes/ui/clipboard/datatypes"
+)
+
+// There is no primary selection on this platform, so it is kept private to
+// the application.
+var (
+	primaryData        = make(map[string][]byte)
+	primaryChangeCount int
+)
+
+func platformPrimaryChangeCount() int {
+	return primaryChangeCount
+}
+
+func platformPrimaryClear() {
+	primaryData = make(map[string][]byte)
+	primaryChangeCount++
+}
+
+func platformPrimaryTypes() []string {
+	types := make([]string, 0, len(primaryData))
+	for key := range primaryData {
+		types = append(types, key)
+	}
+	return types
+}
+
+func platformPrimaryGetData(dataType string) []byte {
+	return primaryData[dataType]
+}
+
+func platformPrimaryGetDataAsync(dataType string, callback func(data []byte, err error)) (cancel func()) {
+	callback(primaryData[dataType], nil)
+	return func() {}
+}
+
+func platformPrimarySetData(data []datatypes.Data) {
+	platformPrimaryClear()
+	for _, one := range data {
+		primaryData[one.MimeType] = one.Bytes
+	}
+}
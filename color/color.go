@@ -25,72 +25,112 @@ func (c Color) String() string {
 // - CSS predefined color name, e.g. "Yellow"
 // - CSS rgb(), e.g. "rgb(255, 255, 0)"
 // - CSS rgba(), e.g. "rgba(255, 255, 0, 0.3)"
-// - CSS short hexadecimal colors, e.g. "#FF0"
-// - CSS long hexadecimal colors, e.g. "#FFFF00"
+// - CSS short hexadecimal colors, e.g. "#FF0", or with alpha, e.g. "#FF08"
+// - CSS long hexadecimal colors, e.g. "#FFFF00", or with alpha, e.g. "#FFFF0080"
 // - CCS hsl(), e.g. "hsl(120, 100%, 50%)"
 // - CSS hsla(), e.g. "hsla(120, 100%, 50%, 0.3)"
+//
+// Strings that are not colors produce 0, which cannot be told apart from a transparent black. Use
+// DecodeOK when that matters.
 func Decode(buffer string) Color {
+	color, _ := DecodeOK(buffer)
+	return color
+}
+
+// DecodeOK creates a Color from a string in any of the formats Decode accepts. The second return
+// value is false if the string is not a color in one of those formats, in which case any parts of
+// it that could be understood are still used for the color.
+func DecodeOK(buffer string) (Color, bool) {
 	buffer = strings.ToLower(strings.TrimSpace(buffer))
 	if color, ok := nameToColor[buffer]; ok {
-		return color
+		return color, true
 	}
+	var d decoder
 	switch {
 	case strings.HasPrefix(buffer, "#"):
 		buffer = buffer[1:]
 		switch len(buffer) {
 		case 3:
-			return RGB(extractChannel(buffer[0:1]+buffer[0:1], 16), extractChannel(buffer[1:2]+buffer[1:2], 16), extractChannel(buffer[2:3]+buffer[2:3], 16))
+			return RGB(d.channel(buffer[0:1]+buffer[0:1], 16), d.channel(buffer[1:2]+buffer[1:2], 16), d.channel(buffer[2:3]+buffer[2:3], 16)), !d.failed
+		case 4:
+			return RGBA(d.channel(buffer[0:1]+buffer[0:1], 16), d.channel(buffer[1:2]+buffer[1:2], 16), d.channel(buffer[2:3]+buffer[2:3], 16), float64(d.channel(buffer[3:4]+buffer[3:4], 16))/255), !d.failed
 		case 6:
-			return RGB(extractChannel(buffer[0:2], 16), extractChannel(buffer[2:4], 16), extractChannel(buffer[4:6], 16))
+			return RGB(d.channel(buffer[0:2], 16), d.channel(buffer[2:4], 16), d.channel(buffer[4:6], 16)), !d.failed
+		case 8:
+			return RGBA(d.channel(buffer[0:2], 16), d.channel(buffer[2:4], 16), d.channel(buffer[4:6], 16), float64(d.channel(buffer[6:8], 16))/255), !d.failed
 		}
 	case strings.HasPrefix(buffer, "rgb(") && strings.HasSuffix(buffer, ")"):
 		parts := strings.SplitN(strings.TrimSpace(buffer[4:len(buffer)-1]), ",", 4)
 		if len(parts) == 3 {
-			return RGB(extractChannel(parts[0], 10), extractChannel(parts[1], 10), extractChannel(parts[2], 10))
+			return RGB(d.channel(parts[0], 10), d.channel(parts[1], 10), d.channel(parts[2], 10)), !d.failed
 		}
 	case strings.HasPrefix(buffer, "rgba(") && strings.HasSuffix(buffer, ")"):
 		parts := strings.SplitN(strings.TrimSpace(buffer[5:len(buffer)-1]), ",", 5)
 		if len(parts) == 4 {
-			return RGBA(extractChannel(parts[0], 10), extractChannel(parts[1], 10), extractChannel(parts[2], 10), extractAlpha(parts[3]))
+			return RGBA(d.channel(parts[0], 10), d.channel(parts[1], 10), d.channel(parts[2], 10), d.alpha(parts[3])), !d.failed
 		}
 	case strings.HasPrefix(buffer, "hsl(") && strings.HasSuffix(buffer, ")"):
 		parts := strings.SplitN(strings.TrimSpace(buffer[4:len(buffer)-1]), ",", 4)
 		if len(parts) == 3 {
-			return HSB(float64(extractChannel(parts[0], 10))/360, extractPercentage(parts[1]), extractPercentage(parts[2]))
+			return HSB(float64(d.channel(parts[0], 10))/360, d.percentage(parts[1]), d.percentage(parts[2])), !d.failed
 		}
 	case strings.HasPrefix(buffer, "hsla(") && strings.HasSuffix(buffer, ")"):
 		parts := strings.SplitN(strings.TrimSpace(buffer[5:len(buffer)-1]), ",", 5)
 		if len(parts) == 4 {
-			return HSBA(float64(extractChannel(parts[0], 10))/360, extractPercentage(parts[1]), extractPercentage(parts[2]), extractAlpha(parts[3]))
+			return HSBA(float64(d.channel(parts[0], 10))/360, d.percentage(parts[1]), d.percentage(parts[2]), d.alpha(parts[3])), !d.failed
 		}
 	}
-	return 0
+	return 0, false
 }
 
-func extractChannel(buffer string, base int) int {
-	if value, err := strconv.ParseInt(strings.TrimSpace(buffer), base, 64); err == nil {
-		return int(value)
+// MarshalText implements the encoding.TextMarshaler interface.
+func (c Color) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (c *Color) UnmarshalText(text []byte) error {
+	color, ok := DecodeOK(string(text))
+	if !ok {
+		return fmt.Errorf("invalid color: %s", text)
 	}
-	return 0
+	*c = color
+	return nil
+}
+
+// decoder extracts the values within a color string, noting whether any of them were malformed.
+// Malformed values are treated as 0.
+type decoder struct {
+	failed bool
+}
+
+func (d *decoder) channel(buffer string, base int) int {
+	value, err := strconv.ParseInt(strings.TrimSpace(buffer), base, 64)
+	if err != nil {
+		d.failed = true
+		return 0
+	}
+	return int(value)
 }
 
-func extractAlpha(buffer string) float64 {
+func (d *decoder) alpha(buffer string) float64 {
 	alpha, err := strconv.ParseFloat(strings.TrimSpace(buffer), 32)
 	if err != nil {
+		d.failed = true
 		return 0
 	}
 	return clamp0To1(alpha)
 }
 
-func extractPercentage(buffer string) float64 {
+func (d *decoder) percentage(buffer string) float64 {
 	buffer = strings.TrimSpace(buffer)
 	if strings.HasSuffix(buffer, "%") {
 		value, err := strconv.Atoi(strings.TrimSpace(buffer[:len(buffer)-1]))
-		if err != nil {
-			return 0
+		if err == nil {
+			return clamp0To1(float64(value) / 100)
 		}
-		return clamp0To1(float64(value) / 100)
 	}
+	d.failed = true
 	return 0
 }
 
@@ -0,0 +1,40 @@
+package color
+
+import "testing"
+
+func TestDecodeOK(t *testing.T) {
+	for i, one := range []struct {
+		text  string
+		color Color
+		ok    bool
+	}{
+		{"Yellow", 0xFFFFFF00, true},
+		{"#FF0", 0xFFFFFF00, true},
+		{"#ff08", 0x88FFFF00, true},
+		{" #FFFF00 ", 0xFFFFFF00, true},
+		{"#FFFF0080", 0x80FFFF00, true},
+		{"#00000000", 0, true},
+		{"rgb(255, 255, 0)", 0xFFFFFF00, true},
+		{"rgba(0, 0, 0, 0)", 0, true},
+		{"hsla(0, 0%, 0%, 0)", 0, true},
+		{"", 0, false},
+		{"bogus", 0, false},
+		{"#12345", 0, false},
+		{"#GG0000", 0xFF000000, false},
+		{"rgb(255, 255)", 0, false},
+		{"rgb(1, x, 3)", 0xFF010003, false},
+		{"rgba(0, 0, 0, half)", 0, false},
+		{"hsl(120, 100, 50%)", 0xFF808080, false},
+	} {
+		color, ok := DecodeOK(one.text)
+		if color != one.color || ok != one.ok {
+			t.Errorf("%d: DecodeOK(%q) = (%08X, %v), expected (%08X, %v)", i, one.text, uint32(color), ok, uint32(one.color), one.ok)
+		}
+		var unmarshaled Color
+		if err := unmarshaled.UnmarshalText([]byte(one.text)); (err == nil) != one.ok {
+			t.Errorf("%d: UnmarshalText(%q) returned %v", i, one.text, err)
+		} else if err == nil && unmarshaled != one.color {
+			t.Errorf("%d: UnmarshalText(%q) produced %08X, expected %08X", i, one.text, uint32(unmarshaled), uint32(one.color))
+		}
+	}
+}
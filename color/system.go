@@ -3,6 +3,8 @@ package color
 var (
 	// Background is the system color used for the window background.
 	Background = RGB(236, 236, 236)
+	// ControlBackground is the system color used for the background of controls, such as buttons.
+	ControlBackground = White
 	// KeyboardFocus is the system color used to highlight controls that have the keyboard focus.
 	KeyboardFocus = RGB(59, 153, 252)
 	// SelectedTextBackground is the system color used for the background of selected text.
@@ -13,4 +15,20 @@ var (
 	TextBackground = White
 	// Text is the system color used for the text in editable text areas.
 	Text = Black
+	// TextWhenLight is the system color used for text and marks when the background is considered to be 'light'.
+	TextWhenLight = Black
+	// TextWhenDark is the system color used for text and marks when the background is considered to be 'dark'.
+	TextWhenDark = White
+	// TextWhenDisabled is the system color used for text and marks when disabled.
+	TextWhenDisabled = Gray
+	// Divider is the system color used for dividers and the outlines of editable text areas.
+	Divider = Background.AdjustBrightness(-0.25)
+	// MenuBorder is the system color used for the border of menus.
+	MenuBorder = Gray
+	// InvalidTextBackground is the system color used for the background of editable text areas marked invalid.
+	InvalidTextBackground = RGB(255, 232, 232)
+	// ToolTipBackground is the system color used for the background of tooltips.
+	ToolTipBackground = LightYellow
+	// ToolTipBorder is the system color used for the border of tooltips.
+	ToolTipBorder = DarkGray
 )
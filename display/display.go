@@ -1,10 +1,132 @@
 package display
 
 import (
+	"math"
+
 	"github.com/richardwilkes/toolbox/xmath/geom"
 )
 
-// MainBounds returns the bounds of the main display.
+// Display holds information about a monitor attached to the system.
+type Display struct {
+	Bounds       geom.Rect // The total area of the display.
+	UsableBounds geom.Rect // The area of the display not covered by system elements, such as panels and docks.
+	ScaleFactor  float64   // The number of device pixels per unit of the display's coordinate space.
+	Primary      bool      // True if this is the primary display.
+}
+
+// Displays returns the displays currently attached to the system. The primary display is always
+// first. The result may be empty, such as while all monitors are switched off or disconnected.
+func Displays() []*Display {
+	return platformDisplays()
+}
+
+// Primary returns the primary display, or nil if there are no displays.
+func Primary() *Display {
+	if displays := Displays(); len(displays) != 0 {
+		return displays[0]
+	}
+	return nil
+}
+
+// MainBounds returns the bounds of the main display, or an empty rectangle if there are no
+// displays.
 func MainBounds() geom.Rect {
-	return platformMainDisplayBounds()
+	if primary := Primary(); primary != nil {
+		return primary.Bounds
+	}
+	return geom.Rect{}
+}
+
+// MaxScaleFactor returns the largest scale factor of all displays currently attached to the
+// system.
+func MaxScaleFactor() float64 {
+	scale := 1.0
+	for _, d := range Displays() {
+		if d.ScaleFactor > scale {
+			scale = d.ScaleFactor
+		}
+	}
+	return scale
+}
+
+// ForPoint returns the display containing the point. If no display contains the point, the
+// display nearest to it is returned. Returns nil if there are no displays.
+func ForPoint(pt geom.Point) *Display {
+	displays := Displays()
+	for _, d := range displays {
+		if d.Bounds.ContainsPoint(pt) {
+			return d
+		}
+	}
+	return nearest(displays, pt)
+}
+
+// ForRect returns the display that has the largest overlap with the rectangle. If no display
+// overlaps the rectangle, the display nearest to its center is returned. Returns nil if there are
+// no displays.
+func ForRect(r geom.Rect) *Display {
+	displays := Displays()
+	var best *Display
+	var bestArea float64
+	for _, d := range displays {
+		overlap := r
+		overlap.Intersect(d.Bounds)
+		if area := overlap.Width * overlap.Height; area > bestArea {
+			best = d
+			bestArea = area
+		}
+	}
+	if best == nil {
+		best = nearest(displays, geom.Point{X: r.X + r.Width/2, Y: r.Y + r.Height/2})
+	}
+	return best
+}
+
+// FitRectOnto returns the rectangle moved, and if necessary shrunk, so that it lies within the
+// usable bounds of the display it overlaps the most. If there are no displays, the rectangle is
+// returned unchanged.
+func FitRectOnto(r geom.Rect) geom.Rect {
+	if d := ForRect(r); d != nil {
+		return d.FitRect(r)
+	}
+	return r
+}
+
+// FitRect returns the rectangle moved, and if necessary shrunk, so that it lies within the usable
+// bounds of this display.
+func (d *Display) FitRect(r geom.Rect) geom.Rect {
+	usable := d.UsableBounds
+	if r.Width > usable.Width {
+		r.Width = usable.Width
+	}
+	if r.Height > usable.Height {
+		r.Height = usable.Height
+	}
+	if r.X+r.Width > usable.X+usable.Width {
+		r.X = usable.X + usable.Width - r.Width
+	}
+	if r.Y+r.Height > usable.Y+usable.Height {
+		r.Y = usable.Y + usable.Height - r.Height
+	}
+	if r.X < usable.X {
+		r.X = usable.X
+	}
+	if r.Y < usable.Y {
+		r.Y = usable.Y
+	}
+	return r
+}
+
+func nearest(displays []*Display, pt geom.Point) *Display {
+	var best *Display
+	bestDistance := math.MaxFloat64
+	for _, d := range displays {
+		dx := math.Max(math.Max(d.Bounds.X-pt.X, 0), pt.X-(d.Bounds.X+d.Bounds.Width))
+		dy := math.Max(math.Max(d.Bounds.Y-pt.Y, 0), pt.Y-(d.Bounds.Y+d.Bounds.Height))
+		if distance := dx*dx + dy*dy; distance < bestDistance {
+			best = d
+			bestDistance = distance
+		}
+	}
+	return best
 }
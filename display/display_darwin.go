@@ -5,12 +5,15 @@ import (
 	// #cgo LDFLAGS: -framework Cocoa
 	// #include "display_darwin.h"
 	"C"
-
-	"github.com/richardwilkes/toolbox/xmath/geom"
 )
 
-func platformMainDisplayBounds() geom.Rect {
-	var bounds geom.Rect
-	C.getMainDisplayBounds((*C.double)(&bounds.X), (*C.double)(&bounds.Y), (*C.double)(&bounds.Width), (*C.double)(&bounds.Height))
-	return bounds
+func platformDisplays() []*Display {
+	count := int(C.getDisplayCount())
+	displays := make([]*Display, count)
+	for i := 0; i < count; i++ {
+		d := &Display{Primary: i == 0}
+		C.getDisplay(C.int(i), (*C.double)(&d.Bounds.X), (*C.double)(&d.Bounds.Y), (*C.double)(&d.Bounds.Width), (*C.double)(&d.Bounds.Height), (*C.double)(&d.UsableBounds.X), (*C.double)(&d.UsableBounds.Y), (*C.double)(&d.UsableBounds.Width), (*C.double)(&d.UsableBounds.Height), (*C.double)(&d.ScaleFactor))
+		displays[i] = d
+	}
+	return displays
 }
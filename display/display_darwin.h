@@ -1,3 +1,4 @@
 #include <Cocoa/Cocoa.h>
 
-void getMainDisplayBounds(double *x, double *y, double *width, double *height);
+int getDisplayCount();
+void getDisplay(int index, double *x, double *y, double *width, double *height, double *usableX, double *usableY, double *usableWidth, double *usableHeight, double *scale);
@@ -1,9 +1,22 @@
 #include "display_darwin.h"
 
-void getMainDisplayBounds(double *x, double *y, double *width, double *height) {
-	NSRect bounds = [[NSScreen mainScreen] visibleFrame];
+int getDisplayCount() {
+	return [[NSScreen screens] count];
+}
+
+void getDisplay(int index, double *x, double *y, double *width, double *height, double *usableX, double *usableY, double *usableWidth, double *usableHeight, double *scale) {
+	NSScreen *screen = [[NSScreen screens] objectAtIndex:index];
+	// Flip the coordinates the same way the window code does
+	CGFloat top = [[NSScreen mainScreen] visibleFrame].size.height;
+	NSRect bounds = [screen frame];
 	*x = bounds.origin.x;
-	*y = bounds.origin.y;
+	*y = top - (bounds.origin.y + bounds.size.height);
 	*width = bounds.size.width;
 	*height = bounds.size.height;
+	bounds = [screen visibleFrame];
+	*usableX = bounds.origin.x;
+	*usableY = top - (bounds.origin.y + bounds.size.height);
+	*usableWidth = bounds.size.width;
+	*usableHeight = bounds.size.height;
+	*scale = [screen backingScaleFactor];
 }
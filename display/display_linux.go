@@ -1,13 +1,59 @@
 package display
 
-import "github.com/richardwilkes/toolbox/xmath/geom"
+import (
+	"os"
+	"strconv"
 
-func platformMainDisplayBounds() geom.Rect {
-	// RAW: Replace with implementation
-	return geom.Rect{
-		Size: geom.Size{
-			Width:  1024,
-			Height: 768,
-		},
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/internal/x11"
+)
+
+func platformDisplays() []*Display {
+	scale := scaleFactor()
+	if !x11.Running() {
+		bounds := geom.Rect{Size: geom.Size{Width: 1024, Height: 768}}
+		return []*Display{{Bounds: bounds, UsableBounds: bounds, ScaleFactor: scale, Primary: true}}
+	}
+	workArea, hasWorkArea := x11.WorkArea()
+	monitors := x11.Monitors()
+	displays := make([]*Display, 0, len(monitors))
+	hasPrimary := false
+	for _, monitor := range monitors {
+		// X11 reports device pixels, but our coordinates are logical units
+		bounds := toLogical(monitor.Bounds, scale)
+		d := &Display{Bounds: bounds, UsableBounds: bounds, ScaleFactor: scale, Primary: monitor.Primary && !hasPrimary}
+		if hasWorkArea {
+			// The work area spans all monitors, so clip it to this one
+			d.UsableBounds.Intersect(toLogical(workArea, scale))
+			if d.UsableBounds.IsEmpty() {
+				d.UsableBounds = d.Bounds
+			}
+		}
+		if d.Primary {
+			hasPrimary = true
+			displays = append([]*Display{d}, displays...)
+		} else {
+			displays = append(displays, d)
+		}
+	}
+	if !hasPrimary {
+		displays[0].Primary = true
+	}
+	return displays
+}
+
+func toLogical(r geom.Rect, scale float64) geom.Rect {
+	return geom.Rect{Point: geom.Point{X: r.X / scale, Y: r.Y / scale}, Size: geom.Size{Width: r.Width / scale, Height: r.Height / scale}}
+}
+
+// scaleFactor returns the scale factor the desktop has requested, either via
+// XSETTINGS or the GDK_SCALE environment variable.
+func scaleFactor() float64 {
+	if scale, ok := x11.XSettingInt("Gdk/WindowScalingFactor"); ok && scale > 0 {
+		return float64(scale)
+	}
+	if scale, err := strconv.ParseFloat(os.Getenv("GDK_SCALE"), 64); err == nil && scale > 0 {
+		return scale
 	}
+	return 1
 }
@@ -2,12 +2,13 @@ package display
 
 import "github.com/richardwilkes/toolbox/xmath/geom"
 
-func platformMainDisplayBounds() geom.Rect {
+func platformDisplays() []*Display {
 	// RAW: Replace with implementation
-	return geom.Rect{
+	bounds := geom.Rect{
 		Size: geom.Size{
-			Width: 1024,
+			Width:  1024,
 			Height: 768,
 		},
 	}
+	return []*Display{{Bounds: bounds, UsableBounds: bounds, ScaleFactor: 1, Primary: true}}
 }
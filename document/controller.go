@@ -0,0 +1,334 @@
+package document
+
+import (
+	"path/filepath"
+
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/log/jot"
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/keys"
+	"github.com/richardwilkes/ui/menu"
+	"github.com/richardwilkes/ui/menu/filemenu"
+	"github.com/richardwilkes/ui/window"
+)
+
+// Possible responses when asked whether to save unsaved changes.
+const (
+	Save SaveResponse = iota
+	Discard
+	Cancel
+)
+
+// SaveResponse is used to respond to requests to save unsaved changes.
+type SaveResponse int
+
+// Controller manages the set of open documents and provides the handlers for
+// the document-related items in the File menu.
+type Controller struct {
+	// NewDocument is called to create a new, empty document. Its window
+	// should not yet have been shown.
+	NewDocument func() Document
+	// ChooseFilesToOpen is called to ask the user which files to open.
+	// Return nil to cancel. If nil, the Open item is disabled.
+	ChooseFilesToOpen func() []string
+	// ChooseSavePath is called to ask the user where to save the document.
+	// Return an empty string to cancel. If nil, documents that have never
+	// been saved cannot be saved.
+	ChooseSavePath func(doc Document) string
+	// AskToSave is called to ask the user whether the unsaved changes in the
+	// document should be saved before it is closed. Must not be nil.
+	AskToSave func(doc Document) SaveResponse
+	// ReportError is called when a document cannot be loaded or saved, or
+	// when the recent files list cannot be persisted. If nil, the error is
+	// logged.
+	ReportError func(doc Document, err error)
+	// MaxRecentFiles is the maximum number of files that will be shown in
+	// the Open Recent menu.
+	MaxRecentFiles int
+	documents      []Document
+	recent         []string
+	recentMenus    map[menu.Bar]menu.Menu
+}
+
+// NewController creates a new document controller. 'newDocument' is called
+// to create new documents and 'askToSave' is called to ask the user whether
+// the unsaved changes in a document should be saved before it is closed. The
+// controller will also ask when the application is asked to quit.
+func NewController(newDocument func() Document, askToSave func(doc Document) SaveResponse) *Controller {
+	c := &Controller{
+		NewDocument:    newDocument,
+		AskToSave:      askToSave,
+		MaxRecentFiles: 10,
+		recentMenus:    make(map[menu.Bar]menu.Menu),
+	}
+	c.loadRecentFiles()
+	event.GlobalTarget().EventHandlers().Add(event.AppQuitRequestedType, c.quitRequested)
+	return c
+}
+
+// Documents returns the documents that are currently open.
+func (c *Controller) Documents() []Document {
+	return append([]Document(nil), c.documents...)
+}
+
+// Current returns the document displayed in the key window, or nil.
+func (c *Controller) Current() Document {
+	if wnd := window.KeyWindow(); wnd != nil {
+		for _, doc := range c.documents {
+			if doc.Window() == wnd {
+				return doc
+			}
+		}
+	}
+	return nil
+}
+
+// Find returns the open document associated with the path, or nil.
+func (c *Controller) Find(path string) Document {
+	path = canonicalPath(path)
+	for _, doc := range c.documents {
+		if docPath := doc.Path(); docPath != "" && canonicalPath(docPath) == path {
+			return doc
+		}
+	}
+	return nil
+}
+
+// Install adds a standard 'File' menu with items for the document-related
+// actions to the end of the menu bar.
+func (c *Controller) Install(bar menu.Bar) menu.Menu {
+	fileMenu := menu.NewMenu(i18n.Text("File"))
+
+	fileMenu.AppendItem(menu.NewItemWithKey(i18n.Text("New"), keys.VirtualKeyN, c.New))
+
+	item := menu.NewItemWithKey(i18n.Text("Open…"), keys.VirtualKeyO, c.Open)
+	item.EventHandlers().Add(event.ValidateType, c.CanOpen)
+	fileMenu.AppendItem(item)
+
+	recentMenu := menu.NewMenu(i18n.Text("Open Recent"))
+	c.recentMenus[bar] = recentMenu
+	if actual, ok := bar.(ui.Widget); ok {
+		// The bar belongs to a window, rather than the application, so
+		// forget it when the window goes away
+		if wnd := actual.Window(); wnd != nil {
+			wnd.EventHandlers().Add(event.ClosedType, func(evt event.Event) { delete(c.recentMenus, bar) })
+		}
+	}
+	c.rebuildRecentMenu(recentMenu)
+	fileMenu.AppendMenu(recentMenu)
+
+	fileMenu.AppendItem(menu.NewSeparator())
+	fileMenu.AppendItem(filemenu.NewCloseKeyWindowItem())
+
+	item = menu.NewItemWithKey(i18n.Text("Save"), keys.VirtualKeyS, c.Save)
+	item.EventHandlers().Add(event.ValidateType, c.CanSave)
+	fileMenu.AppendItem(item)
+
+	item = menu.NewItemWithKeyAndModifiers(i18n.Text("Save As…"), keys.VirtualKeyS, keys.ShiftModifier|keys.PlatformMenuModifier(), c.SaveAs)
+	item.EventHandlers().Add(event.ValidateType, c.CanSaveAs)
+	fileMenu.AppendItem(item)
+
+	item = menu.NewItem(i18n.Text("Revert"), c.Revert)
+	item.EventHandlers().Add(event.ValidateType, c.CanRevert)
+	fileMenu.AppendItem(item)
+
+	bar.AppendMenu(fileMenu)
+	return fileMenu
+}
+
+// New creates a new, empty document and shows it.
+func (c *Controller) New(evt event.Event) {
+	doc := c.NewDocument()
+	c.add(doc)
+	doc.Window().ToFront()
+}
+
+// Open asks the user which files to open and opens them.
+func (c *Controller) Open(evt event.Event) {
+	if c.ChooseFilesToOpen != nil {
+		for _, path := range c.ChooseFilesToOpen() {
+			c.OpenPath(path)
+		}
+	}
+}
+
+// CanOpen marks the menu item invalid if there is no way to ask the user
+// which files to open.
+func (c *Controller) CanOpen(evt event.Event) {
+	if c.ChooseFilesToOpen == nil {
+		evt.(*event.Validate).MarkInvalid()
+	}
+}
+
+// OpenPath opens the file at the path and shows it. If the file is already
+// open, its window is brought to the front instead. Returns the document, or
+// nil if it could not be loaded.
+func (c *Controller) OpenPath(path string) Document {
+	if doc := c.Find(path); doc != nil {
+		doc.Window().ToFront()
+		c.addRecentFile(path)
+		return doc
+	}
+	doc := c.NewDocument()
+	if err := doc.Load(path); err != nil {
+		doc.Window().Close()
+		c.reportError(doc, err)
+		return nil
+	}
+	doc.SetPath(path)
+	doc.SetModified(false)
+	c.add(doc)
+	doc.Window().ToFront()
+	c.addRecentFile(path)
+	return doc
+}
+
+// Save the current document, asking the user for a location if it has
+// never been saved.
+func (c *Controller) Save(evt event.Event) {
+	if doc := c.Current(); doc != nil {
+		c.SaveDocument(doc)
+	}
+}
+
+// CanSave marks the menu item invalid if there is no current document or it
+// has no unsaved changes.
+func (c *Controller) CanSave(evt event.Event) {
+	if doc := c.Current(); doc == nil || !doc.Modified() {
+		evt.(*event.Validate).MarkInvalid()
+	}
+}
+
+// SaveAs asks the user for a new location for the current document and
+// saves it there.
+func (c *Controller) SaveAs(evt event.Event) {
+	if doc := c.Current(); doc != nil {
+		c.SaveDocumentAs(doc)
+	}
+}
+
+// CanSaveAs marks the menu item invalid if there is no current document or
+// no way to ask the user for a location.
+func (c *Controller) CanSaveAs(evt event.Event) {
+	if c.Current() == nil || c.ChooseSavePath == nil {
+		evt.(*event.Validate).MarkInvalid()
+	}
+}
+
+// Revert discards the unsaved changes in the current document by reloading
+// it from its file.
+func (c *Controller) Revert(evt event.Event) {
+	if doc := c.Current(); doc != nil && doc.Path() != "" {
+		if err := doc.Load(doc.Path()); err != nil {
+			c.reportError(doc, err)
+			return
+		}
+		doc.SetModified(false)
+	}
+}
+
+// CanRevert marks the menu item invalid if there is no current document, it
+// has never been saved, or it has no unsaved changes.
+func (c *Controller) CanRevert(evt event.Event) {
+	if doc := c.Current(); doc == nil || doc.Path() == "" || !doc.Modified() {
+		evt.(*event.Validate).MarkInvalid()
+	}
+}
+
+// SaveDocument saves the document, asking the user for a location if it has
+// never been saved. Returns true if the document was saved.
+func (c *Controller) SaveDocument(doc Document) bool {
+	if doc.Path() == "" {
+		return c.SaveDocumentAs(doc)
+	}
+	return c.saveTo(doc, doc.Path())
+}
+
+// SaveDocumentAs asks the user for a new location for the document and
+// saves it there. Returns true if the document was saved.
+func (c *Controller) SaveDocumentAs(doc Document) bool {
+	if c.ChooseSavePath == nil {
+		return false
+	}
+	path := c.ChooseSavePath(doc)
+	if path == "" {
+		return false
+	}
+	return c.saveTo(doc, path)
+}
+
+func (c *Controller) saveTo(doc Document, path string) bool {
+	if err := doc.Save(path); err != nil {
+		c.reportError(doc, err)
+		return false
+	}
+	doc.SetPath(path)
+	doc.SetModified(false)
+	c.addRecentFile(path)
+	return true
+}
+
+// MayClose returns true if the document may be closed. If the document has
+// unsaved changes, the user will be asked whether they should be saved first.
+func (c *Controller) MayClose(doc Document) bool {
+	if !doc.Modified() {
+		return true
+	}
+	doc.Window().ToFront()
+	switch c.AskToSave(doc) {
+	case Save:
+		return c.SaveDocument(doc)
+	case Discard:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *Controller) add(doc Document) {
+	c.documents = append(c.documents, doc)
+	handlers := doc.Window().EventHandlers()
+	handlers.Add(event.ClosingType, func(evt event.Event) {
+		if !c.MayClose(doc) {
+			evt.(*event.Closing).Abort()
+		}
+	})
+	handlers.Add(event.ClosedType, func(evt event.Event) { c.remove(doc) })
+}
+
+func (c *Controller) remove(doc Document) {
+	for i, one := range c.documents {
+		if one == doc {
+			copy(c.documents[i:], c.documents[i+1:])
+			count := len(c.documents) - 1
+			c.documents[count] = nil
+			c.documents = c.documents[:count]
+			break
+		}
+	}
+}
+
+func (c *Controller) quitRequested(evt event.Event) {
+	for _, doc := range c.Documents() {
+		if !c.MayClose(doc) {
+			evt.(*event.AppQuitRequested).Cancel()
+			return
+		}
+	}
+}
+
+func (c *Controller) reportError(doc Document, err error) {
+	if c.ReportError != nil {
+		c.ReportError(doc, err)
+	} else {
+		jot.Error(err)
+	}
+}
+
+func canonicalPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
@@ -0,0 +1,111 @@
+package document
+
+import (
+	"path/filepath"
+
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/event"
+)
+
+var (
+	// EditedMarker is prefixed to the window title of documents that have
+	// unsaved changes.
+	EditedMarker = "• "
+)
+
+// Document defines the methods a document must provide to be managed by a
+// Controller.
+type Document interface {
+	// Window returns the window the document is displayed in.
+	Window() ui.Window
+	// Title returns the title of the document, without any edited marker.
+	Title() string
+	// Path returns the file the document is associated with, or an empty
+	// string if it has never been saved.
+	Path() string
+	// SetPath sets the file the document is associated with.
+	SetPath(path string)
+	// Load replaces the document's content with the content of the file at
+	// the specified path.
+	Load(path string) error
+	// Save writes the document's content to the file at the specified path.
+	Save(path string) error
+	// Modified returns true if the document has unsaved changes.
+	Modified() bool
+	// SetModified sets whether the document has unsaved changes.
+	SetModified(modified bool)
+}
+
+// Base provides the parts of a Document that are common to most documents:
+// the window, path, title and modification tracking. Embed it and supply
+// Load() and Save() to complete the Document interface.
+type Base struct {
+	wnd      ui.Window
+	path     string
+	modified bool
+}
+
+// InitDocument initializes the document with the window it is displayed in.
+// The window's title will be kept in sync with the document's title and
+// modification state.
+func (doc *Base) InitDocument(wnd ui.Window) {
+	doc.wnd = wnd
+	doc.updateTitle()
+}
+
+// Window returns the window the document is displayed in.
+func (doc *Base) Window() ui.Window {
+	return doc.wnd
+}
+
+// Title returns the title of the document, without any edited marker.
+func (doc *Base) Title() string {
+	if doc.path == "" {
+		return i18n.Text("Untitled")
+	}
+	return filepath.Base(doc.path)
+}
+
+// Path returns the file the document is associated with, or an empty string
+// if it has never been saved.
+func (doc *Base) Path() string {
+	return doc.path
+}
+
+// SetPath sets the file the document is associated with.
+func (doc *Base) SetPath(path string) {
+	if doc.path != path {
+		doc.path = path
+		doc.updateTitle()
+	}
+}
+
+// Modified returns true if the document has unsaved changes.
+func (doc *Base) Modified() bool {
+	return doc.modified
+}
+
+// SetModified sets whether the document has unsaved changes.
+func (doc *Base) SetModified(modified bool) {
+	if doc.modified != modified {
+		doc.modified = modified
+		doc.updateTitle()
+	}
+}
+
+// TrackModifications marks the document as modified whenever the target
+// generates a Modified event.
+func (doc *Base) TrackModifications(target event.Target) {
+	target.EventHandlers().Add(event.ModifiedType, func(evt event.Event) { doc.SetModified(true) })
+}
+
+func (doc *Base) updateTitle() {
+	if doc.wnd != nil {
+		title := doc.Title()
+		if doc.modified {
+			title = EditedMarker + title
+		}
+		doc.wnd.SetTitle(title)
+	}
+}
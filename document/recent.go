@@ -0,0 +1,115 @@
+package document
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio/fs/paths"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/menu"
+)
+
+const recentFilesName = "recent_files.json"
+
+// RecentFiles returns the files that have been opened or saved recently,
+// most recent first.
+func (c *Controller) RecentFiles() []string {
+	return append([]string(nil), c.recent...)
+}
+
+// ClearRecentFiles empties the list of recent files.
+func (c *Controller) ClearRecentFiles() {
+	c.recent = nil
+	c.recentFilesChanged()
+}
+
+func (c *Controller) addRecentFile(path string) {
+	path = canonicalPath(path)
+	list := make([]string, 0, len(c.recent)+1)
+	list = append(list, path)
+	for _, one := range c.recent {
+		if one != path {
+			list = append(list, one)
+		}
+	}
+	if c.MaxRecentFiles > 0 && len(list) > c.MaxRecentFiles {
+		list = list[:c.MaxRecentFiles]
+	}
+	c.recent = list
+	c.recentFilesChanged()
+}
+
+func (c *Controller) recentFilesChanged() {
+	for _, recentMenu := range c.recentMenus {
+		c.rebuildRecentMenu(recentMenu)
+	}
+	if err := c.saveRecentFiles(); err != nil {
+		c.reportError(nil, err)
+	}
+}
+
+func (c *Controller) rebuildRecentMenu(recentMenu menu.Menu) {
+	for i := recentMenu.Count() - 1; i >= 0; i-- {
+		item := recentMenu.Item(i)
+		recentMenu.Remove(i)
+		item.Dispose()
+	}
+	for _, one := range c.recent {
+		path := one
+		item := menu.NewItem(filepath.Base(path), func(evt event.Event) { c.OpenPath(path) })
+		item.EventHandlers().Add(event.ValidateType, func(evt event.Event) {
+			if _, err := os.Stat(path); err != nil {
+				evt.(*event.Validate).MarkInvalid()
+			}
+		})
+		recentMenu.AppendItem(item)
+	}
+	if len(c.recent) > 0 {
+		recentMenu.AppendItem(menu.NewSeparator())
+	}
+	item := menu.NewItem(i18n.Text("Clear Menu"), func(evt event.Event) { c.ClearRecentFiles() })
+	item.EventHandlers().Add(event.ValidateType, func(evt event.Event) {
+		if len(c.recent) == 0 {
+			evt.(*event.Validate).MarkInvalid()
+		}
+	})
+	recentMenu.AppendItem(item)
+}
+
+func recentFilesPath() string {
+	return filepath.Join(paths.AppDataDir(), recentFilesName)
+}
+
+func (c *Controller) loadRecentFiles() {
+	data, err := ioutil.ReadFile(recentFilesPath())
+	if err != nil {
+		return
+	}
+	var list []string
+	if err = json.Unmarshal(data, &list); err != nil {
+		return
+	}
+	if c.MaxRecentFiles > 0 && len(list) > c.MaxRecentFiles {
+		list = list[:c.MaxRecentFiles]
+	}
+	c.recent = list
+}
+
+func (c *Controller) saveRecentFiles() error {
+	data, err := json.Marshal(c.recent)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	path := recentFilesPath()
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errs.Wrap(err)
+	}
+	if err = ioutil.WriteFile(path, data, 0644); err != nil {
+		return errs.Wrap(err)
+	}
+	return nil
+}
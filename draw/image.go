@@ -10,7 +10,9 @@ import (
 	_ "image/jpeg" // Support loading of JPEG
 	_ "image/png"  // Support loading of PNG
 	"io"
+	"math"
 	"net/http"
+	"path/filepath"
 	"sync"
 	"unsafe"
 
@@ -36,16 +38,21 @@ type Image struct {
 	disabledID uint64
 	width      int
 	height     int
+	scale      float64
 	surface    *C.cairo_surface_t
 	key        interface{}
 }
 
 var (
-	imageRegistryLock sync.Mutex
-	imageRegistry     = make(map[interface{}]*imgRef)
+	// PreferredImageScale holds the scale factor that AcquireImageFromFile prefers when choosing
+	// amongst multiple resolutions of an image. This is normally kept up-to-date with the largest
+	// scale factor of the attached displays.
+	PreferredImageScale = 1.0
+	imageRegistryLock   sync.Mutex
+	imageRegistry       = make(map[interface{}]*imgRef)
 )
 
-func loadFromStream(key interface{}, stream io.ReadCloser) (ref *imgRef, err error) {
+func loadFromStream(key interface{}, stream io.ReadCloser, scale float64) (ref *imgRef, err error) {
 	defer func() {
 		if serr := stream.Close(); serr != nil && err == nil {
 			err = errs.Wrap(serr)
@@ -67,11 +74,16 @@ func loadFromStream(key interface{}, stream io.ReadCloser) (ref *imgRef, err err
 	}
 	C.cairo_surface_mark_dirty(surface)
 	img := &Image{width: bounds.Dx(), height: bounds.Dy(), surface: surface, key: key}
+	img.setScale(scale)
 	img.InitTypeAndID(img)
 	return &imgRef{img: img}, nil
 }
 
-// AcquireImageFromFile attempts to load an image from the file system.
+// AcquireImageFromFile attempts to load an image from the file system. If PreferredImageScale is
+// greater than 1, higher resolution variants of the image are looked for first, using the
+// convention of appending "@2x", "@3x", etc. to the file name before its extension. For example,
+// with a PreferredImageScale of 2, "icon@2x.png" will be used in place of "icon.png" if it exists.
+// The size of an image loaded from such a variant is the same as that of the original.
 func AcquireImageFromFile(fs http.FileSystem, path string) (img *Image, err error) {
 	imageRegistryLock.Lock()
 	defer imageRegistryLock.Unlock()
@@ -79,11 +91,13 @@ func AcquireImageFromFile(fs http.FileSystem, path string) (img *Image, err erro
 	var ok bool
 	key := fsKey{fs: fs, path: path}
 	if ref, ok = imageRegistry[key]; !ok {
-		var file http.File
-		if file, err = fs.Open(path); err != nil {
-			return nil, errs.Wrap(err)
+		file, scale := openScaledImageFile(fs, path)
+		if file == nil {
+			if file, err = fs.Open(path); err != nil {
+				return nil, errs.Wrap(err)
+			}
 		}
-		if ref, err = loadFromStream(key, file); err != nil {
+		if ref, err = loadFromStream(key, file, scale); err != nil {
 			return nil, err
 		}
 		imageRegistry[key] = ref
@@ -92,6 +106,18 @@ func AcquireImageFromFile(fs http.FileSystem, path string) (img *Image, err erro
 	return ref.img, nil
 }
 
+func openScaledImageFile(fs http.FileSystem, path string) (file http.File, scale float64) {
+	ext := filepath.Ext(path)
+	base := path[:len(path)-len(ext)]
+	for i := int(math.Ceil(PreferredImageScale)); i > 1; i-- {
+		var err error
+		if file, err = fs.Open(fmt.Sprintf("%s@%dx%s", base, i, ext)); err == nil {
+			return file, float64(i)
+		}
+	}
+	return nil, 1
+}
+
 // AcquireImageFromURL attempts to load an image from a URL.
 func AcquireImageFromURL(url string) (img *Image, err error) {
 	imageRegistryLock.Lock()
@@ -103,7 +129,7 @@ func AcquireImageFromURL(url string) (img *Image, err error) {
 		if resp, err = http.Get(url); err != nil {
 			return nil, errs.Wrap(err)
 		}
-		if ref, err = loadFromStream(url, resp.Body); err != nil {
+		if ref, err = loadFromStream(url, resp.Body, 1); err != nil {
 			return nil, err
 		}
 		imageRegistry[url] = ref
@@ -135,7 +161,7 @@ func AcquireImageFromData(data *ImageData) *Image {
 		}
 	}
 	C.cairo_surface_mark_dirty(surface)
-	img := &Image{width: data.Width, height: data.Height, surface: surface}
+	img := &Image{width: data.Width, height: data.Height, scale: 1, surface: surface}
 	img.InitTypeAndID(img)
 	img.key = img.ID()
 	ref := &imgRef{img: img, count: 1}
@@ -147,7 +173,7 @@ func AcquireImageFromData(data *ImageData) *Image {
 
 // NewImage creates a new image.
 func NewImage(width, height int) *Image {
-	img := &Image{width: width, height: height, surface: C.cairo_image_surface_create(C.CAIRO_FORMAT_ARGB32, C.int(width), C.int(height))}
+	img := &Image{width: width, height: height, scale: 1, surface: C.cairo_image_surface_create(C.CAIRO_FORMAT_ARGB32, C.int(width), C.int(height))}
 	img.InitTypeAndID(img)
 	img.key = img.ID()
 	ref := &imgRef{img: img, count: 1}
@@ -176,16 +202,28 @@ func (img *Image) AcquireDisabled() *Image {
 		data.Pixels[i] = color.RGBA(v, v, v, p.AlphaIntensity()*0.4)
 	}
 	image = AcquireImageFromData(data)
+	image.setScale(img.scale)
 	img.disabledID = image.ID()
 	return image
 }
 
-// Size returns the size of the image.
+// Size returns the size of the image in logical units. For images loaded from higher resolution
+// variants, this is the size in pixels divided by Scale().
 func (img *Image) Size() geom.Size {
-	return geom.Size{Width: float64(img.width), Height: float64(img.height)}
+	return geom.Size{Width: float64(img.width) / img.scale, Height: float64(img.height) / img.scale}
+}
+
+// Scale returns the number of pixels per logical unit in the image.
+func (img *Image) Scale() float64 {
+	return img.scale
+}
+
+func (img *Image) setScale(scale float64) {
+	img.scale = scale
+	C.cairo_surface_set_device_scale(img.surface, C.double(scale), C.double(scale))
 }
 
-// Data extracts the raw image data.
+// Data extracts the raw image data. The data is always at the full pixel resolution of the image.
 func (img *Image) Data() *ImageData {
 	data := &ImageData{Width: img.width, Height: img.height, Pixels: make([]color.Color, img.width*img.height)}
 	stride := int(C.cairo_image_surface_get_stride(img.surface)) / 4
@@ -4,6 +4,7 @@ import (
 	// #cgo pkg-config: pangocairo
 	// #include <pango/pangocairo.h>
 	"C"
+	"math"
 
 	"github.com/richardwilkes/toolbox/xmath/geom"
 )
@@ -22,13 +23,20 @@ const (
 type Surface struct {
 	surface *C.cairo_surface_t
 	size    geom.Size
+	scale   float64
 }
 
-// Size returns the size in pixels of the surface.
+// Size returns the size of the surface in logical units. Multiply by Scale() to obtain the size in
+// device pixels.
 func (surface *Surface) Size() geom.Size {
 	return surface.size
 }
 
+// Scale returns the number of device pixels per logical unit.
+func (surface *Surface) Scale() float64 {
+	return surface.scale
+}
+
 // Destroy a surface.
 func (surface *Surface) Destroy() {
 	C.cairo_surface_destroy(surface.surface)
@@ -40,7 +48,8 @@ func (surface *Surface) NewCairoContext() CairoContext {
 }
 
 // CreateSimilar creates a new surface similar to this surface, but with the specified
-// content type and size.
+// content type and size. The size is in logical units and the new surface inherits the scale of
+// this surface.
 func (surface *Surface) CreateSimilar(contentType CairoContentType, size geom.Size) *Surface {
-	return &Surface{surface: C.cairo_surface_create_similar(surface.surface, C.cairo_content_t(contentType), C.int(size.Width), C.int(size.Height)), size: size}
+	return &Surface{surface: C.cairo_surface_create_similar(surface.surface, C.cairo_content_t(contentType), C.int(math.Ceil(size.Width)), C.int(math.Ceil(size.Height))), size: size, scale: surface.scale}
 }
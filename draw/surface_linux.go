@@ -1,21 +1,41 @@
 package draw
 
 import (
-	// #cgo pkg-config: pangocairo
+	// #cgo pkg-config: pangocairo cairo-xlib
 	// #include <pango/pangocairo.h>
+	// #include <cairo/cairo-xlib.h>
 	"C"
+	"math"
 	"unsafe"
 
 	"github.com/richardwilkes/toolbox/xmath/geom"
 )
 
-func NewSurface(surface unsafe.Pointer, size geom.Size) *Surface {
-	return &Surface{surface: (*C.cairo_surface_t)(surface), size: size}
+// NewSurface wraps an existing Cairo Xlib surface. The size is in logical units, while the
+// underlying surface is expected to have been created with a size of size * scale device pixels.
+func NewSurface(surface unsafe.Pointer, size geom.Size, scale float64) *Surface {
+	s := &Surface{surface: (*C.cairo_surface_t)(surface), size: size, scale: scale}
+	C.cairo_surface_set_device_scale(s.surface, C.double(scale), C.double(scale))
+	return s
 }
 
+// SetSize sets the size, in logical units, of the surface.
 func (surface *Surface) SetSize(size geom.Size) {
 	if surface.size != size {
 		surface.size = size
-		C.cairo_xlib_surface_set_size(surface.surface, C.int(size.Width), C.int(size.Height))
+		surface.updateDeviceSize()
 	}
 }
+
+// SetScale sets the number of device pixels per logical unit, keeping the logical size.
+func (surface *Surface) SetScale(scale float64) {
+	if surface.scale != scale {
+		surface.scale = scale
+		C.cairo_surface_set_device_scale(surface.surface, C.double(scale), C.double(scale))
+		surface.updateDeviceSize()
+	}
+}
+
+func (surface *Surface) updateDeviceSize() {
+	C.cairo_xlib_surface_set_size(surface.surface, C.int(math.Ceil(surface.size.Width*surface.scale)), C.int(math.Ceil(surface.size.Height*surface.scale)))
+}
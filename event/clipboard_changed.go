@@ -0,0 +1,63 @@
+package event
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// ClipboardChanged is generated when the contents of the clipboard change,
+// whether by this application or another one. It is also generated when the
+// primary selection changes. Currently, this event is only generated on
+// Linux, and only when the X server supports the XFixes extension.
+type ClipboardChanged struct {
+	target   Target
+	primary  bool
+	finished bool
+}
+
+// SendClipboardChanged sends a new ClipboardChanged event. 'primary' should
+// be true if the primary selection changed rather than the clipboard.
+func SendClipboardChanged(primary bool) {
+	Dispatch(&ClipboardChanged{target: GlobalTarget(), primary: primary})
+}
+
+// Type returns the event type ID.
+func (e *ClipboardChanged) Type() Type {
+	return ClipboardChangedType
+}
+
+// Target the original target of the event.
+func (e *ClipboardChanged) Target() Target {
+	return e.target
+}
+
+// Cascade returns true if this event should be passed to its target's parent if not marked done.
+func (e *ClipboardChanged) Cascade() bool {
+	return false
+}
+
+// Finished returns true if this event has been handled and should no longer be processed.
+func (e *ClipboardChanged) Finished() bool {
+	return e.finished
+}
+
+// Finish marks this event as handled and no longer eligible for processing.
+func (e *ClipboardChanged) Finish() {
+	e.finished = true
+}
+
+// Primary returns true if the primary selection changed rather than the clipboard.
+func (e *ClipboardChanged) Primary() bool {
+	return e.primary
+}
+
+// String implements the fmt.Stringer interface.
+func (e *ClipboardChanged) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("ClipboardChanged[Primary: %v", e.primary))
+	if e.finished {
+		buffer.WriteString(", Finished")
+	}
+	buffer.WriteString("]")
+	return buffer.String()
+}
@@ -0,0 +1,54 @@
+package event
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Commit is generated when the value being edited in a widget is committed, such as when the user
+// presses Enter or moves the focus elsewhere.
+type Commit struct {
+	target   Target
+	finished bool
+}
+
+// NewCommit creates a new Commit event. 'target' is the widget whose value was committed.
+func NewCommit(target Target) *Commit {
+	return &Commit{target: target}
+}
+
+// Type returns the event type ID.
+func (e *Commit) Type() Type {
+	return CommitType
+}
+
+// Target the original target of the event.
+func (e *Commit) Target() Target {
+	return e.target
+}
+
+// Cascade returns true if this event should be passed to its target's parent if not marked done.
+func (e *Commit) Cascade() bool {
+	return false
+}
+
+// Finished returns true if this event has been handled and should no longer be processed.
+func (e *Commit) Finished() bool {
+	return e.finished
+}
+
+// Finish marks this event as handled and no longer eligible for processing.
+func (e *Commit) Finish() {
+	e.finished = true
+}
+
+// String implements the fmt.Stringer interface.
+func (e *Commit) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("Commit[Target: %v", e.target))
+	if e.finished {
+		buffer.WriteString(", Finished")
+	}
+	buffer.WriteString("]")
+	return buffer.String()
+}
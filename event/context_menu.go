@@ -0,0 +1,78 @@
+package event
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+)
+
+// ContextMenu is generated when the user asks for a context menu, either by clicking the right
+// mouse button or by pressing the Menu key or Shift+F10. Handlers add items to the menu, which is
+// then shown at the event's location if it isn't empty. The event cascades, so parents can add
+// their own items after those added by their children.
+type ContextMenu struct {
+	target   Target
+	where    geom.Point
+	menu     interface{}
+	finished bool
+}
+
+// NewContextMenu creates a new ContextMenu event. 'target' is the widget the menu is being
+// requested for. 'where' is the location in the window the menu should be shown.
+func NewContextMenu(target Target, where geom.Point) *ContextMenu {
+	return &ContextMenu{target: target, where: where}
+}
+
+// Type returns the event type ID.
+func (e *ContextMenu) Type() Type {
+	return ContextMenuType
+}
+
+// Target the original target of the event.
+func (e *ContextMenu) Target() Target {
+	return e.target
+}
+
+// Cascade returns true if this event should be passed to its target's parent if not marked done.
+func (e *ContextMenu) Cascade() bool {
+	return true
+}
+
+// Finished returns true if this event has been handled and should no longer be processed.
+func (e *ContextMenu) Finished() bool {
+	return e.finished
+}
+
+// Finish marks this event as handled and no longer eligible for processing.
+func (e *ContextMenu) Finish() {
+	e.finished = true
+}
+
+// Where returns the location in the window the menu should be shown.
+func (e *ContextMenu) Where() geom.Point {
+	return e.where
+}
+
+// Menu returns the menu being assembled, or nil if no handler has asked for it yet. Handlers
+// should call menu.ForContextMenu() rather than this method, as this package cannot refer to the
+// menu types.
+func (e *ContextMenu) Menu() interface{} {
+	return e.menu
+}
+
+// SetMenu sets the menu being assembled.
+func (e *ContextMenu) SetMenu(menu interface{}) {
+	e.menu = menu
+}
+
+// String implements the fmt.Stringer interface.
+func (e *ContextMenu) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("ContextMenu[Where: [%v], Target: %v", e.where, e.target))
+	if e.finished {
+		buffer.WriteString(", Finished")
+	}
+	buffer.WriteString("]")
+	return buffer.String()
+}
@@ -0,0 +1,53 @@
+package event
+
+import (
+	"bytes"
+)
+
+// DisplaysChanged is generated when displays are added, removed or rearranged, or
+// when their usable areas change.
+type DisplaysChanged struct {
+	target   Target
+	finished bool
+}
+
+// SendDisplaysChanged sends a new DisplaysChanged event.
+func SendDisplaysChanged() {
+	Dispatch(&DisplaysChanged{target: GlobalTarget()})
+}
+
+// Type returns the event type ID.
+func (e *DisplaysChanged) Type() Type {
+	return DisplaysChangedType
+}
+
+// Target the original target of the event.
+func (e *DisplaysChanged) Target() Target {
+	return e.target
+}
+
+// Cascade returns true if this event should be passed to its target's parent if not marked done.
+func (e *DisplaysChanged) Cascade() bool {
+	return false
+}
+
+// Finished returns true if this event has been handled and should no longer be processed.
+func (e *DisplaysChanged) Finished() bool {
+	return e.finished
+}
+
+// Finish marks this event as handled and no longer eligible for processing.
+func (e *DisplaysChanged) Finish() {
+	e.finished = true
+}
+
+// String implements the fmt.Stringer interface.
+func (e *DisplaysChanged) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("DisplaysChanged[")
+	if e.finished {
+		buffer.WriteString("Finished")
+	}
+	buffer.WriteString("]")
+	return buffer.String()
+}
@@ -40,6 +40,13 @@ const (
 	ClosedType
 	ValidateType
 	ModifiedType
+	ThemeChangedType
+	DisplaysChangedType
+	PreeditType
+	ClipboardChangedType
+	ContextMenuType
+	ReorderType
+	CommitType
 	// UserType should be used as the base value for custom application
 	// events.
 	UserType = 10000
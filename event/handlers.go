@@ -26,6 +26,15 @@ func (eh *Handlers) Add(eventType Type, handler Handler) {
 	eh.handlers[eventType] = append(eh.handlers[eventType], handler)
 }
 
+// Prepend an event handler for an event type, so that it is called before any
+// handlers that were previously added for that event type.
+func (eh *Handlers) Prepend(eventType Type, handler Handler) {
+	if eh.handlers == nil {
+		eh.handlers = make(map[Type][]Handler)
+	}
+	eh.handlers[eventType] = append([]Handler{handler}, eh.handlers[eventType]...)
+}
+
 // Remove an event handler for an event type.
 func (eh *Handlers) Remove(eventType Type, handler Handler) {
 	if eh.handlers != nil {
@@ -0,0 +1,70 @@
+package event
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Preedit is generated when the text an input method is composing changes. The composing text has
+// not yet been committed, so widgets that accept text should display it at the text caret, but
+// should not insert it into their content. Once the input method commits the text, it arrives via
+// KeyDown events.
+type Preedit struct {
+	target   Target
+	text     string
+	caret    int
+	finished bool
+}
+
+// NewPreedit creates a new Preedit event. 'target' is the widget that has the keyboard focus.
+// 'text' is the text being composed, which will be empty when composition has ended. 'caret' is
+// the rune index of the caret within the text.
+func NewPreedit(target Target, text string, caret int) *Preedit {
+	return &Preedit{target: target, text: text, caret: caret}
+}
+
+// Type returns the event type ID.
+func (e *Preedit) Type() Type {
+	return PreeditType
+}
+
+// Target the original target of the event.
+func (e *Preedit) Target() Target {
+	return e.target
+}
+
+// Cascade returns true if this event should be passed to its target's parent if not marked done.
+func (e *Preedit) Cascade() bool {
+	return false
+}
+
+// Finished returns true if this event has been handled and should no longer be processed.
+func (e *Preedit) Finished() bool {
+	return e.finished
+}
+
+// Finish marks this event as handled and no longer eligible for processing.
+func (e *Preedit) Finish() {
+	e.finished = true
+}
+
+// Text returns the text being composed. Will be empty if composition has ended.
+func (e *Preedit) Text() string {
+	return e.text
+}
+
+// Caret returns the rune index of the caret within the text being composed.
+func (e *Preedit) Caret() int {
+	return e.caret
+}
+
+// String implements the fmt.Stringer interface.
+func (e *Preedit) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("Preedit[Target: %v, Text: '%s', Caret: %d", e.target, e.text, e.caret))
+	if e.finished {
+		buffer.WriteString(", Finished")
+	}
+	buffer.WriteString("]")
+	return buffer.String()
+}
@@ -0,0 +1,84 @@
+package event
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Reorder is generated when the user asks to move some of the rows within a widget to a new
+// location.
+type Reorder struct {
+	target   Target
+	indexes  []int
+	to       int
+	finished bool
+	aborted  bool
+}
+
+// NewReorder creates a new Reorder event. 'target' is the widget whose rows are being moved.
+// 'indexes' are the rows being moved, in ascending order. 'to' is the index of the row they will be
+// placed before, which may be equal to the number of rows to place them at the end.
+func NewReorder(target Target, indexes []int, to int) *Reorder {
+	return &Reorder{target: target, indexes: indexes, to: to}
+}
+
+// Type returns the event type ID.
+func (e *Reorder) Type() Type {
+	return ReorderType
+}
+
+// Target the original target of the event.
+func (e *Reorder) Target() Target {
+	return e.target
+}
+
+// Cascade returns true if this event should be passed to its target's parent if not marked done.
+func (e *Reorder) Cascade() bool {
+	return false
+}
+
+// Finished returns true if this event has been handled and should no longer be processed.
+func (e *Reorder) Finished() bool {
+	return e.finished
+}
+
+// Finish marks this event as handled and no longer eligible for processing.
+func (e *Reorder) Finish() {
+	e.finished = true
+}
+
+// Indexes returns the rows being moved, in ascending order.
+func (e *Reorder) Indexes() []int {
+	return e.indexes
+}
+
+// To returns the index of the row the moved rows will be placed before.
+func (e *Reorder) To() int {
+	return e.to
+}
+
+// Aborted returns true if the rows should not be moved.
+func (e *Reorder) Aborted() bool {
+	return e.aborted
+}
+
+// Abort marks this event as being aborted and done.
+func (e *Reorder) Abort() {
+	e.aborted = true
+	e.finished = true
+}
+
+// String implements the fmt.Stringer interface.
+func (e *Reorder) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString("Reorder[")
+	if e.aborted {
+		buffer.WriteString("Aborted, ")
+	}
+	buffer.WriteString(fmt.Sprintf("Target: %v, Indexes: %v, To: %d", e.target, e.indexes, e.to))
+	if e.finished {
+		buffer.WriteString(", Finished")
+	}
+	buffer.WriteString("]")
+	return buffer.String()
+}
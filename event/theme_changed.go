@@ -0,0 +1,56 @@
+package event
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// ThemeChanged is generated for each widget in each window when the active theme
+// changes, giving widgets that cache theme-derived values a chance to refresh
+// them.
+type ThemeChanged struct {
+	target   Target
+	finished bool
+}
+
+// NewThemeChanged creates a new ThemeChanged event. 'target' is the widget that
+// should refresh its theme-derived values.
+func NewThemeChanged(target Target) *ThemeChanged {
+	return &ThemeChanged{target: target}
+}
+
+// Type returns the event type ID.
+func (e *ThemeChanged) Type() Type {
+	return ThemeChangedType
+}
+
+// Target the original target of the event.
+func (e *ThemeChanged) Target() Target {
+	return e.target
+}
+
+// Cascade returns true if this event should be passed to its target's parent if not marked done.
+func (e *ThemeChanged) Cascade() bool {
+	return false
+}
+
+// Finished returns true if this event has been handled and should no longer be processed.
+func (e *ThemeChanged) Finished() bool {
+	return e.finished
+}
+
+// Finish marks this event as handled and no longer eligible for processing.
+func (e *ThemeChanged) Finish() {
+	e.finished = true
+}
+
+// String implements the fmt.Stringer interface.
+func (e *ThemeChanged) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("ThemeChanged[Target: %v", e.target))
+	if e.finished {
+		buffer.WriteString(", Finished")
+	}
+	buffer.WriteString("]")
+	return buffer.String()
+}
@@ -30,7 +30,9 @@ type Font struct {
 }
 
 func init() {
-	// Tell Pango we want our typographic points to be 1/72 of an inch.
+	// Tell Pango we want our typographic points to be 1/72 of an inch. Fonts are sized in logical
+	// units, the same as everything else we draw; any device scale factor is applied by the
+	// surface being drawn into.
 	C.pango_cairo_font_map_set_resolution((*C.PangoCairoFontMap)(C.pango_cairo_font_map_get_default()), 72)
 }
 
@@ -1,7 +1,9 @@
 package font
 
+import "math"
+
 func init() {
-	// RAW: Try to determine what the user set in system preferences...
+	// These are used until the desktop tells us what it would like via SetDesktopFont().
 	User = NewFont("Sans 12")
 	UserMonospaced = NewFont("Monospace 10")
 	System = NewFont("Sans 13")
@@ -13,3 +15,36 @@ func init() {
 	Menu = NewFont("Sans 14")
 	MenuCmdKey = NewFont("Sans 14")
 }
+
+// SetDesktopFont updates the standard fonts in place to be derived from the
+// font description the desktop has specified, e.g. "Cantarell 11". The
+// desktop's font sizes are in points at 'dpi' dots per inch, while ours are
+// at 72 dots per inch, so the size is scaled accordingly.
+func SetDesktopFont(desc string, dpi float64) {
+	base := NewFont(desc)
+	defer base.Dispose()
+	size := base.Size()
+	if size <= 0 {
+		return
+	}
+	size = math.Round(size * dpi / 72)
+	family := base.Family()
+	for _, one := range []struct {
+		font  *Font
+		delta float64
+	}{
+		{font: User},
+		{font: System, delta: 1},
+		{font: EmphasizedSystem, delta: 1},
+		{font: SmallSystem, delta: -1},
+		{font: SmallEmphasizedSystem, delta: -1},
+		{font: Views},
+		{font: Label, delta: -2},
+		{font: Menu, delta: 2},
+		{font: MenuCmdKey, delta: 2},
+	} {
+		one.font.SetFamily(family)
+		one.font.SetSize(size + one.delta)
+	}
+	UserMonospaced.SetSize(size - 2)
+}
@@ -2,4 +2,8 @@ module github.com/richardwilkes/ui
 
 go 1.12
 
-require github.com/richardwilkes/toolbox v1.2.0
+require (
+	github.com/BurntSushi/toml v0.3.0
+	github.com/richardwilkes/toolbox v1.2.0
+	gopkg.in/yaml.v2 v2.2.2
+)
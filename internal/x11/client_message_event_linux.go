@@ -30,6 +30,10 @@ func (evt *ClientMessageEvent) Protocol() Atom {
 	return *(*Atom)(unsafe.Pointer(&evt.data))
 }
 
+func (evt *ClientMessageEvent) Long(index int) int64 {
+	return int64((*[5]C.long)(unsafe.Pointer(&evt.data))[index])
+}
+
 func (evt *ClientMessageEvent) TaskID() uint64 {
 	return *(*uint64)(unsafe.Pointer(&evt.data))
 }
@@ -4,174 +4,556 @@ import (
 	// #cgo pkg-config: x11
 	// #include <X11/Xlib.h>
 	// #include <X11/Xatom.h>
+	// #include <poll.h>
 	"C"
+	"fmt"
+	"time"
 	"unsafe"
 
+	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/ui/clipboard/datatypes"
+	"github.com/richardwilkes/ui/internal/task"
+)
+
+const (
+	// outgoingTransferTimeout is the amount of time an incremental transfer
+	// to another client may sit idle before we abandon it.
+	outgoingTransferTimeout = time.Second * 10
+	// abandonedTransferLifetime is the amount of time we continue to watch
+	// for late replies to a transfer that timed out or was canceled.
+	abandonedTransferLifetime = time.Minute
+	// maxWaitSlice is the longest we block waiting for input from the X
+	// server before checking for events again, in case another thread has
+	// read them into the queue.
+	maxWaitSlice = time.Millisecond * 50
 )
 
 var (
-	clipboardWindow     Window
-	acquiredTime        C.Time
-	clipData            = make(map[string][]byte)
-	clipDataChangeCount int
+	// Clipboard is the selection used for explicit cut, copy and paste.
+	Clipboard = &Selection{data: make(map[string][]byte)}
+	// Primary is the selection holding the most recently selected text,
+	// which is traditionally pasted with the middle mouse button.
+	Primary                = &Selection{data: make(map[string][]byte)}
+	clipboardWindow        Window
+	incrAtom               Atom
+	incrChunkSize          int
+	transferPropertyCount  int
+	freeTransferProperties []Atom
+	incomingTransfers      []*incomingTransfer
+	outgoingTransfers      []*outgoingTransfer
 )
 
+// Selection provides access to one of the X11 selections.
+type Selection struct {
+	atom         Atom
+	owned        bool
+	acquiredTime C.Time
+	data         map[string][]byte
+	changeCount  int
+}
+
+// incomingTransfer tracks a request for the contents of a selection from
+// another client.
+type incomingTransfer struct {
+	selection *Selection
+	targets   []Atom // The target to request, followed by any fallbacks
+	property  Atom
+	timer     *time.Timer
+	done      func(t *incomingTransfer)
+	data      []byte
+	dataType  Atom
+	format    int
+	err       error
+	incr      bool
+	finished  bool
+	abandoned time.Time // When the transfer timed out or was canceled, if it did.
+}
+
+// outgoingTransfer tracks an incremental transfer of our selection contents
+// to another client.
+type outgoingTransfer struct {
+	requestor    Window
+	property     Atom
+	propertyType Atom
+	data         []byte
+	offset       int
+	lastActivity time.Time
+}
+
 func initClipboard() {
+	incrAtom = InternAtom("INCR")
+	size := int(C.XExtendedMaxRequestSize(display))
+	if size == 0 {
+		size = int(C.XMaxRequestSize(display))
+	}
+	// The request size is in 4-byte units, so using it as a byte count
+	// limits each chunk to a quarter of the maximum, leaving plenty of room
+	// for the request overhead.
+	incrChunkSize = size
+	Clipboard.atom = clipboardAtom
+	Primary.atom = C.XA_PRIMARY
 	clipboardWindow = Window(C.XCreateWindow(display, C.Window(DefaultRootWindow()), 0, 0, 1, 1, 0, C.CopyFromParent, C.InputOnly, nil, 0, nil))
-	clipboardWindow.SelectInput(PropertyNotifyType | SelectionClearType | SelectionRequestType | SelectionNotifyType)
+	clipboardWindow.SelectInput(PropertyChangeMask)
+	initSelectionOwnerNotify()
 }
 
-func ownsClipboard() bool {
-	return acquiredTime != 0 && C.XGetSelectionOwner(display, C.Atom(clipboardAtom)) == C.Window(clipboardWindow)
+func selectionForAtom(atom Atom) *Selection {
+	switch atom {
+	case Clipboard.atom:
+		return Clipboard
+	case Primary.atom:
+		return Primary
+	default:
+		return nil
+	}
 }
 
-func ClipboardChangeCount() int {
-	if !ownsClipboard() {
-		// Someone else owns the clipboard, so increment the counter as we can't tell what the
-		// real state is.
-		clipDataChangeCount++
+func (s *Selection) owns() bool {
+	return s.owned && C.XGetSelectionOwner(display, C.Atom(s.atom)) == C.Window(clipboardWindow)
+}
+
+func (s *Selection) ChangeCount() int {
+	if !xfixesAvailable && !s.owns() {
+		// Someone else owns the selection and we aren't told when that changes, so increment the
+		// counter as we can't tell what the real state is.
+		s.changeCount++
 	}
-	return clipDataChangeCount
+	return s.changeCount
 }
 
-func ClipboardClear() {
-	clipData = make(map[string][]byte)
-	clipDataChangeCount++
-	claimSelectionOwnership()
+func (s *Selection) Clear() {
+	s.data = make(map[string][]byte)
+	s.changeCount++
+	s.claimOwnership()
 }
 
-func localClipboardTypes() []string {
-	types := make([]string, len(clipData))
+func (s *Selection) localTypes() []string {
+	types := make([]string, len(s.data))
 	i := 0
-	for key := range clipData {
+	for key := range s.data {
 		types[i] = key
 		i++
 	}
 	return types
 }
 
-func ClipboardTypes() []string {
-	if ownsClipboard() {
-		return localClipboardTypes()
+// Types returns the types of data available in the selection, waiting no
+// longer than the timeout for the selection owner to respond. The calling
+// thread is blocked while waiting, so the timeout should be short.
+func (s *Selection) Types(timeout time.Duration) []string {
+	if s.owns() {
+		return s.localTypes()
+	}
+	t := s.newIncomingTransfer([]Atom{targetsAtom}, nil)
+	t.wait(timeout)
+	result := make([]string, 0)
+	if t.err != nil || t.dataType != C.XA_ATOM || t.format != 32 || len(t.data) == 0 {
+		return result
 	}
-	clipboardWindow.DeleteProperty(clipboardAtom)
-	C.XConvertSelection(display, C.Atom(clipboardAtom), C.Atom(targetsAtom), C.Atom(clipboardAtom), C.Window(clipboardWindow), lastEventTime)
-	for {
-		evt := clipboardWindow.NextEventOfType(SelectionNotifyType)
-		if evt != nil {
-			selEvt := evt.ToSelectionEvent()
-			prop := selEvt.Property()
-			if prop == C.None {
-				return make([]string, 0)
+	count := len(t.data) / int(unsafe.Sizeof(C.Atom(0)))
+	names := make([]*C.char, count)
+	C.XGetAtomNames(display, (*C.Atom)(unsafe.Pointer(&t.data[0])), C.int(count), &names[0])
+	seen := make(map[string]bool)
+	for i := 0; i < count; i++ {
+		name := C.GoString(names[i])
+		switch name {
+		case "TIMESTAMP", "TARGETS", "MULTIPLE", "SAVE_TARGETS":
+		default:
+			if dataType := dataTypeForTarget(name); !seen[dataType] {
+				seen[dataType] = true
+				result = append(result, dataType)
 			}
-			actualType, format, count, data := clipboardWindow.Property(prop, C.AnyPropertyType)
-			result := make([]string, 0, count)
-			if data != nil {
-				if actualType == C.XA_ATOM && format == 32 {
-					names := make([]*C.char, count)
-					C.XGetAtomNames(display, (*C.Atom)(data), C.int(count), &names[0])
-					var seenStringType bool
-					var seenRTFType bool
-					for i := 0; i < count; i++ {
-						name := C.GoString(names[i])
-						switch name {
-						case "TIMESTAMP", "TARGETS", "MULTIPLE", "SAVE_TARGETS":
-						case datatypes.PlainText, "UTF8_STRING", "COMPOUND_TEXT", "STRING", "TEXT":
-							if !seenStringType {
-								seenStringType = true
-								result = append(result, datatypes.PlainText)
-							}
-						case datatypes.RTFText, "TEXT/RTF", "application/rtf":
-							if !seenRTFType {
-								seenRTFType = true
-								result = append(result, datatypes.RTFText)
-							}
-						default:
-							result = append(result, name)
-						}
-						C.XFree(unsafe.Pointer(names[i]))
-					}
-				}
-				C.XFree(data)
+		}
+		C.XFree(unsafe.Pointer(names[i]))
+	}
+	return result
+}
+
+// Data returns the selection data of the specified type, waiting no longer
+// than the timeout for the selection owner to respond. Returns nil if the
+// data isn't available. The calling thread is blocked while waiting, so the
+// timeout should be short; DataAsync doesn't have that problem.
+func (s *Selection) Data(dataType string, timeout time.Duration) []byte {
+	if s.owns() {
+		return s.data[dataType]
+	}
+	t := s.newIncomingTransfer(targetsForDataType(dataType), nil)
+	t.wait(timeout)
+	if t.err != nil {
+		return nil
+	}
+	return convertFromTarget(t.targets[0], t.dataType, t.data)
+}
+
+// DataAsync requests the selection data of the specified type and returns
+// immediately. The callback will be called from the event loop once the data
+// has arrived, the selection owner has refused the request, or the timeout
+// has elapsed. Calling the returned function before then cancels the
+// request, in which case the callback will not be called.
+func (s *Selection) DataAsync(dataType string, timeout time.Duration, callback func(data []byte, err error)) (cancel func()) {
+	if s.owns() {
+		data := s.data[dataType]
+		canceled := false
+		clipboardWindow.InvokeTask(task.Record(func() {
+			if !canceled {
+				callback(data, nil)
 			}
-			clipboardWindow.DeleteProperty(prop)
-			return result
+		}))
+		return func() { canceled = true }
+	}
+	t := s.newIncomingTransfer(targetsForDataType(dataType), func(t *incomingTransfer) {
+		if t.err != nil {
+			callback(nil, t.err)
+		} else {
+			callback(convertFromTarget(t.targets[0], t.dataType, t.data), nil)
 		}
+	})
+	if timeout > 0 {
+		t.timer = time.AfterFunc(timeout, func() {
+			clipboardWindow.InvokeTask(task.Record(func() { t.abandon(errs.New("timed out waiting for the selection owner")) }))
+		})
 	}
+	return t.cancel
 }
 
-func GetClipboard(dataType string) []byte {
-	if ownsClipboard() {
-		return clipData[dataType]
-	}
-	clipboardWindow.DeleteProperty(clipboardAtom)
-	C.XConvertSelection(display, C.Atom(clipboardAtom), C.Atom(InternAtom(dataType)), C.Atom(clipboardAtom), C.Window(clipboardWindow), lastEventTime)
-	for {
-		evt := clipboardWindow.NextEventOfType(SelectionNotifyType)
-		if evt != nil {
-			selEvt := evt.ToSelectionEvent()
-			prop := selEvt.Property()
-			if prop == C.None {
-				switch dataType {
-				case datatypes.PlainText:
-					return GetClipboard("UTF8_STRING")
-				case "UTF8_STRING":
-					return GetClipboard("STRING")
-				default:
-					return nil
-				}
+func acquireTransferProperty() Atom {
+	if i := len(freeTransferProperties) - 1; i >= 0 {
+		property := freeTransferProperties[i]
+		freeTransferProperties = freeTransferProperties[:i]
+		return property
+	}
+	transferPropertyCount++
+	return InternAtom(fmt.Sprintf("UI_SELECTION_%d", transferPropertyCount))
+}
+
+func releaseTransferProperty(property Atom) {
+	freeTransferProperties = append(freeTransferProperties, property)
+}
+
+func (s *Selection) newIncomingTransfer(targets []Atom, done func(t *incomingTransfer)) *incomingTransfer {
+	// Stop watching for late replies to transfers abandoned long ago
+	now := time.Now()
+	active := incomingTransfers[:0]
+	for _, t := range incomingTransfers {
+		if t.abandoned.IsZero() || now.Sub(t.abandoned) < abandonedTransferLifetime {
+			active = append(active, t)
+		} else {
+			t.retire()
+		}
+	}
+	incomingTransfers = active
+	t := &incomingTransfer{selection: s, targets: targets, property: acquireTransferProperty(), done: done}
+	incomingTransfers = append(incomingTransfers, t)
+	t.request()
+	return t
+}
+
+func (t *incomingTransfer) request() {
+	clipboardWindow.DeleteProperty(t.property)
+	C.XConvertSelection(display, C.Atom(t.selection.atom), C.Atom(t.targets[0]), C.Atom(t.property), C.Window(clipboardWindow), lastEventTime)
+	Flush()
+}
+
+// wait processes the events for this transfer until it has finished or the
+// timeout has elapsed.
+func (t *incomingTransfer) wait(timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for !t.finished {
+		if evt := clipboardWindow.NextEventOfType(SelectionNotifyType); evt != nil {
+			processSelectionNotifyEvent(evt.ToSelectionEvent())
+		} else if evt = clipboardWindow.NextEventOfType(PropertyNotifyType); evt != nil {
+			processIncomingPropertyEvent(evt.ToPropertyEvent())
+		} else if remaining := time.Until(deadline); remaining <= 0 {
+			t.abandon(errs.New("timed out waiting for the selection owner"))
+		} else {
+			waitForInput(remaining)
+		}
+	}
+}
+
+// waitForInput blocks until the X server sends us something or the timeout
+// has elapsed.
+func waitForInput(timeout time.Duration) {
+	if timeout > maxWaitSlice {
+		timeout = maxWaitSlice
+	}
+	Flush()
+	fd := C.struct_pollfd{fd: C.XConnectionNumber(display), events: C.POLLIN}
+	C.poll(&fd, 1, C.int((timeout+time.Millisecond-1)/time.Millisecond))
+}
+
+func (t *incomingTransfer) cancel() {
+	t.done = nil
+	t.abandon(errs.New("canceled"))
+}
+
+// finish completes the transfer, releasing its property for reuse.
+func (t *incomingTransfer) finish(err error) {
+	if t.finished {
+		return
+	}
+	t.remove()
+	clipboardWindow.DeleteProperty(t.property)
+	releaseTransferProperty(t.property)
+	t.complete(err)
+}
+
+// abandon gives up on the transfer before the selection owner has finished
+// with it. The transfer continues to be tracked for a while, so that any late
+// replies are not mistaken for replies to later transfers, and its property
+// isn't reused until then.
+func (t *incomingTransfer) abandon(err error) {
+	if t.finished {
+		return
+	}
+	t.abandoned = time.Now()
+	t.complete(err)
+}
+
+// retire releases the property of an abandoned transfer for reuse, once the
+// selection owner has replied late or enough time has passed that it won't.
+// An incremental transfer's property is never reused, as its owner is still
+// waiting to write the next chunk into it and would do so as soon as a later
+// transfer cleared it.
+func (t *incomingTransfer) retire() {
+	if !t.incr {
+		clipboardWindow.DeleteProperty(t.property)
+		releaseTransferProperty(t.property)
+	}
+}
+
+func (t *incomingTransfer) complete(err error) {
+	t.finished = true
+	t.err = err
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+	if t.done != nil {
+		t.done(t)
+	}
+}
+
+func (t *incomingTransfer) remove() {
+	for i, one := range incomingTransfers {
+		if one == t {
+			incomingTransfers = append(incomingTransfers[:i], incomingTransfers[i+1:]...)
+			break
+		}
+	}
+}
+
+func (t *incomingTransfer) selectionNotified(evt *SelectionEvent) {
+	if evt.Property() == C.None {
+		if len(t.targets) > 1 {
+			t.targets = t.targets[1:]
+			t.request()
+		} else {
+			t.finish(nil)
+		}
+		return
+	}
+	actualType, format, count, data := clipboardWindow.Property(t.property, C.AnyPropertyType)
+	if actualType == incrAtom {
+		if data != nil {
+			C.XFree(data)
+		}
+		// Deleting the property tells the owner to start sending the data
+		t.incr = true
+		clipboardWindow.DeleteProperty(t.property)
+		Flush()
+		return
+	}
+	t.append(actualType, format, count, data)
+	t.finish(nil)
+}
+
+func (t *incomingTransfer) propertyChanged(evt *PropertyEvent) {
+	if evt.State() != PropertyNewValue {
+		return
+	}
+	actualType, format, count, data := clipboardWindow.Property(t.property, C.AnyPropertyType)
+	// Deleting the property tells the owner to send the next chunk
+	clipboardWindow.DeleteProperty(t.property)
+	Flush()
+	if count == 0 {
+		if data != nil {
+			C.XFree(data)
+		}
+		t.finish(nil)
+		return
+	}
+	t.append(actualType, format, count, data)
+}
+
+func (t *incomingTransfer) append(actualType Atom, format, count int, data unsafe.Pointer) {
+	t.dataType = actualType
+	t.format = format
+	if data != nil {
+		length := count * propertyItemSize(format)
+		t.data = append(t.data, (*[1 << 30]byte)(data)[:length:length]...)
+		C.XFree(data)
+	}
+}
+
+// propertyItemSize returns the number of bytes Xlib uses for each item of a
+// property with the specified format. Note that 32-bit items are returned as
+// longs, regardless of the actual size of a long.
+func propertyItemSize(format int) int {
+	if format == 32 {
+		return int(unsafe.Sizeof(C.long(0)))
+	}
+	return format / 8
+}
+
+// ProcessClipboardEvent handles the events needed for transferring the
+// contents of the selections. Returns true if the event was consumed.
+func ProcessClipboardEvent(evt *Event) bool {
+	switch evt.Type() {
+	case SelectionNotifyType:
+		if se := evt.ToSelectionEvent(); se.Requestor() == clipboardWindow {
+			processSelectionNotifyEvent(se)
+			return true
+		}
+	case PropertyNotifyType:
+		pe := evt.ToPropertyEvent()
+		if pe.Window() == clipboardWindow {
+			processIncomingPropertyEvent(pe)
+			return true
+		}
+		return processOutgoingPropertyEvent(pe)
+	}
+	return false
+}
+
+// processSelectionNotifyEvent passes the reply on to the transfer it belongs
+// to. Refusals don't identify the property, so they are matched to the oldest
+// outstanding request for the target, which the selection owner answers first.
+func processSelectionNotifyEvent(evt *SelectionEvent) {
+	property := evt.Property()
+	for _, t := range incomingTransfers {
+		if !t.incr && t.selection.atom == evt.Selection() && (t.property == property || (property == C.None && t.targets[0] == evt.Target())) {
+			if t.abandoned.IsZero() {
+				t.selectionNotified(evt)
+			} else {
+				// A late reply to an abandoned transfer; it is finally done with
+				t.remove()
+				t.retire()
+				Flush()
 			}
-			_, format, count, data := clipboardWindow.Property(prop, C.AnyPropertyType)
-			length := count * format / 8
-			result := make([]byte, length)
-			if data != nil {
-				raw := (*[1 << 30]byte)(data)
-				copy(result, raw[:length])
-				C.XFree(data)
+			return
+		}
+	}
+}
+
+func processIncomingPropertyEvent(evt *PropertyEvent) {
+	property := evt.Atom()
+	for _, t := range incomingTransfers {
+		if t.incr && t.property == property {
+			// Chunks for an abandoned transfer are ignored, which stalls the
+			// owner's side of it
+			if t.abandoned.IsZero() {
+				t.propertyChanged(evt)
 			}
-			clipboardWindow.DeleteProperty(prop)
-			return result
+			return
 		}
 	}
 }
 
-func SetClipboard(data []datatypes.Data) {
-	clipData = make(map[string][]byte)
-	clipDataChangeCount++
+func processOutgoingPropertyEvent(evt *PropertyEvent) bool {
+	now := time.Now()
+	for i := 0; i < len(outgoingTransfers); i++ {
+		t := outgoingTransfers[i]
+		if t.requestor == evt.Window() && t.property == evt.Atom() {
+			if evt.State() == PropertyDelete {
+				t.lastActivity = now
+				if t.sendNextChunk() {
+					outgoingTransfers = append(outgoingTransfers[:i], outgoingTransfers[i+1:]...)
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func startOutgoingTransfer(requestor Window, property, propertyType Atom, data []byte) {
+	// Drop any transfers the requestors appear to have abandoned
+	now := time.Now()
+	active := outgoingTransfers[:0]
+	for _, t := range outgoingTransfers {
+		if now.Sub(t.lastActivity) < outgoingTransferTimeout {
+			active = append(active, t)
+		} else {
+			t.requestor.SelectInput(NoEventMask)
+		}
+	}
+	outgoingTransfers = append(active, &outgoingTransfer{requestor: requestor, property: property, propertyType: propertyType, data: data, lastActivity: now})
+	// We need to know when the requestor deletes the property, signalling
+	// it is ready for the next chunk.
+	requestor.SelectInput(PropertyChangeMask)
+	size := C.long(len(data))
+	requestor.ChangeProperty(property, incrAtom, 32, PropModeReplace, unsafe.Pointer(&size), 1)
+}
+
+// sendNextChunk sends the next chunk of data to the requestor. Returns true
+// once the transfer is complete.
+func (t *outgoingTransfer) sendNextChunk() bool {
+	remaining := len(t.data) - t.offset
+	if remaining > incrChunkSize {
+		remaining = incrChunkSize
+	}
+	if remaining > 0 {
+		t.requestor.ChangeProperty(t.property, t.propertyType, 8, PropModeReplace, unsafe.Pointer(&t.data[t.offset]), remaining)
+		t.offset += remaining
+		Flush()
+		return false
+	}
+	// A zero-length chunk marks the end of the transfer
+	t.requestor.ChangeProperty(t.property, t.propertyType, 8, PropModeReplace, nil, 0)
+	t.requestor.SelectInput(NoEventMask)
+	Flush()
+	return true
+}
+
+func (s *Selection) SetData(data []datatypes.Data) {
+	s.data = make(map[string][]byte)
+	s.changeCount++
 	for _, one := range data {
-		clipData[one.MimeType] = one.Bytes
+		s.data[one.MimeType] = one.Bytes
 	}
-	claimSelectionOwnership()
+	s.claimOwnership()
 }
 
-func claimSelectionOwnership() {
-	C.XSetSelectionOwner(display, C.Atom(clipboardAtom), C.Window(clipboardWindow), lastEventTime)
+func (s *Selection) claimOwnership() {
+	s.acquiredTime = lastEventTime
+	C.XSetSelectionOwner(display, C.Atom(s.atom), C.Window(clipboardWindow), lastEventTime)
+	s.owned = C.XGetSelectionOwner(display, C.Atom(s.atom)) == C.Window(clipboardWindow)
 }
 
 func ProcessSelectionClearEvent(evt *SelectionClearEvent) {
 	if evt.Window() == clipboardWindow {
-		clipData = make(map[string][]byte)
-		clipDataChangeCount++
-		acquiredTime = 0
+		if s := selectionForAtom(Atom(evt.Selection())); s != nil {
+			s.data = make(map[string][]byte)
+			s.changeCount++
+			s.owned = false
+			s.acquiredTime = 0
+		}
 	}
 }
 
 func ProcessSelectionRequestEvent(evt *SelectionRequestEvent) {
-	if evt.Owner() == clipboardWindow && evt.Selection() == clipboardAtom {
+	if s := selectionForAtom(evt.Selection()); evt.Owner() == clipboardWindow && s != nil {
 		when := evt.When()
 		prop := evt.Property()
-		bad := prop == C.None || (when != C.CurrentTime && when < acquiredTime)
+		bad := prop == C.None || (when != C.CurrentTime && when < s.acquiredTime)
 		if !bad {
 			target := evt.Target()
 			switch target {
 			case targetsAtom:
 				// Send list of supported targets
-				available := localClipboardTypes()
-				atoms := make([]Atom, len(available)+1)
-				atoms[0] = targetsAtom
-				for i, one := range available {
-					atoms[i+1] = InternAtom(one)
+				atoms := []Atom{targetsAtom}
+				for _, one := range s.localTypes() {
+					atoms = append(atoms, targetsForDataType(one)...)
 				}
 				evt.Requestor().ChangeProperty(prop, C.XA_ATOM, 32, PropModeReplace, unsafe.Pointer(&atoms[0]), len(atoms))
 			case multipleAtom:
@@ -185,21 +567,21 @@ func ProcessSelectionRequestEvent(evt *SelectionRequestEvent) {
 			default:
 				// Convert data to requested format
 				requested := target.Name()
-				var adjustedRequest string
-				switch requested {
-				case "UTF8_STRING", "COMPOUND_TEXT", "STRING", "TEXT":
-					adjustedRequest = datatypes.PlainText
-				case "TEXT/RTF", "application/rtf":
-					adjustedRequest = datatypes.RTFText
-				default:
-					adjustedRequest = requested
-				}
+				adjustedRequest := dataTypeForTarget(requested)
 				bad = true
-				for _, one := range localClipboardTypes() {
+				for _, one := range s.localTypes() {
 					if one == adjustedRequest {
 						bad = false
-						bytes := clipData[one]
-						evt.Requestor().ChangeProperty(prop, target, 8, PropModeReplace, unsafe.Pointer(&bytes[0]), len(bytes))
+						bytes := convertForTarget(requested, s.data[one])
+						propertyType := propertyTypeForTarget(target)
+						switch {
+						case len(bytes) > incrChunkSize:
+							startOutgoingTransfer(evt.Requestor(), prop, propertyType, bytes)
+						case len(bytes) == 0:
+							evt.Requestor().ChangeProperty(prop, propertyType, 8, PropModeReplace, nil, 0)
+						default:
+							evt.Requestor().ChangeProperty(prop, propertyType, 8, PropModeReplace, unsafe.Pointer(&bytes[0]), len(bytes))
+						}
 						break
 					}
 				}
@@ -0,0 +1,159 @@
+package x11
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	"github.com/richardwilkes/ui/clipboard/datatypes"
+)
+
+const (
+	gnomeCopiedFilesTarget = "x-special/gnome-copied-files"
+	stringTarget           = "STRING"
+	textTarget             = "TEXT"
+	utf8StringTarget       = "UTF8_STRING"
+	compoundTextTarget     = "COMPOUND_TEXT"
+)
+
+// targetAliases maps the names of X11 targets commonly used by other
+// applications to the data types we store their data under. When requesting
+// data of a given type, its aliases are tried in the order listed here.
+// COMPOUND_TEXT is deliberately absent, as we don't support its encoding.
+var targetAliases = []struct {
+	target   string
+	dataType string
+}{
+	{utf8StringTarget, datatypes.PlainText},
+	{"text/plain;charset=utf-8", datatypes.PlainText},
+	{textTarget, datatypes.PlainText},
+	{stringTarget, datatypes.PlainText},
+	{"TEXT/RTF", datatypes.RTFText},
+	{"application/rtf", datatypes.RTFText},
+	{"text/richtext", datatypes.RTFText},
+	{"text/html;charset=utf-8", datatypes.HTMLText},
+	{"PNG", datatypes.PNGImage},
+	{gnomeCopiedFilesTarget, datatypes.URIList},
+}
+
+// dataTypeForTarget returns the data type the target is equivalent to.
+func dataTypeForTarget(target string) string {
+	for _, alias := range targetAliases {
+		if alias.target == target {
+			return alias.dataType
+		}
+	}
+	return target
+}
+
+// targetNamesForDataType returns the names of the targets that are
+// equivalent to the data type, starting with the data type itself.
+func targetNamesForDataType(dataType string) []string {
+	names := []string{dataType}
+	for _, alias := range targetAliases {
+		if alias.dataType == dataType {
+			names = append(names, alias.target)
+		}
+	}
+	return names
+}
+
+func targetsForDataType(dataType string) []Atom {
+	names := targetNamesForDataType(dataType)
+	atoms := make([]Atom, len(names))
+	for i, name := range names {
+		atoms[i] = InternAtom(name)
+	}
+	return atoms
+}
+
+// propertyTypeForTarget returns the type to give the property holding data
+// converted for the target. Requests for TEXT leave the choice of encoding to
+// us, so we state that we chose UTF-8.
+func propertyTypeForTarget(target Atom) Atom {
+	if target == InternAtom(textTarget) {
+		return InternAtom(utf8StringTarget)
+	}
+	return target
+}
+
+// convertForTarget converts data we hold to the form the target expects.
+func convertForTarget(target string, data []byte) []byte {
+	switch target {
+	case stringTarget:
+		return utf8ToLatin1(data)
+	case gnomeCopiedFilesTarget:
+		var buffer bytes.Buffer
+		buffer.WriteString("copy")
+		for _, line := range bytes.Split(data, []byte("\n")) {
+			if line = bytes.TrimSpace(line); len(line) != 0 && line[0] != '#' {
+				buffer.WriteByte('\n')
+				buffer.Write(line)
+			}
+		}
+		return buffer.Bytes()
+	default:
+		return data
+	}
+}
+
+// convertFromTarget converts data received for the target, as a property of
+// the specified type, to the form we store it in. Returns nil if the data
+// can't be converted.
+func convertFromTarget(target, propertyType Atom, data []byte) []byte {
+	switch propertyType {
+	case InternAtom(stringTarget):
+		return latin1ToUTF8(data)
+	case InternAtom(compoundTextTarget):
+		// Likely a reply to a request for TEXT. Compound text is only
+		// compatible with UTF-8 when it is plain ASCII.
+		for _, b := range data {
+			if b >= utf8.RuneSelf || b == 0x1b {
+				return nil
+			}
+		}
+		return data
+	}
+	if target == InternAtom(gnomeCopiedFilesTarget) {
+		// Drop the leading "copy" or "cut" line and use CRLF line endings
+		lines := bytes.Split(data, []byte("\n"))
+		if len(lines) > 0 {
+			lines = lines[1:]
+		}
+		var buffer bytes.Buffer
+		for _, line := range lines {
+			if line = bytes.TrimSpace(line); len(line) != 0 {
+				buffer.Write(line)
+				buffer.WriteString("\r\n")
+			}
+		}
+		return buffer.Bytes()
+	}
+	return data
+}
+
+// utf8ToLatin1 converts UTF-8 text to ISO 8859-1, as used by the STRING
+// target. Characters Latin-1 can't represent are replaced with '?'.
+func utf8ToLatin1(data []byte) []byte {
+	result := make([]byte, 0, len(data))
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		data = data[size:]
+		if r > 0xff {
+			r = '?'
+		}
+		result = append(result, byte(r))
+	}
+	return result
+}
+
+// latin1ToUTF8 converts ISO 8859-1 text, as used by the STRING target, to
+// UTF-8.
+func latin1ToUTF8(data []byte) []byte {
+	result := make([]byte, 0, len(data))
+	var buffer [utf8.UTFMax]byte
+	for _, b := range data {
+		size := utf8.EncodeRune(buffer[:], rune(b))
+		result = append(result, buffer[:size]...)
+	}
+	return result
+}
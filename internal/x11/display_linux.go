@@ -51,10 +51,20 @@ func OpenDisplay() {
 		panic("Failed to open the X11 display")
 	}
 	initAtoms()
+	// Needed for the settings manager's MANAGER client message and for
+	// changes to the work area.
+	DefaultRootWindow().SelectInput(StructureNotifyMask | PropertyChangeMask)
 	initClipboard()
+	initXSettings()
+	initMonitors()
+	initInputMethod()
 }
 
 func CloseDisplay() {
+	if inputMethod != nil {
+		C.XCloseIM(inputMethod)
+		inputMethod = nil
+	}
 	C.XCloseDisplay(display)
 	display = nil
 }
@@ -0,0 +1,117 @@
+package x11
+
+import (
+	// #cgo pkg-config: x11 xrandr
+	// #include <X11/Xlib.h>
+	// #include <X11/Xatom.h>
+	// #include <X11/extensions/Xrandr.h>
+	"C"
+	"unsafe"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+)
+
+// Monitor holds information about a monitor attached to the default screen.
+type Monitor struct {
+	Bounds  geom.Rect
+	Primary bool
+}
+
+var (
+	randrAvailable     bool
+	randrEventBase     C.int
+	workAreaAtom       Atom
+	currentDesktopAtom Atom
+)
+
+func initMonitors() {
+	workAreaAtom = InternAtom("_NET_WORKAREA")
+	currentDesktopAtom = InternAtom("_NET_CURRENT_DESKTOP")
+	var errorBase, major, minor C.int
+	if C.XRRQueryExtension(display, &randrEventBase, &errorBase) != 0 && C.XRRQueryVersion(display, &major, &minor) != 0 {
+		// XRRGetMonitors() requires version 1.5 or later
+		if major > 1 || (major == 1 && minor >= 5) {
+			randrAvailable = true
+			C.XRRSelectInput(display, C.Window(DefaultRootWindow()), C.RRScreenChangeNotifyMask|C.RRCrtcChangeNotifyMask|C.RROutputChangeNotifyMask)
+		}
+	}
+}
+
+// Monitors returns the monitors attached to the default screen. If XRandR
+// isn't available, the whole screen is treated as a single monitor.
+func Monitors() []Monitor {
+	var monitors []Monitor
+	if randrAvailable {
+		var count C.int
+		if info := C.XRRGetMonitors(display, C.Window(DefaultRootWindow()), C.True, &count); info != nil {
+			for _, one := range (*[1 << 16]C.XRRMonitorInfo)(unsafe.Pointer(info))[:count:count] {
+				monitors = append(monitors, Monitor{
+					Bounds:  geom.Rect{Point: geom.Point{X: float64(one.x), Y: float64(one.y)}, Size: geom.Size{Width: float64(one.width), Height: float64(one.height)}},
+					Primary: one.primary != 0,
+				})
+			}
+			C.XRRFreeMonitors(info)
+		}
+	}
+	if len(monitors) == 0 {
+		screen := C.int(DefaultScreen())
+		monitors = append(monitors, Monitor{
+			Bounds:  geom.Rect{Size: geom.Size{Width: float64(C.XDisplayWidth(display, screen)), Height: float64(C.XDisplayHeight(display, screen))}},
+			Primary: true,
+		})
+	}
+	return monitors
+}
+
+// WorkArea returns the area of the screen not covered by panels on the
+// current desktop, as published by the window manager. Returns false if the
+// window manager doesn't publish this information.
+func WorkArea() (geom.Rect, bool) {
+	root := DefaultRootWindow()
+	desktop := 0
+	actualType, actualFormat, count, data := root.Property(currentDesktopAtom, C.XA_CARDINAL)
+	if data != nil {
+		if actualType == C.XA_CARDINAL && actualFormat == 32 && count == 1 {
+			desktop = int(*(*C.long)(data))
+		}
+		C.XFree(data)
+	}
+	var area geom.Rect
+	var found bool
+	actualType, actualFormat, count, data = root.Property(workAreaAtom, C.XA_CARDINAL)
+	if data != nil {
+		// There are four values for each desktop
+		if actualType == C.XA_CARDINAL && actualFormat == 32 && count >= 4 {
+			if (desktop+1)*4 > count {
+				desktop = 0
+			}
+			fields := (*[1 << 16]C.long)(data)[desktop*4 : desktop*4+4]
+			area = geom.Rect{Point: geom.Point{X: float64(fields[0]), Y: float64(fields[1])}, Size: geom.Size{Width: float64(fields[2]), Height: float64(fields[3])}}
+			found = true
+		}
+		C.XFree(data)
+	}
+	return area, found
+}
+
+// ProcessMonitorEvent checks whether the event indicates that the monitor
+// configuration or the work area has changed. Returns true if so, in which
+// case the event has been consumed.
+func ProcessMonitorEvent(evt *Event) bool {
+	if randrAvailable {
+		switch evt.Type() - int(randrEventBase) {
+		case C.RRScreenChangeNotify:
+			C.XRRUpdateConfiguration((*C.XEvent)(unsafe.Pointer(evt)))
+			return true
+		case C.RRNotify:
+			return true
+		}
+	}
+	if evt.Type() == PropertyNotifyType {
+		pe := evt.ToPropertyEvent()
+		if pe.Window() == DefaultRootWindow() && (pe.Atom() == workAreaAtom || pe.Atom() == currentDesktopAtom) {
+			return true
+		}
+	}
+	return false
+}
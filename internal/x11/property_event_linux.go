@@ -9,6 +9,23 @@ import (
 
 type PropertyEvent C.XPropertyEvent
 
+const (
+	PropertyNewValue = C.PropertyNewValue
+	PropertyDelete   = C.PropertyDelete
+)
+
+func (evt *PropertyEvent) Window() Window {
+	return Window(evt.window)
+}
+
+func (evt *PropertyEvent) Atom() Atom {
+	return Atom(evt.atom)
+}
+
+func (evt *PropertyEvent) State() int {
+	return int(evt.state)
+}
+
 func (evt *PropertyEvent) Time() C.Time {
 	return evt.time
 }
@@ -0,0 +1,47 @@
+package x11
+
+import (
+	// #cgo pkg-config: x11 xfixes
+	// #include <X11/Xlib.h>
+	// #include <X11/extensions/Xfixes.h>
+	"C"
+	"unsafe"
+)
+
+var (
+	xfixesAvailable bool
+	xfixesEventBase C.int
+)
+
+func initSelectionOwnerNotify() {
+	var errorBase, major, minor C.int
+	if C.XFixesQueryExtension(display, &xfixesEventBase, &errorBase) != 0 && C.XFixesQueryVersion(display, &major, &minor) != 0 {
+		xfixesAvailable = true
+		for _, s := range []*Selection{Clipboard, Primary} {
+			C.XFixesSelectSelectionInput(display, C.Window(clipboardWindow), C.Atom(s.atom), C.XFixesSetSelectionOwnerNotifyMask|C.XFixesSelectionWindowDestroyNotifyMask|C.XFixesSelectionClientCloseNotifyMask)
+		}
+	}
+}
+
+// ProcessSelectionOwnerEvent checks whether the event indicates that the
+// owner of the clipboard or primary selection has changed. Returns the
+// selection that changed, or nil if the event isn't a selection owner
+// notification. A non-nil return means the event has been consumed.
+func ProcessSelectionOwnerEvent(evt *Event) *Selection {
+	if xfixesAvailable && evt.Type()-int(xfixesEventBase) == C.XFixesSelectionNotify {
+		notify := (*C.XFixesSelectionNotifyEvent)(unsafe.Pointer(evt))
+		if s := selectionForAtom(Atom(notify.selection)); s != nil {
+			// Our own ownership changes have already been counted
+			if Window(notify.owner) != clipboardWindow {
+				s.changeCount++
+			}
+			return s
+		}
+	}
+	return nil
+}
+
+// IsPrimary returns true if this is the primary selection.
+func (s *Selection) IsPrimary() bool {
+	return s == Primary
+}
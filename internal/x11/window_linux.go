@@ -92,14 +92,16 @@ func (wnd Window) applyCommonSetup() {
 	wnd.SetProtocols(DeleteWindowSubType)
 	pid := os.Getpid()
 	wnd.ChangeProperty(wmPidAtom, C.XA_CARDINAL, 32, PropModeReplace, unsafe.Pointer(&pid), 1)
+	wnd.createInputContext()
 }
 
 func (wnd Window) Destroy() {
+	wnd.destroyInputContext()
 	C.XDestroyWindow(display, C.Window(wnd))
 }
 
-func (wnd Window) NewSurface(size geom.Size) *draw.Surface {
-	return draw.NewSurface(unsafe.Pointer(C.cairo_xlib_surface_create(display, C.Drawable(uintptr(wnd)), (*C.Visual)(DefaultVisual()), C.int(size.Width), C.int(size.Height))), size)
+func (wnd Window) NewSurface(size geom.Size, scale float64) *draw.Surface {
+	return draw.NewSurface(unsafe.Pointer(C.cairo_xlib_surface_create(display, C.Drawable(uintptr(wnd)), (*C.Visual)(DefaultVisual()), C.int(math.Ceil(size.Width*scale)), C.int(math.Ceil(size.Height*scale)))), size, scale)
 }
 
 func (wnd Window) SelectInput(mask int) {
@@ -0,0 +1,116 @@
+#include <stdlib.h>
+#include <locale.h>
+#include <wchar.h>
+#include "xim_linux.h"
+#include "_cgo_export.h"
+
+static XIMStyle chooseInputStyle(XIM im) {
+	XIMStyles *styles = NULL;
+	XIMStyle chosen = 0;
+	if (XGetIMValues(im, XNQueryInputStyle, &styles, NULL) == NULL && styles != NULL) {
+		for (int i = 0; i < styles->count_styles; i++) {
+			XIMStyle style = styles->supported_styles[i];
+			if (style == (XIMPreeditCallbacks | XIMStatusNothing)) {
+				chosen = style;
+				break;
+			}
+			if (style == (XIMPreeditNothing | XIMStatusNothing)) {
+				chosen = style;
+			}
+		}
+		XFree(styles);
+	}
+	return chosen;
+}
+
+XIM openInputMethod(Display *display, XIMStyle *style) {
+	setlocale(LC_CTYPE, "");
+	if (!XSupportsLocale()) {
+		return NULL;
+	}
+	XSetLocaleModifiers("");
+	XIM im = XOpenIM(display, NULL, NULL, NULL);
+	if (im == NULL) {
+		// Fall back to the internal input method, which still handles dead keys and compose
+		// sequences.
+		XSetLocaleModifiers("@im=none");
+		im = XOpenIM(display, NULL, NULL, NULL);
+	}
+	if (im != NULL) {
+		*style = chooseInputStyle(im);
+		if (*style == 0) {
+			XCloseIM(im);
+			im = NULL;
+		}
+	}
+	return im;
+}
+
+static int preeditStart(XIC ic, XPointer client, XPointer data) {
+	goPreeditStart((Window)client);
+	// No limit on the length of the preedit text
+	return -1;
+}
+
+static void preeditDone(XIC ic, XPointer client, XPointer data) {
+	goPreeditDone((Window)client);
+}
+
+static void preeditDraw(XIC ic, XPointer client, XIMPreeditDrawCallbackStruct *data) {
+	wchar_t *converted = NULL;
+	int *wideChar = NULL;
+	int length = 0;
+	if (data->text != NULL) {
+		if (data->text->encoding_is_wchar) {
+			wideChar = (int *)data->text->string.wide_char;
+			length = data->text->length;
+		} else if (data->text->string.multi_byte != NULL) {
+			// The multi-byte text is in the locale's encoding, which need not be UTF-8
+			converted = malloc((data->text->length + 1) * sizeof(wchar_t));
+			if (converted != NULL) {
+				size_t count = mbstowcs(converted, data->text->string.multi_byte, data->text->length + 1);
+				if (count != (size_t)-1) {
+					wideChar = (int *)converted;
+					length = count > data->text->length ? data->text->length : (int)count;
+				}
+			}
+		}
+	}
+	goPreeditDraw((Window)client, data->caret, data->chg_first, data->chg_length, wideChar, length);
+	free(converted);
+}
+
+static void preeditCaret(XIC ic, XPointer client, XIMPreeditCaretCallbackStruct *data) {
+	data->position = goPreeditCaret((Window)client, data->direction, data->position);
+}
+
+XIC createInputContext(XIM im, XIMStyle style, Display *display, Window window) {
+	XIC ic;
+	if (style & XIMPreeditCallbacks) {
+		XIMCallback start = { (XPointer)window, (XIMProc)preeditStart };
+		XIMCallback done = { (XPointer)window, (XIMProc)preeditDone };
+		XIMCallback draw = { (XPointer)window, (XIMProc)preeditDraw };
+		XIMCallback caret = { (XPointer)window, (XIMProc)preeditCaret };
+		XVaNestedList preedit = XVaCreateNestedList(0, XNPreeditStartCallback, &start, XNPreeditDoneCallback, &done, XNPreeditDrawCallback, &draw, XNPreeditCaretCallback, &caret, NULL);
+		ic = XCreateIC(im, XNInputStyle, style, XNClientWindow, window, XNFocusWindow, window, XNPreeditAttributes, preedit, NULL);
+		XFree(preedit);
+	} else {
+		ic = XCreateIC(im, XNInputStyle, style, XNClientWindow, window, XNFocusWindow, window, NULL);
+	}
+	if (ic != NULL) {
+		// The input method may need to see additional events
+		unsigned long filter = 0;
+		XWindowAttributes attrs;
+		if (XGetICValues(ic, XNFilterEvents, &filter, NULL) == NULL && filter != 0 && XGetWindowAttributes(display, window, &attrs)) {
+			XSelectInput(display, window, attrs.your_event_mask | filter);
+		}
+	}
+	return ic;
+}
+
+void setInputContextSpot(XIC ic, int x, int y) {
+	XPoint spot = { x, y };
+	XVaNestedList preedit = XVaCreateNestedList(0, XNSpotLocation, &spot, NULL);
+	XSetICValues(ic, XNPreeditAttributes, preedit, NULL);
+	XFree(preedit);
+}
@@ -0,0 +1,193 @@
+package x11
+
+import (
+	// #cgo pkg-config: x11
+	// #include <stdlib.h>
+	// #include "xim_linux.h"
+	"C"
+	"unsafe"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/keys"
+)
+
+var (
+	// PreeditChanged will be called, if not nil, whenever the text the input
+	// method is composing for a window changes. The caret is a rune index
+	// into the text. An empty text means composition has ended.
+	PreeditChanged func(wnd Window, text string, caret int)
+	inputMethod    C.XIM
+	inputStyle     C.XIMStyle
+	inputContexts  = make(map[Window]C.XIC)
+	preedits       = make(map[Window]*preedit)
+)
+
+type preedit struct {
+	text  []rune
+	caret int
+}
+
+func initInputMethod() {
+	inputMethod = C.openInputMethod(display, &inputStyle)
+}
+
+// InputMethodActive returns true if an input method is in use. When one is,
+// dead keys and compose sequences are handled by the input method.
+func InputMethodActive() bool {
+	return inputMethod != nil
+}
+
+// FilterEvent gives the input method a chance to consume the event. Returns
+// true if the event was consumed and should not be processed further.
+func FilterEvent(evt *Event) bool {
+	return inputMethod != nil && C.XFilterEvent((*C.XEvent)(evt), C.None) != 0
+}
+
+func (wnd Window) createInputContext() {
+	if inputMethod != nil {
+		if ic := C.createInputContext(inputMethod, inputStyle, display, C.Window(wnd)); ic != nil {
+			inputContexts[wnd] = ic
+		}
+	}
+}
+
+func (wnd Window) destroyInputContext() {
+	if ic, ok := inputContexts[wnd]; ok {
+		C.XDestroyIC(ic)
+		delete(inputContexts, wnd)
+	}
+	delete(preedits, wnd)
+}
+
+// SetInputFocus informs the window's input context, if any, of whether the
+// window has the keyboard focus.
+func (wnd Window) SetInputFocus(focused bool) {
+	if ic, ok := inputContexts[wnd]; ok {
+		if focused {
+			C.XSetICFocus(ic)
+		} else {
+			C.XUnsetICFocus(ic)
+		}
+	}
+}
+
+// SetInputSpot tells the input method where, in window coordinates, the
+// text caret is located, so that it can place its candidate window nearby.
+func (wnd Window) SetInputSpot(where geom.Point) {
+	if ic, ok := inputContexts[wnd]; ok {
+		C.setInputContextSpot(ic, C.int(where.X), C.int(where.Y))
+	}
+}
+
+// CodeAndChars is similar to CodeAndChar, but looks up the characters using
+// the window's input context, if there is one. When the input method
+// commits composed text, more than one character may be returned, in which
+// case the code will be 0.
+func (evt *KeyEvent) CodeAndChars() (code int, chars []rune) {
+	ic, ok := inputContexts[evt.Window()]
+	if !ok || evt._type != KeyPressType {
+		var ch rune
+		if code, ch = evt.CodeAndChar(); ch != 0 {
+			chars = []rune{ch}
+		}
+		return
+	}
+	buffer := make([]C.char, 64)
+	var keySym C.KeySym
+	var status C.Status
+	count := C.Xutf8LookupString(ic, (*C.XKeyPressedEvent)(evt), &buffer[0], C.int(len(buffer)), &keySym, &status)
+	if status == C.XBufferOverflow {
+		buffer = make([]C.char, count)
+		count = C.Xutf8LookupString(ic, (*C.XKeyPressedEvent)(evt), &buffer[0], C.int(len(buffer)), &keySym, &status)
+	}
+	var text string
+	if status == C.XLookupChars || status == C.XLookupBoth {
+		text = C.GoStringN(&buffer[0], count)
+	}
+	if status != C.XLookupKeySym && status != C.XLookupBoth {
+		keySym = C.NoSymbol
+	}
+	if chars = []rune(text); len(chars) > 1 {
+		return 0, chars
+	}
+	var ch rune
+	if code, ch = keys.Transform(int(keySym), text); ch != 0 {
+		chars = []rune{ch}
+	} else {
+		chars = nil
+	}
+	return
+}
+
+func notifyPreeditChanged(wnd Window, pe *preedit) {
+	if PreeditChanged != nil {
+		PreeditChanged(wnd, string(pe.text), pe.caret)
+	}
+}
+
+//export goPreeditStart
+func goPreeditStart(wnd C.Window) {
+	preedits[Window(wnd)] = &preedit{}
+}
+
+//export goPreeditDone
+func goPreeditDone(wnd C.Window) {
+	delete(preedits, Window(wnd))
+	notifyPreeditChanged(Window(wnd), &preedit{})
+}
+
+//export goPreeditDraw
+func goPreeditDraw(wnd C.Window, caret, first, length C.int, wideChar *C.int, count C.int) {
+	pe, ok := preedits[Window(wnd)]
+	if !ok {
+		pe = &preedit{}
+		preedits[Window(wnd)] = pe
+	}
+	var replacement []rune
+	if wideChar != nil {
+		for _, one := range (*[1 << 20]C.int)(unsafe.Pointer(wideChar))[:count:count] {
+			replacement = append(replacement, rune(one))
+		}
+	}
+	start := clampInt(int(first), 0, len(pe.text))
+	end := clampInt(start+int(length), start, len(pe.text))
+	text := make([]rune, 0, len(pe.text)-(end-start)+len(replacement))
+	text = append(text, pe.text[:start]...)
+	text = append(text, replacement...)
+	pe.text = append(text, pe.text[end:]...)
+	pe.caret = clampInt(int(caret), 0, len(pe.text))
+	notifyPreeditChanged(Window(wnd), pe)
+}
+
+//export goPreeditCaret
+func goPreeditCaret(wnd C.Window, direction C.XIMCaretDirection, position C.int) C.int {
+	pe, ok := preedits[Window(wnd)]
+	if !ok {
+		return position
+	}
+	switch direction {
+	case C.XIMForwardChar:
+		pe.caret++
+	case C.XIMBackwardChar:
+		pe.caret--
+	case C.XIMLineStart:
+		pe.caret = 0
+	case C.XIMLineEnd:
+		pe.caret = len(pe.text)
+	case C.XIMAbsolutePosition:
+		pe.caret = int(position)
+	}
+	pe.caret = clampInt(pe.caret, 0, len(pe.text))
+	notifyPreeditChanged(Window(wnd), pe)
+	return C.int(pe.caret)
+}
+
+func clampInt(value, min, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
@@ -0,0 +1,5 @@
+#include <X11/Xlib.h>
+
+XIM openInputMethod(Display *display, XIMStyle *style);
+XIC createInputContext(XIM im, XIMStyle style, Display *display, Window window);
+void setInputContextSpot(XIC ic, int x, int y);
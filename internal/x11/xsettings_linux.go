@@ -0,0 +1,166 @@
+package x11
+
+import (
+	// #cgo pkg-config: x11
+	// #include <X11/Xlib.h>
+	"C"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/richardwilkes/ui/color"
+)
+
+const (
+	xsettingsTypeInteger = iota
+	xsettingsTypeString
+	xsettingsTypeColor
+)
+
+var (
+	// XSettingsChanged will be called, if not nil, whenever the settings
+	// published by the desktop's XSETTINGS manager are loaded or change.
+	XSettingsChanged       func()
+	xsettingsSelectionAtom Atom
+	xsettingsAtom          Atom
+	managerAtom            Atom
+	xsettingsOwner         Window
+	xsettings              = make(map[string]interface{})
+)
+
+func initXSettings() {
+	xsettingsSelectionAtom = InternAtom(fmt.Sprintf("_XSETTINGS_S%d", DefaultScreen()))
+	xsettingsAtom = InternAtom("_XSETTINGS_SETTINGS")
+	managerAtom = InternAtom("MANAGER")
+	updateXSettingsOwner()
+}
+
+// XSettingInt returns the integer value of the named desktop setting.
+func XSettingInt(name string) (int, bool) {
+	value, ok := xsettings[name].(int)
+	return value, ok
+}
+
+// XSettingString returns the string value of the named desktop setting.
+func XSettingString(name string) (string, bool) {
+	value, ok := xsettings[name].(string)
+	return value, ok
+}
+
+// ProcessXSettingsEvent checks whether the event relates to the desktop
+// settings and updates them if so. Returns true if the event was consumed.
+func ProcessXSettingsEvent(evt *Event) bool {
+	switch evt.Type() {
+	case PropertyNotifyType:
+		pe := evt.ToPropertyEvent()
+		if xsettingsOwner != 0 && pe.Window() == xsettingsOwner && pe.Atom() == xsettingsAtom {
+			readXSettings()
+			return true
+		}
+	case DestroyNotifyType:
+		if xsettingsOwner != 0 && evt.ToDestroyWindowEvent().Window() == xsettingsOwner {
+			updateXSettingsOwner()
+			return true
+		}
+	case ClientMessageType:
+		cm := evt.ToClientMessageEvent()
+		if cm.SubType() == managerAtom && cm.Format() == 32 && Atom(cm.Long(1)) == xsettingsSelectionAtom {
+			updateXSettingsOwner()
+			return true
+		}
+	}
+	return false
+}
+
+func updateXSettingsOwner() {
+	// The server is grabbed so that the owner can't go away between
+	// looking it up and selecting input on it.
+	C.XGrabServer(display)
+	xsettingsOwner = Window(C.XGetSelectionOwner(display, C.Atom(xsettingsSelectionAtom)))
+	if xsettingsOwner != 0 {
+		xsettingsOwner.SelectInput(PropertyChangeMask | StructureNotifyMask)
+	}
+	C.XUngrabServer(display)
+	C.XFlush(display)
+	readXSettings()
+}
+
+func readXSettings() {
+	settings := make(map[string]interface{})
+	if xsettingsOwner != 0 {
+		actualType, actualFormat, count, data := xsettingsOwner.Property(xsettingsAtom, xsettingsAtom)
+		if data != nil {
+			if actualType == xsettingsAtom && actualFormat == 8 {
+				if parsed, ok := parseXSettings(C.GoBytes(data, C.int(count))); ok {
+					settings = parsed
+				}
+			}
+			C.XFree(data)
+		}
+	}
+	xsettings = settings
+	if XSettingsChanged != nil {
+		XSettingsChanged()
+	}
+}
+
+func parseXSettings(data []byte) (map[string]interface{}, bool) {
+	if len(data) < 12 {
+		return nil, false
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if data[0] != 0 {
+		order = binary.BigEndian
+	}
+	count := int(order.Uint32(data[8:]))
+	settings := make(map[string]interface{}, count)
+	data = data[12:]
+	for i := 0; i < count; i++ {
+		if len(data) < 4 {
+			return nil, false
+		}
+		kind := data[0]
+		nameLen := int(order.Uint16(data[2:]))
+		data = data[4:]
+		padded := pad4(nameLen)
+		// The name is followed by a 4 byte serial
+		if len(data) < padded+4 {
+			return nil, false
+		}
+		name := string(data[:nameLen])
+		data = data[padded+4:]
+		switch kind {
+		case xsettingsTypeInteger:
+			if len(data) < 4 {
+				return nil, false
+			}
+			settings[name] = int(int32(order.Uint32(data)))
+			data = data[4:]
+		case xsettingsTypeString:
+			if len(data) < 4 {
+				return nil, false
+			}
+			valueLen := int(order.Uint32(data))
+			data = data[4:]
+			padded = pad4(valueLen)
+			if len(data) < padded {
+				return nil, false
+			}
+			settings[name] = string(data[:valueLen])
+			data = data[padded:]
+		case xsettingsTypeColor:
+			if len(data) < 8 {
+				return nil, false
+			}
+			// Channels are stored in the order red, blue, green, alpha
+			settings[name] = color.RGBA(int(order.Uint16(data)>>8), int(order.Uint16(data[4:])>>8), int(order.Uint16(data[2:])>>8), float64(order.Uint16(data[6:]))/0xFFFF)
+			data = data[8:]
+		default:
+			return nil, false
+		}
+	}
+	return settings, true
+}
+
+func pad4(length int) int {
+	return (length + 3) &^ 3
+}
@@ -0,0 +1,83 @@
+package x11
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/ui/color"
+)
+
+// settingsBlob holds settings in the form an XSETTINGS manager publishes them on an x86 desktop.
+var settingsBlob = []byte{
+	// Byte order, padding, serial, setting count
+	0x00, 0x00, 0x00, 0x00, 0x07, 0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00,
+	// Net/ThemeName = "Adwaita-dark"
+	0x01, 0x00, 0x0d, 0x00, 0x4e, 0x65, 0x74, 0x2f, 0x54, 0x68, 0x65, 0x6d,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x0c, 0x00, 0x00, 0x00, 0x41, 0x64, 0x77, 0x61, 0x69, 0x74, 0x61, 0x2d,
+	0x64, 0x61, 0x72, 0x6b,
+	// Net/CursorBlinkTime = 1200
+	0x00, 0x00, 0x13, 0x00, 0x4e, 0x65, 0x74, 0x2f, 0x43, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x42, 0x6c, 0x69, 0x6e, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0xb0, 0x04, 0x00, 0x00,
+	// Xft/DPI = 98304
+	0x00, 0x00, 0x07, 0x00, 0x58, 0x66, 0x74, 0x2f, 0x44, 0x50, 0x49, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x80, 0x01, 0x00,
+	// Gtk/Color = red 0xffff, blue 0x8000, green 0, alpha 0xffff
+	0x02, 0x00, 0x09, 0x00, 0x47, 0x74, 0x6b, 0x2f, 0x43, 0x6f, 0x6c, 0x6f,
+	0x72, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xff, 0xff, 0x00, 0x80,
+	0x00, 0x00, 0xff, 0xff,
+}
+
+func TestParseXSettings(t *testing.T) {
+	settings, ok := parseXSettings(settingsBlob)
+	if !ok {
+		t.Fatal("parseXSettings() failed")
+	}
+	expected := map[string]interface{}{
+		"Net/ThemeName":       "Adwaita-dark",
+		"Net/CursorBlinkTime": 1200,
+		"Xft/DPI":             98304,
+		"Gtk/Color":           color.RGB(255, 0, 128),
+	}
+	if len(settings) != len(expected) {
+		t.Errorf("parsed %d settings, expected %d", len(settings), len(expected))
+	}
+	for name, value := range expected {
+		if settings[name] != value {
+			t.Errorf("%s = %v, expected %v", name, settings[name], value)
+		}
+	}
+}
+
+func TestParseXSettingsBigEndian(t *testing.T) {
+	settings, ok := parseXSettings([]byte{
+		// Byte order, padding, serial, setting count
+		0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x01,
+		// Net/CursorBlink = 0
+		0x00, 0x00, 0x00, 0x0f, 0x4e, 0x65, 0x74, 0x2f, 0x43, 0x75, 0x72, 0x73,
+		0x6f, 0x72, 0x42, 0x6c, 0x69, 0x6e, 0x6b, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+	})
+	if !ok {
+		t.Fatal("parseXSettings() failed")
+	}
+	if value, exists := settings["Net/CursorBlink"]; !exists || value != 0 {
+		t.Errorf("Net/CursorBlink = %v, expected 0", value)
+	}
+}
+
+func TestParseXSettingsTruncated(t *testing.T) {
+	for length := 0; length < len(settingsBlob); length++ {
+		if _, ok := parseXSettings(settingsBlob[:length]); ok {
+			t.Errorf("parseXSettings() accepted the settings cut short at %d bytes", length)
+		}
+	}
+}
+
+func TestParseXSettingsUnknownType(t *testing.T) {
+	blob := append([]byte(nil), settingsBlob...)
+	blob[12] = 3
+	if _, ok := parseXSettings(blob); ok {
+		t.Error("parseXSettings() accepted a setting of an unknown type")
+	}
+}
@@ -34,6 +34,8 @@ type Bar interface {
 	SetupSpecialMenu(which SpecialMenuType, menu Menu)
 	// ProcessKeyDown is called to process KeyDown events prior to anything else receiving them.
 	ProcessKeyDown(evt *event.KeyDown)
+	// ProcessKeyUp is called to process KeyUp events prior to anything else receiving them.
+	ProcessKeyUp(evt *event.KeyUp)
 }
 
 var (
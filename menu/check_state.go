@@ -0,0 +1,19 @@
+package menu
+
+// Possible values for CheckState.
+const (
+	Unchecked CheckState = iota
+	Mixed
+	Checked
+)
+
+// CheckState represents the check mark state of an item.
+type CheckState int
+
+// CheckStateFor returns Checked if 'checked' is true and Unchecked if it is false.
+func CheckStateFor(checked bool) CheckState {
+	if checked {
+		return Checked
+	}
+	return Unchecked
+}
@@ -0,0 +1,21 @@
+package menu
+
+import (
+	"github.com/richardwilkes/ui/event"
+)
+
+// ForContextMenu returns the menu being assembled for the ContextMenu event, creating it if this is
+// the first handler to ask for it. Handlers should append their items to the returned menu. When
+// the menu already has items in it, a separator is appended first, so that the items added by each
+// handler are grouped together.
+func ForContextMenu(evt *event.ContextMenu) Menu {
+	if mnu, ok := evt.Menu().(Menu); ok {
+		if count := mnu.Count(); count > 0 && mnu.Item(count-1).Title() != "" {
+			mnu.AppendItem(NewSeparator())
+		}
+		return mnu
+	}
+	mnu := NewMenu("")
+	evt.SetMenu(mnu)
+	return mnu
+}
@@ -2,11 +2,14 @@ package custom
 
 import (
 	"fmt"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/richardwilkes/toolbox/xmath/geom"
 	"github.com/richardwilkes/ui/border"
 	"github.com/richardwilkes/ui/color"
 	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/keys"
 	"github.com/richardwilkes/ui/layout/flex"
 	"github.com/richardwilkes/ui/menu"
 	"github.com/richardwilkes/ui/widget"
@@ -16,7 +19,9 @@ import (
 // MenuBar represents a set of menus.
 type MenuBar struct {
 	widget.Block
-	special map[menu.SpecialMenuType]menu.Menu
+	special      map[menu.SpecialMenuType]menu.Menu
+	keyboardItem *MenuItem
+	altPending   bool
 }
 
 var (
@@ -36,13 +41,18 @@ func AppBar(id uint64) menu.Bar {
 		mb := &MenuBar{special: make(map[menu.SpecialMenuType]menu.Menu)}
 		mb.InitTypeAndID(mb)
 		mb.Describer = func() string { return fmt.Sprintf("MenuBar #%d", mb.ID()) }
-		mb.SetBorder(border.NewLine(color.Background.AdjustBrightness(-0.25), geom.Insets{Top: 0, Left: 0, Bottom: 1, Right: 0}))
+		mb.setBorderFromTheme()
+		mb.EventHandlers().Add(event.ThemeChangedType, func(evt event.Event) { mb.setBorderFromTheme() })
 		flex.NewLayout(mb)
 		bar = mb
 	}
 	return bar
 }
 
+func (bar *MenuBar) setBorderFromTheme() {
+	bar.SetBorder(border.NewLine(color.Divider, geom.Insets{Top: 0, Left: 0, Bottom: 1, Right: 0}))
+}
+
 // AppendMenu appends a menu at the end of this bar.
 func (bar *MenuBar) AppendMenu(subMenu menu.Menu) {
 	bar.InsertMenu(subMenu, -1)
@@ -95,14 +105,169 @@ func (bar *MenuBar) SetupSpecialMenu(which menu.SpecialMenuType, mnu menu.Menu)
 
 // ProcessKeyDown is called to process KeyDown events prior to anything else receiving them.
 func (bar *MenuBar) ProcessKeyDown(evt *event.KeyDown) {
+	code := evt.Code()
+	if code == keys.VirtualKeyOptionLeft || code == keys.VirtualKeyOptionRight {
+		// Pressing and releasing Alt by itself moves the keyboard focus to the menu bar
+		bar.altPending = true
+		return
+	}
+	bar.altPending = false
+	if bar.keyboardItem != nil {
+		if bar.processKeyboardModeKeyDown(evt) {
+			evt.Finish()
+			return
+		}
+		bar.exitKeyboardMode()
+	}
+	mods := evt.Modifiers() & keys.NonStickyModifiers
+	if code == keys.VirtualKeyF10 && mods == 0 {
+		bar.enterKeyboardMode(nil)
+		evt.Finish()
+		return
+	}
+	if mods == keys.OptionModifier && code < utf8.RuneSelf {
+		if item := bar.itemForMnemonic(unicode.ToLower(rune(code))); item != nil {
+			bar.openMenu(item)
+			evt.Finish()
+			return
+		}
+	}
 	for _, child := range bar.Children() {
-		switch item := child.(type) {
-		case *MenuItem:
+		if item, ok := child.(*MenuItem); ok {
 			if item.processKeyDown(evt) {
-				break
+				return
+			}
+		}
+	}
+}
+
+// ProcessKeyUp is called to process KeyUp events prior to anything else receiving them.
+func (bar *MenuBar) ProcessKeyUp(evt *event.KeyUp) {
+	if bar.altPending && (evt.Code() == keys.VirtualKeyOptionLeft || evt.Code() == keys.VirtualKeyOptionRight) {
+		bar.altPending = false
+		if bar.keyboardItem != nil {
+			bar.exitKeyboardMode()
+		} else {
+			bar.enterKeyboardMode(nil)
+		}
+		evt.Finish()
+	}
+}
+
+// processKeyboardModeKeyDown handles a key while the menu bar has the keyboard focus, but none of
+// its menus are open. Returns true if the key was consumed.
+func (bar *MenuBar) processKeyboardModeKeyDown(evt *event.KeyDown) bool {
+	switch evt.Code() {
+	case keys.VirtualKeyLeft:
+		bar.enterKeyboardMode(bar.adjacentItem(bar.keyboardItem, -1))
+	case keys.VirtualKeyRight:
+		bar.enterKeyboardMode(bar.adjacentItem(bar.keyboardItem, 1))
+	case keys.VirtualKeyUp, keys.VirtualKeyDown, keys.VirtualKeyReturn, keys.VirtualKeyNumPadEnter, keys.VirtualKeySpace:
+		bar.openMenu(bar.keyboardItem)
+	case keys.VirtualKeyEscape:
+		bar.exitKeyboardMode()
+	default:
+		if evt.Modifiers()&(keys.ControlModifier|keys.CommandModifier) != 0 {
+			return false
+		}
+		item := bar.itemForMnemonic(unicode.ToLower(evt.Rune()))
+		if item == nil {
+			return false
+		}
+		bar.openMenu(item)
+	}
+	return true
+}
+
+// enterKeyboardMode gives the menu bar the keyboard focus, highlighting the specified item. Pass
+// in nil to highlight the first item.
+func (bar *MenuBar) enterKeyboardMode(item *MenuItem) {
+	if item == nil {
+		item = bar.adjacentItem(nil, 1)
+	}
+	bar.exitKeyboardMode()
+	if item != nil {
+		bar.keyboardItem = item
+		item.setHighlighted(true)
+	}
+}
+
+func (bar *MenuBar) exitKeyboardMode() {
+	if bar.keyboardItem != nil {
+		bar.keyboardItem.setHighlighted(false)
+		bar.keyboardItem = nil
+	}
+}
+
+func (bar *MenuBar) openMenu(item *MenuItem) {
+	bar.exitKeyboardMode()
+	if item != nil && item.menu != nil {
+		item.menu.show(true)
+	}
+}
+
+// openAdjacentMenu closes the currently open menu and opens the one next to it in the direction
+// indicated by 'delta'.
+func (bar *MenuBar) openAdjacentMenu(delta int) {
+	if current := bar.findOpenMenu(); current != nil {
+		owner := current.owner
+		current.close(nil)
+		if next := bar.adjacentItem(current.item, delta); next != nil && next != current.item {
+			bar.openMenu(next)
+		} else {
+			bar.enterKeyboardMode(current.item)
+			if owner != nil {
+				owner.ToFront()
+			}
+		}
+	}
+}
+
+// adjacentItem returns the enabled item next to the specified item in the direction indicated by
+// 'delta', wrapping around at either end. Pass in nil to start from the beginning or end.
+func (bar *MenuBar) adjacentItem(item *MenuItem, delta int) *MenuItem {
+	var items []*MenuItem
+	current := -1
+	for _, child := range bar.Children() {
+		if one, ok := child.(*MenuItem); ok && one.Enabled() {
+			if one == item {
+				current = len(items)
 			}
+			items = append(items, one)
 		}
 	}
+	count := len(items)
+	if count == 0 {
+		return nil
+	}
+	switch {
+	case current == -1 && delta < 0:
+		return items[count-1]
+	case current == -1:
+		return items[0]
+	default:
+		return items[(current+delta+count)%count]
+	}
+}
+
+func (bar *MenuBar) itemForMnemonic(ch rune) *MenuItem {
+	if ch != 0 {
+		for _, child := range bar.Children() {
+			if item, ok := child.(*MenuItem); ok && item.Enabled() && item.mnemonic == ch {
+				return item
+			}
+		}
+	}
+	return nil
+}
+
+func (bar *MenuBar) findOpenMenu() *Menu {
+	for _, child := range bar.Children() {
+		if item, ok := child.(*MenuItem); ok && item.menu != nil && item.menuOpen {
+			return item.menu
+		}
+	}
+	return nil
 }
 
 func (bar *MenuBar) findRoot() menuRoot {
@@ -19,4 +19,5 @@ func Install() {
 		return NewItemWithKeyAndModifiers(title, keyCode, modifiers, handler)
 	}
 	menu.NewSeparator = func() menu.Item { return NewSeparator() }
+	menu.NewSearchItem = func(bar menu.Bar) menu.Item { return NewSearchItem(bar) }
 }
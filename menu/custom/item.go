@@ -2,10 +2,12 @@ package custom
 
 import (
 	"fmt"
+	"math"
 	"unicode/utf8"
 
 	"github.com/richardwilkes/toolbox/xmath/geom"
 	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/draw"
 	"github.com/richardwilkes/ui/event"
 	"github.com/richardwilkes/ui/keys"
 	"github.com/richardwilkes/ui/menu"
@@ -21,9 +23,21 @@ type MenuItem struct {
 	menu         *Menu
 	menuParent   menuRoot
 	title        string
+	text         string
+	mnemonic     rune
+	mnemonicPos  int
+	image        *draw.Image
+	checkState   menu.CheckState
 	pos          float64
+	checkSpace   float64
+	imageSpace   float64
+	matchStart   int
+	matchEnd     int
+	checkable    bool
+	radio        bool
 	highlighted  bool
 	menuOpen     bool
+	searchResult bool
 }
 
 // NewItem creates a new item with no key accelerator.
@@ -39,6 +53,7 @@ func NewItemWithKey(title string, keyCode int, handler event.Handler) *MenuItem
 // NewItemWithKeyAndModifiers creates a new item.
 func NewItemWithKeyAndModifiers(title string, keyCode int, modifiers keys.Modifiers, handler event.Handler) *MenuItem {
 	item := &MenuItem{Theme: StdTheme, title: title, keyCode: keyCode, keyModifiers: modifiers}
+	item.text, item.mnemonic, item.mnemonicPos = menu.ParseMnemonic(title)
 	item.InitTypeAndID(item)
 	item.Describer = func() string { return fmt.Sprintf("MenuItem #%d (%s)", item.ID(), item.title) }
 	item.SetSizer(item)
@@ -62,6 +77,53 @@ func (item *MenuItem) Title() string {
 	return item.title
 }
 
+// Mnemonic returns the lower-cased character that can be typed to trigger this item while its menu
+// is open. A value of 0 indicates no mnemonic is attached.
+func (item *MenuItem) Mnemonic() rune {
+	return item.mnemonic
+}
+
+// CheckState returns the state of this item's check mark.
+func (item *MenuItem) CheckState() menu.CheckState {
+	return item.checkState
+}
+
+// SetCheckState sets the state of this item's check mark. Once this has been called, the menu the
+// item is in will reserve space for check marks, even when the item is unchecked.
+func (item *MenuItem) SetCheckState(state menu.CheckState) {
+	item.checkable = true
+	if item.checkState != state {
+		item.checkState = state
+		item.Repaint()
+	}
+}
+
+// Radio returns true if this item's check mark is drawn as a radio indicator.
+func (item *MenuItem) Radio() bool {
+	return item.radio
+}
+
+// SetRadio sets whether this item's check mark is drawn as a radio indicator.
+func (item *MenuItem) SetRadio(radio bool) {
+	if item.radio != radio {
+		item.radio = radio
+		item.Repaint()
+	}
+}
+
+// Image returns the image shown alongside this item's title, or nil.
+func (item *MenuItem) Image() *draw.Image {
+	return item.image
+}
+
+// SetImage sets the image shown alongside this item's title. Pass in nil to remove it.
+func (item *MenuItem) SetImage(img *draw.Image) {
+	if item.image != img {
+		item.image = img
+		item.Repaint()
+	}
+}
+
 // KeyCode returns the key code that can be used to trigger this item. A value of 0 indicates no
 // key is attached.
 func (item *MenuItem) KeyCode() int {
@@ -75,9 +137,14 @@ func (item *MenuItem) KeyModifiers() keys.Modifiers {
 
 // Sizes implements Sizer
 func (item *MenuItem) Sizes(hint geom.Size) (min, pref, max geom.Size) {
-	pref = item.Theme.TitleFont.Measure(item.title)
-	pref.Width += item.Theme.HMargin*2 + item.Theme.KeySpacing
+	pref = item.Theme.TitleFont.Measure(item.text)
+	pref.Width += item.Theme.HMargin*2 + item.Theme.KeySpacing + item.checkSpace + item.imageSpace
 	pref.Height += item.Theme.VMargin * 2
+	if item.image != nil {
+		if height := item.image.Size().Height + item.Theme.VMargin*2; pref.Height < height {
+			pref.Height = height
+		}
+	}
 	pref.GrowToInteger()
 	if item.keyCode != 0 {
 		mapping := keys.MappingForKeyCode(item.keyCode)
@@ -104,6 +171,10 @@ func (item *MenuItem) Sizes(hint geom.Size) (min, pref, max geom.Size) {
 	return pref, pref, pref
 }
 
+func (item *MenuItem) checkMarkSize() float64 {
+	return math.Ceil(item.Theme.TitleFont.Ascent() + item.Theme.TitleFont.Descent())
+}
+
 func (item *MenuItem) calculateAcceleratorPosition() float64 {
 	pos := item.Theme.HMargin
 	if item.keyCode != 0 {
@@ -121,9 +192,40 @@ func (item *MenuItem) paint(evt event.Event) {
 		gc := paintEvent.GC()
 		gc.SetColor(item.currentBackground())
 		gc.FillRect(bounds)
-		size := item.Theme.TitleFont.Measure(item.title)
+		size := item.Theme.TitleFont.Measure(item.text)
 		gc.SetColor(item.textColor())
-		gc.DrawString(bounds.X+item.Theme.HMargin, bounds.Y+(bounds.Height-size.Height)/2, item.title, item.Theme.TitleFont)
+		x := bounds.X + item.Theme.HMargin
+		if item.checkSpace > 0 {
+			box := item.checkMarkSize()
+			item.paintCheckMark(gc, geom.Rect{Point: geom.Point{X: x, Y: bounds.Y + (bounds.Height-box)/2}, Size: geom.Size{Width: box, Height: box}})
+			x += item.checkSpace
+		}
+		if item.image != nil {
+			img := item.image
+			if !item.Enabled() {
+				img = img.AcquireDisabled()
+				defer img.Release()
+			}
+			gc.DrawImage(img, geom.Point{X: x, Y: bounds.Y + (bounds.Height-img.Size().Height)/2})
+		}
+		x += item.imageSpace
+		y := bounds.Y + (bounds.Height-size.Height)/2
+		if item.matchEnd > item.matchStart && item.matchEnd <= len(item.text) {
+			left := x + item.Theme.TitleFont.Measure(item.text[:item.matchStart]).Width
+			width := item.Theme.TitleFont.Measure(item.text[item.matchStart:item.matchEnd]).Width
+			gc.SetColor(item.Theme.MatchBackground)
+			gc.FillRect(geom.Rect{Point: geom.Point{X: left, Y: y}, Size: geom.Size{Width: width, Height: size.Height}})
+			gc.SetColor(item.textColor())
+		}
+		gc.DrawString(x, y, item.text, item.Theme.TitleFont)
+		if item.mnemonicPos >= 0 {
+			runes := []rune(item.text)
+			left := x + item.Theme.TitleFont.Measure(string(runes[:item.mnemonicPos])).Width
+			width := item.Theme.TitleFont.Measure(string(runes[item.mnemonicPos])).Width
+			y += item.Theme.TitleFont.Ascent() + 1.5
+			gc.SetStrokeWidth(1)
+			gc.StrokeLine(left, y, left+width, y)
+		}
 		if item.keyCode != 0 && item.pos > 0 {
 			mapping := keys.MappingForKeyCode(item.keyCode)
 			if mapping != nil {
@@ -152,6 +254,32 @@ func (item *MenuItem) paint(evt event.Event) {
 	}
 }
 
+func (item *MenuItem) paintCheckMark(gc *draw.Graphics, bounds geom.Rect) {
+	switch item.checkState {
+	case menu.Mixed:
+		gc.Save()
+		gc.SetStrokeWidth(2)
+		gc.StrokeLine(bounds.X+bounds.Width*0.25, bounds.Y+bounds.Height*0.5, bounds.X+bounds.Width*0.75, bounds.Y+bounds.Height*0.5)
+		gc.Restore()
+	case menu.Checked:
+		if item.radio {
+			dot := bounds
+			dot.InsetUniform(bounds.Width * 0.3)
+			gc.Ellipse(dot)
+			gc.FillPath()
+			return
+		}
+		gc.Save()
+		gc.SetStrokeWidth(2)
+		gc.BeginPath()
+		gc.MoveTo(bounds.X+bounds.Width*0.2, bounds.Y+bounds.Height*0.55)
+		gc.LineTo(bounds.X+bounds.Width*0.4, bounds.Y+bounds.Height*0.75)
+		gc.LineTo(bounds.X+bounds.Width*0.8, bounds.Y+bounds.Height*0.25)
+		gc.StrokePath()
+		gc.Restore()
+	}
+}
+
 func (item *MenuItem) currentBackground() color.Color {
 	switch {
 	case !item.Enabled():
@@ -176,10 +304,20 @@ func (item *MenuItem) textColor() color.Color {
 }
 
 func (item *MenuItem) mouseDown(evt event.Event) {
+	if bar, ok := item.menuParent.(*MenuBar); ok {
+		bar.exitKeyboardMode()
+	}
 	item.highlighted = true
 	item.Repaint()
 }
 
+func (item *MenuItem) setHighlighted(highlighted bool) {
+	if item.highlighted != highlighted {
+		item.highlighted = highlighted
+		item.Repaint()
+	}
+}
+
 func (item *MenuItem) setMenuOpen(menuOpen bool) {
 	if item.menuOpen != menuOpen {
 		item.menuOpen = menuOpen
@@ -192,14 +330,30 @@ func (item *MenuItem) mouseOver(where geom.Point) {
 	if item.Enabled() {
 		bounds := item.LocalInsetBounds()
 		highlighted := bounds.ContainsPoint(item.FromWindow(where))
-		if item.highlighted != highlighted {
-			item.highlighted = highlighted
-			item.Repaint()
+		if !highlighted && item.menuOpen {
+			// Leave the item with the open sub-menu highlighted until something else is chosen
+			return
 		}
-		if highlighted && item.menu != nil && !item.menuOpen {
-			if leafMenu := item.findRoot().findLeafOpenMenu(); leafMenu != nil {
-				leafMenu.close(nil)
-				item.menu.open(nil)
+		item.setHighlighted(highlighted)
+		if highlighted {
+			switch parent := item.menuParent.(type) {
+			case *MenuBar:
+				// Only switch menus if one is already open
+				if item.menu != nil && !item.menuOpen {
+					if open := parent.findOpenMenu(); open != nil {
+						open.close(nil)
+						item.menu.show(false)
+					}
+				}
+			case *Menu:
+				parent.highlightOnly(item)
+				if open := parent.findOpenSubMenu(); open != nil && open != item.menu {
+					open.close(nil)
+					parent.wnd.ToFront()
+				}
+				if item.menu != nil && !item.menuOpen {
+					item.menu.show(false)
+				}
 			}
 		}
 	}
@@ -217,8 +371,7 @@ func (item *MenuItem) mouseUp(evt event.Event) {
 	if mouseUp, ok := evt.(*event.MouseUp); ok {
 		bounds := item.LocalInsetBounds()
 		if bounds.ContainsPoint(item.FromWindow(mouseUp.Where())) {
-			event.Dispatch(event.NewSelection(item))
-			event.Dispatch(event.NewClosing(item))
+			item.activate()
 		}
 	}
 }
@@ -250,6 +403,25 @@ func (item *MenuItem) keyDown(evt event.Event) {
 	}
 }
 
+// activate the item. Items with a sub-menu open it, while other items are selected and then close
+// the menus they are in.
+func (item *MenuItem) activate() {
+	event.Dispatch(event.NewSelection(item))
+	if item.menu == nil {
+		event.Dispatch(event.NewClosing(item))
+	}
+}
+
+// activateFromKeyboard is similar to activate, but highlights the first item of a sub-menu when
+// opening it.
+func (item *MenuItem) activateFromKeyboard() {
+	if item.menu != nil {
+		item.menu.show(true)
+	} else {
+		item.activate()
+	}
+}
+
 func (item *MenuItem) validate() {
 	evt := event.NewValidate(item)
 	event.Dispatch(evt)
@@ -279,10 +451,3 @@ func (item *MenuItem) processKeyDown(evt *event.KeyDown) bool {
 	}
 	return false
 }
-
-func (item *MenuItem) findRoot() menuRoot {
-	if item.menuParent != nil {
-		return item.menuParent
-	}
-	return item.menu
-}
@@ -2,6 +2,9 @@ package custom
 
 import (
 	"fmt"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/richardwilkes/toolbox/xmath/geom"
 	"github.com/richardwilkes/ui"
@@ -9,6 +12,7 @@ import (
 	"github.com/richardwilkes/ui/color"
 	"github.com/richardwilkes/ui/draw/align"
 	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/keys"
 	"github.com/richardwilkes/ui/layout"
 	"github.com/richardwilkes/ui/layout/flex"
 	"github.com/richardwilkes/ui/menu"
@@ -16,6 +20,12 @@ import (
 	"github.com/richardwilkes/ui/window"
 )
 
+var (
+	// TypeAheadTimeout holds the maximum amount of time that can elapse between key strokes for
+	// them to be considered part of the same type-ahead search within an open menu.
+	TypeAheadTimeout = time.Second
+)
+
 type menuRoot interface {
 	findRoot() menuRoot
 	findLeafOpenMenu() *Menu
@@ -27,6 +37,9 @@ type Menu struct {
 	item           *MenuItem
 	menuParent     menuRoot
 	wnd            ui.Window
+	owner          ui.Window
+	typeAhead      string
+	lastTypeAhead  time.Time
 	attachToBottom bool
 }
 
@@ -38,7 +51,6 @@ func NewMenu(title string) *Menu {
 	mnu.Describer = func() string {
 		return fmt.Sprintf("Menu #%d (%s)", mnu.ID(), mnu.Title())
 	}
-	mnu.SetBorder(border.NewLine(color.Gray, geom.NewUniformInsets(1)))
 	mnu.item.EventHandlers().Add(event.SelectionType, mnu.open)
 	lay := flex.NewLayout(mnu)
 	lay.EqualColumns = true
@@ -60,10 +72,13 @@ func (mnu *Menu) AppendItem(item menu.Item) {
 func (mnu *Menu) InsertItem(item menu.Item, index int) {
 	if actual, ok := item.(ui.Widget); ok {
 		mnu.AddChildAtIndex(actual, index)
-		if mi, ok := item.(*MenuItem); ok {
+		switch mi := item.(type) {
+		case *MenuItem:
+			mi.menuParent = mnu
+		case *SearchItem:
 			mi.menuParent = mnu
 		}
-		actual.EventHandlers().Add(event.ClosingType, mnu.close)
+		actual.EventHandlers().Add(event.ClosingType, mnu.closing)
 		flexData := flex.NewData()
 		flexData.HGrab = true
 		flexData.HAlign = align.Fill
@@ -81,6 +96,7 @@ func (mnu *Menu) AppendMenu(subMenu menu.Menu) {
 func (mnu *Menu) InsertMenu(subMenu menu.Menu, index int) {
 	if actual, ok := subMenu.(*Menu); ok {
 		mnu.InsertItem(actual.item, index)
+		actual.menuParent = mnu
 	}
 }
 
@@ -100,27 +116,48 @@ func (mnu *Menu) Item(index int) menu.Item {
 }
 
 func (mnu *Menu) adjustItems(evt event.Event) {
-	var largest float64
+	var largest, checkSpace, imageSpace float64
 	for _, child := range mnu.Children() {
 		switch item := child.(type) {
 		case *MenuItem:
+			item.highlighted = false
+			item.validate()
 			pos := item.calculateAcceleratorPosition()
 			if largest < pos {
 				largest = pos
 			}
+			if item.checkable {
+				if space := item.checkMarkSize() + item.Theme.HMargin; checkSpace < space {
+					checkSpace = space
+				}
+			}
+			if item.image != nil {
+				if space := item.image.Size().Width + item.Theme.HMargin; imageSpace < space {
+					imageSpace = space
+				}
+			}
 		}
 	}
 	for _, child := range mnu.Children() {
 		switch item := child.(type) {
 		case *MenuItem:
 			item.pos = largest
-			item.highlighted = false
-			item.validate()
+			item.checkSpace = checkSpace
+			item.imageSpace = imageSpace
 		}
 	}
 }
 
 func (mnu *Menu) open(evt event.Event) {
+	mnu.show(false)
+}
+
+// show the menu. If 'highlightFirst' is true, the first enabled item in the menu will be
+// highlighted, as is appropriate when the menu is opened from the keyboard.
+func (mnu *Menu) show(highlightFirst bool) {
+	if mnu.wnd != nil {
+		return
+	}
 	bounds := mnu.item.LocalBounds()
 	where := mnu.item.ToWindow(bounds.Point)
 	focus := mnu.item.Window()
@@ -131,10 +168,16 @@ func (mnu *Menu) open(evt event.Event) {
 		where.X += bounds.Width
 	}
 	mnu.showPopup(focus, where, size)
+	if highlightFirst {
+		mnu.moveHighlight(1)
+	}
 }
 
 func (mnu *Menu) close(evt event.Event) {
 	if mnu.wnd != nil {
+		if subMenu := mnu.findOpenSubMenu(); subMenu != nil {
+			subMenu.close(nil)
+		}
 		wnd := mnu.wnd
 		mnu.wnd = nil
 		wnd.Close()
@@ -142,6 +185,189 @@ func (mnu *Menu) close(evt event.Event) {
 	}
 }
 
+// closing is called when an item in the menu has been chosen. It closes the menu and any parent
+// menus, then returns the keyboard focus to the window the menus were opened from.
+func (mnu *Menu) closing(evt event.Event) {
+	top := mnu.topMenu()
+	owner := top.owner
+	top.close(nil)
+	if bar, ok := top.menuParent.(*MenuBar); ok {
+		bar.exitKeyboardMode()
+	}
+	if owner != nil {
+		owner.ToFront()
+	}
+}
+
+// closeOneLevel closes the menu, leaving any parent menus open, and returns the keyboard focus to
+// the window the menu was opened from.
+func (mnu *Menu) closeOneLevel() {
+	owner := mnu.owner
+	mnu.close(nil)
+	switch parent := mnu.menuParent.(type) {
+	case *Menu:
+		parent.highlightOnly(mnu.item)
+	case *MenuBar:
+		parent.enterKeyboardMode(mnu.item)
+	}
+	if owner != nil {
+		owner.ToFront()
+	}
+}
+
+func (mnu *Menu) focusLost(evt event.Event) {
+	// The keyboard focus moves to a sub-menu's window when it opens, so only close things up if
+	// the focus went somewhere else.
+	if mnu.wnd != nil && mnu.findOpenSubMenu() == nil {
+		top := mnu.topMenu()
+		top.close(nil)
+		if bar, ok := top.menuParent.(*MenuBar); ok {
+			bar.exitKeyboardMode()
+		}
+	}
+}
+
+func (mnu *Menu) keyDown(evt event.Event) {
+	e, ok := evt.(*event.KeyDown)
+	if !ok {
+		return
+	}
+	switch e.Code() {
+	case keys.VirtualKeyUp:
+		mnu.moveHighlight(-1)
+	case keys.VirtualKeyDown:
+		mnu.moveHighlight(1)
+	case keys.VirtualKeyHome:
+		mnu.highlightOnly(nil)
+		mnu.moveHighlight(1)
+	case keys.VirtualKeyEnd:
+		mnu.highlightOnly(nil)
+		mnu.moveHighlight(-1)
+	case keys.VirtualKeyRight:
+		if item := mnu.highlightedItem(); item != nil && item.menu != nil {
+			item.menu.show(true)
+		} else if bar, ok := mnu.topMenu().menuParent.(*MenuBar); ok {
+			bar.openAdjacentMenu(1)
+		}
+	case keys.VirtualKeyLeft:
+		switch parent := mnu.menuParent.(type) {
+		case *Menu:
+			mnu.closeOneLevel()
+		case *MenuBar:
+			parent.openAdjacentMenu(-1)
+		}
+	case keys.VirtualKeyEscape:
+		mnu.closeOneLevel()
+	case keys.VirtualKeyReturn, keys.VirtualKeyNumPadEnter, keys.VirtualKeySpace:
+		if item := mnu.highlightedItem(); item != nil {
+			item.activateFromKeyboard()
+		}
+	default:
+		ch := e.Rune()
+		if ch <= ' ' || e.Modifiers()&(keys.ControlModifier|keys.OptionModifier|keys.CommandModifier) != 0 {
+			return
+		}
+		mnu.processChar(unicode.ToLower(ch))
+	}
+	evt.Finish()
+}
+
+// processChar activates the item with the mnemonic 'ch'. If more than one item has that mnemonic,
+// the highlight cycles between them instead. If no item has that mnemonic, the character is added
+// to the type-ahead search.
+func (mnu *Menu) processChar(ch rune) {
+	var matches []*MenuItem
+	for _, item := range mnu.enabledItems() {
+		if item.mnemonic == ch {
+			matches = append(matches, item)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		now := time.Now()
+		if now.Sub(mnu.lastTypeAhead) > TypeAheadTimeout {
+			mnu.typeAhead = ""
+		}
+		mnu.lastTypeAhead = now
+		mnu.typeAhead += string(ch)
+		for _, item := range mnu.enabledItems() {
+			if strings.HasPrefix(strings.ToLower(item.text), mnu.typeAhead) {
+				mnu.highlightOnly(item)
+				break
+			}
+		}
+	case 1:
+		mnu.highlightOnly(matches[0])
+		matches[0].activateFromKeyboard()
+	default:
+		next := matches[0]
+		current := mnu.highlightedItem()
+		for i, item := range matches {
+			if item == current && i+1 < len(matches) {
+				next = matches[i+1]
+				break
+			}
+		}
+		mnu.highlightOnly(next)
+	}
+}
+
+func (mnu *Menu) enabledItems() []*MenuItem {
+	var items []*MenuItem
+	for _, child := range mnu.Children() {
+		if item, ok := child.(*MenuItem); ok && item.Enabled() {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (mnu *Menu) highlightedItem() *MenuItem {
+	for _, child := range mnu.Children() {
+		if item, ok := child.(*MenuItem); ok && item.highlighted {
+			return item
+		}
+	}
+	return nil
+}
+
+// highlightOnly highlights the specified item and removes the highlight from all others. Pass in
+// nil to remove the highlight from all items.
+func (mnu *Menu) highlightOnly(target *MenuItem) {
+	for _, child := range mnu.Children() {
+		if item, ok := child.(*MenuItem); ok {
+			item.setHighlighted(item == target)
+		}
+	}
+}
+
+// moveHighlight moves the highlight to the next enabled item in the direction indicated by
+// 'delta', wrapping around at either end.
+func (mnu *Menu) moveHighlight(delta int) {
+	items := mnu.enabledItems()
+	count := len(items)
+	if count == 0 {
+		return
+	}
+	current := -1
+	for i, item := range items {
+		if item.highlighted {
+			current = i
+			break
+		}
+	}
+	var next int
+	switch {
+	case current == -1 && delta < 0:
+		next = count - 1
+	case current == -1:
+		next = 0
+	default:
+		next = (current + delta + count) % count
+	}
+	mnu.highlightOnly(items[next])
+}
+
 // Dispose releases any operating system resources associated with this menu. It will also
 // call Dispose() on all menu items it contains.
 func (mnu *Menu) Dispose() {
@@ -167,6 +393,10 @@ func (mnu *Menu) Popup(windowID uint64, where geom.Point, width float64, item me
 }
 
 func (mnu *Menu) preparePopup(wnd ui.Window, where *geom.Point, width float64) geom.Size {
+	mnu.SetBorder(border.NewLine(color.MenuBorder, geom.NewUniformInsets(1)))
+	if search := mnu.searchItem(); search != nil {
+		search.reset()
+	}
 	mnu.adjustItems(nil)
 	lay := mnu.Layout()
 	_, pref, _ := lay.Sizes(layout.NoHintSize)
@@ -182,10 +412,45 @@ func (mnu *Menu) preparePopup(wnd ui.Window, where *geom.Point, width float64) g
 func (mnu *Menu) showPopup(focus ui.Window, where geom.Point, size geom.Size) {
 	wnd := window.NewPopupWindow(focus, where, size)
 	wnd.Content().AddChild(mnu)
-	wnd.EventHandlers().Add(event.FocusLostType, mnu.close)
-	wnd.ToFront()
+	wnd.Content().EventHandlers().Add(event.KeyDownType, mnu.keyDown)
+	wnd.EventHandlers().Add(event.FocusLostType, mnu.focusLost)
 	mnu.wnd = wnd
+	mnu.owner = focus
+	mnu.typeAhead = ""
 	mnu.item.setMenuOpen(true)
+	wnd.ToFront()
+	if search := mnu.searchItem(); search != nil {
+		wnd.SetFocus(search.field)
+	}
+}
+
+// relayout resizes the open menu to fit its current items. The menu will not become narrower than
+// it already is.
+func (mnu *Menu) relayout() {
+	if mnu.wnd == nil {
+		return
+	}
+	mnu.adjustItems(nil)
+	lay := mnu.Layout()
+	_, pref, _ := lay.Sizes(layout.NoHintSize)
+	if width := mnu.Bounds().Width; pref.Width < width {
+		pref.Width = width
+	}
+	mnu.SetBounds(geom.Rect{Size: pref})
+	lay.Layout()
+	frame := mnu.wnd.ContentFrame()
+	frame.Size = pref
+	mnu.wnd.SetContentFrame(frame)
+	mnu.Repaint()
+}
+
+func (mnu *Menu) searchItem() *SearchItem {
+	for _, child := range mnu.Children() {
+		if item, ok := child.(*SearchItem); ok {
+			return item
+		}
+	}
+	return nil
 }
 
 func (mnu *Menu) processKeyDown(evt *event.KeyDown) bool {
@@ -202,11 +467,32 @@ func (mnu *Menu) processKeyDown(evt *event.KeyDown) bool {
 
 func (mnu *Menu) findRoot() menuRoot {
 	if mnu.menuParent != nil {
-		return mnu.menuParent
+		return mnu.menuParent.findRoot()
 	}
 	return mnu
 }
 
+// topMenu returns the outermost open menu this menu is nested within, which may be itself.
+func (mnu *Menu) topMenu() *Menu {
+	top := mnu
+	for {
+		if parent, ok := top.menuParent.(*Menu); ok && parent.wnd != nil {
+			top = parent
+		} else {
+			return top
+		}
+	}
+}
+
+func (mnu *Menu) findOpenSubMenu() *Menu {
+	for _, child := range mnu.Children() {
+		if item, ok := child.(*MenuItem); ok && item.menu != nil && item.menuOpen {
+			return item.menu
+		}
+	}
+	return nil
+}
+
 func (mnu *Menu) findLeafOpenMenu() *Menu {
 	for _, child := range mnu.Children() {
 		if item, ok := child.(*MenuItem); ok && item.menu != nil && item.menuOpen {
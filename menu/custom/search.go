@@ -0,0 +1,253 @@
+package custom
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/border"
+	"github.com/richardwilkes/ui/draw"
+	"github.com/richardwilkes/ui/draw/align"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/keys"
+	"github.com/richardwilkes/ui/layout/flex"
+	"github.com/richardwilkes/ui/menu"
+	"github.com/richardwilkes/ui/widget"
+	"github.com/richardwilkes/ui/widget/textfield"
+)
+
+var (
+	// MaxSearchResults holds the maximum number of matching items a SearchItem will list.
+	MaxSearchResults = 20
+	// SearchPathSeparator is placed between the titles of the menus leading to a matching item.
+	SearchPathSeparator = " ▸ "
+)
+
+// SearchItem represents a menu item holding a field that searches the titles of all of the items
+// within a menu bar. Matching items are listed below it and may be chosen like any other item.
+type SearchItem struct {
+	widget.Block
+	Theme      *Theme
+	field      *textfield.TextField
+	bar        menu.Bar
+	menuParent *Menu
+	results    []menu.Item
+}
+
+// NewSearchItem creates a new item that searches the items within the menu bar.
+func NewSearchItem(bar menu.Bar) *SearchItem {
+	item := &SearchItem{Theme: StdTheme, bar: bar}
+	item.InitTypeAndID(item)
+	item.Describer = func() string { return fmt.Sprintf("SearchItem #%d", item.ID()) }
+	item.SetBorder(border.NewEmpty(geom.Insets{Top: item.Theme.VMargin, Left: item.Theme.HMargin, Bottom: item.Theme.VMargin, Right: item.Theme.HMargin}))
+	flex.NewLayout(item)
+	item.field = textfield.New()
+	item.field.SetWatermark(i18n.Text("Search"))
+	flexData := flex.NewData()
+	flexData.HGrab = true
+	flexData.HAlign = align.Fill
+	flexData.SizeHint.Width = 200
+	item.field.SetLayoutData(flexData)
+	item.AddChild(item.field)
+	handlers := item.field.EventHandlers()
+	handlers.Add(event.ModifiedType, item.search)
+	// The field consumes the navigation keys, so intercept the ones the menu needs first
+	handlers.Prepend(event.KeyDownType, item.keyDown)
+	item.EventHandlers().Add(event.PaintType, item.paint)
+	return item
+}
+
+// Title returns this item's title.
+func (item *SearchItem) Title() string {
+	return ""
+}
+
+// Text returns the text being searched for.
+func (item *SearchItem) Text() string {
+	return item.field.Text()
+}
+
+// KeyCode returns the key code that can be used to trigger this item. A value of 0 indicates no
+// key is attached.
+func (item *SearchItem) KeyCode() int {
+	return 0
+}
+
+// KeyModifiers returns the key modifiers that are required to trigger this item.
+func (item *SearchItem) KeyModifiers() keys.Modifiers {
+	return 0
+}
+
+// SubMenu returns a sub-menu attached to this item or nil.
+func (item *SearchItem) SubMenu() menu.Menu {
+	return nil
+}
+
+// CheckState returns the state of this item's check mark.
+func (item *SearchItem) CheckState() menu.CheckState {
+	return menu.Unchecked
+}
+
+// SetCheckState sets the state of this item's check mark.
+func (item *SearchItem) SetCheckState(state menu.CheckState) {
+	// Does nothing
+}
+
+// Radio returns true if this item's check mark is drawn as a radio indicator.
+func (item *SearchItem) Radio() bool {
+	return false
+}
+
+// SetRadio sets whether this item's check mark is drawn as a radio indicator.
+func (item *SearchItem) SetRadio(radio bool) {
+	// Does nothing
+}
+
+// Image returns the image shown alongside this item's title, or nil.
+func (item *SearchItem) Image() *draw.Image {
+	return nil
+}
+
+// SetImage sets the image shown alongside this item's title.
+func (item *SearchItem) SetImage(img *draw.Image) {
+	// Does nothing
+}
+
+// Dispose releases any operating system resources associated with this item.
+func (item *SearchItem) Dispose() {
+	// Does nothing
+}
+
+func (item *SearchItem) paint(evt event.Event) {
+	if paintEvent, ok := evt.(*event.Paint); ok {
+		gc := paintEvent.GC()
+		gc.SetColor(item.Theme.Background)
+		gc.FillRect(item.LocalBounds())
+	}
+}
+
+func (item *SearchItem) keyDown(evt event.Event) {
+	if e, ok := evt.(*event.KeyDown); ok && item.menuParent != nil {
+		switch e.Code() {
+		case keys.VirtualKeyUp, keys.VirtualKeyDown, keys.VirtualKeyReturn, keys.VirtualKeyNumPadEnter, keys.VirtualKeyEscape:
+			item.menuParent.keyDown(evt)
+		}
+	}
+}
+
+// reset clears the search text and removes any results.
+func (item *SearchItem) reset() {
+	item.field.SetText("")
+	item.removeResults()
+}
+
+func (item *SearchItem) removeResults() {
+	if item.menuParent != nil {
+		for _, result := range item.results {
+			if one, ok := result.(*MenuItem); ok {
+				item.menuParent.RemoveChild(one)
+			} else if one, ok := result.(*Separator); ok {
+				item.menuParent.RemoveChild(one)
+			}
+			result.Dispose()
+		}
+	}
+	item.results = nil
+}
+
+func (item *SearchItem) search(evt event.Event) {
+	item.removeResults()
+	if item.menuParent == nil {
+		return
+	}
+	if query := strings.ToLower(strings.TrimSpace(item.field.Text())); query != "" {
+		var matches []*MenuItem
+		titles := make([]string, 0, 8)
+		menu.Walk(item.bar, func(path []string, target menu.Item) bool {
+			if target.SubMenu() != nil || target.Title() == "" {
+				return true
+			}
+			if result, ok := target.(*MenuItem); ok && result.searchResult {
+				return true
+			}
+			titles = titles[:0]
+			for _, one := range path {
+				text, _, _ := menu.ParseMnemonic(one)
+				titles = append(titles, text)
+			}
+			text, _, _ := menu.ParseMnemonic(target.Title())
+			display := strings.Join(append(titles, text), SearchPathSeparator)
+			if start, end := indexFold(display, query); start != -1 {
+				matches = append(matches, newSearchResult(target, display, start, end))
+			}
+			return len(matches) < MaxSearchResults
+		})
+		if len(matches) > 0 {
+			index := item.menuParent.IndexOfChild(item) + 1
+			sep := NewSeparator()
+			item.menuParent.InsertItem(sep, index)
+			item.results = append(item.results, sep)
+			for _, result := range matches {
+				index++
+				item.menuParent.InsertItem(result, index)
+				item.results = append(item.results, result)
+			}
+		}
+	}
+	item.menuParent.relayout()
+}
+
+// indexFold returns the byte offsets within 'text' of the first span that matches 'query' when
+// lowercased, or -1, -1 if there is none. 'query' must already be lowercased. The offsets refer to
+// 'text' itself, as lowercasing may change the length of the text.
+func indexFold(text, query string) (start, end int) {
+	for start = range text {
+		if end = prefixFold(text[start:], query); end != -1 {
+			return start, start + end
+		}
+	}
+	return -1, -1
+}
+
+// prefixFold returns the number of bytes at the start of 'text' that match 'query' when
+// lowercased, or -1 if 'text' doesn't start with a match.
+func prefixFold(text, query string) int {
+	pos := 0
+	for _, q := range query {
+		r, size := utf8.DecodeRuneInString(text[pos:])
+		if size == 0 || unicode.ToLower(r) != q {
+			return -1
+		}
+		pos += size
+	}
+	return pos
+}
+
+// newSearchResult creates an item that stands in for 'target' within the search results. It is
+// enabled only when 'target' is, and choosing it chooses 'target'. The portion of 'display'
+// between 'matchStart' and 'matchEnd' is highlighted.
+func newSearchResult(target menu.Item, display string, matchStart, matchEnd int) *MenuItem {
+	result := NewItem(strings.Replace(display, "&", "&&", -1), func(evt event.Event) {
+		if validateItem(target) {
+			event.Dispatch(event.NewSelection(target))
+		}
+	})
+	result.searchResult = true
+	result.matchStart = matchStart
+	result.matchEnd = matchEnd
+	result.EventHandlers().Add(event.ValidateType, func(evt event.Event) {
+		if !validateItem(target) {
+			evt.(*event.Validate).MarkInvalid()
+		}
+	})
+	return result
+}
+
+func validateItem(item menu.Item) bool {
+	evt := event.NewValidate(item)
+	event.Dispatch(evt)
+	return evt.Valid()
+}
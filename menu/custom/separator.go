@@ -1,6 +1,7 @@
 package custom
 
 import (
+	"github.com/richardwilkes/ui/draw"
 	"github.com/richardwilkes/ui/keys"
 	"github.com/richardwilkes/ui/menu"
 	"github.com/richardwilkes/ui/widget/separator"
@@ -40,6 +41,36 @@ func (sep *Separator) SubMenu() menu.Menu {
 	return nil
 }
 
+// CheckState returns the state of this item's check mark.
+func (sep *Separator) CheckState() menu.CheckState {
+	return menu.Unchecked
+}
+
+// SetCheckState sets the state of this item's check mark.
+func (sep *Separator) SetCheckState(state menu.CheckState) {
+	// Does nothing
+}
+
+// Radio returns true if this item's check mark is drawn as a radio indicator.
+func (sep *Separator) Radio() bool {
+	return false
+}
+
+// SetRadio sets whether this item's check mark is drawn as a radio indicator.
+func (sep *Separator) SetRadio(radio bool) {
+	// Does nothing
+}
+
+// Image returns the image shown alongside this item's title, or nil.
+func (sep *Separator) Image() *draw.Image {
+	return nil
+}
+
+// SetImage sets the image shown alongside this item's title. Pass in nil to remove it.
+func (sep *Separator) SetImage(img *draw.Image) {
+	// Does nothing
+}
+
 // Dispose releases any operating system resources associated with this item.
 func (sep *Separator) Dispose() {
 	// Does nothing
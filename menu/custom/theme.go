@@ -3,6 +3,7 @@ package custom
 import (
 	"github.com/richardwilkes/ui/color"
 	"github.com/richardwilkes/ui/font"
+	"github.com/richardwilkes/ui/theme"
 )
 
 var (
@@ -10,6 +11,10 @@ var (
 	StdTheme = NewTheme()
 )
 
+func init() {
+	theme.RegisterInitializer(func() { StdTheme.Init() })
+}
+
 // Theme contains the theme elements for MenuItems.
 type Theme struct {
 	HMargin               float64     // The amount of horizontal space on each edge.
@@ -19,6 +24,7 @@ type Theme struct {
 	KeyFont               *font.Font  // The font to use for the key binding.
 	Background            color.Color // The color to use for the background.
 	HighlightedBackground color.Color // The color to use for the background when highlighted.
+	MatchBackground       color.Color // The color to use behind the portion of a title that matches a search.
 	TextWhenLight         color.Color // The text color to use when the background is considered to be 'light'.
 	TextWhenDark          color.Color // The text color to use when the background is considered to be 'dark'.
 	TextWhenDisabled      color.Color // The text color to use when disabled.
@@ -41,8 +47,9 @@ func (theme *Theme) Init() {
 	theme.KeyFont = font.MenuCmdKey
 	theme.Background = color.Background
 	theme.HighlightedBackground = color.KeyboardFocus
-	theme.TextWhenLight = color.Black
-	theme.TextWhenDark = color.White
-	theme.TextWhenDisabled = color.Gray
+	theme.MatchBackground = color.SelectedTextBackground
+	theme.TextWhenLight = color.TextWhenLight
+	theme.TextWhenDark = color.TextWhenDark
+	theme.TextWhenDisabled = color.TextWhenDisabled
 	theme.DisabledAdjustment = -0.05
 }
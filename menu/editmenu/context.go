@@ -0,0 +1,16 @@
+package editmenu
+
+import (
+	"github.com/richardwilkes/ui/menu"
+)
+
+// AppendContextMenuItems appends the standard Cut, Copy, Paste and Select All items to the
+// specified menu. Widgets that edit or display text typically use this to populate their context
+// menu.
+func AppendContextMenuItems(m menu.Menu) {
+	AppendCutItem(m)
+	AppendCopyItem(m)
+	AppendPasteItem(m)
+	m.AppendItem(menu.NewSeparator())
+	AppendSelectAllItem(m)
+}
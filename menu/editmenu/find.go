@@ -0,0 +1,67 @@
+package editmenu
+
+import (
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/keys"
+	"github.com/richardwilkes/ui/menu"
+	"github.com/richardwilkes/ui/window"
+)
+
+// Findable defines the methods required of objects that can respond to the Find, Find Next and
+// Replace menu items. Unlike the other edit menu items, these are also offered to the ancestors of
+// the current keyboard focus, since the widget providing the find controls usually contains the
+// text being searched rather than being it.
+type Findable interface {
+	// CanFind returns true if Find() can be called successfully.
+	CanFind() bool
+	// Find presents the controls for finding text.
+	Find()
+	// CanFindNext returns true if FindNext() can be called successfully.
+	CanFindNext() bool
+	// FindNext selects the next occurrence of the text being searched for.
+	FindNext()
+	// CanReplace returns true if Replace() can be called successfully.
+	CanReplace() bool
+	// Replace presents the controls for finding and replacing text.
+	Replace()
+}
+
+// AppendFindItem adds the standard Find menu item to the specified menu.
+func AppendFindItem(m menu.Menu) {
+	InsertFindItem(m, -1)
+}
+
+// InsertFindItem adds the standard Find menu item to the specified menu.
+func InsertFindItem(m menu.Menu, index int) {
+	item := menu.NewItemWithKey(i18n.Text("Find…"), keys.VirtualKeyF, Find)
+	item.EventHandlers().Add(event.ValidateType, CanFind)
+	m.InsertItem(item, index)
+}
+
+// Find presents the controls for finding text within the current keyboard focus.
+func Find(evt event.Event) {
+	if f := findable(); f != nil {
+		f.Find()
+	}
+}
+
+// CanFind returns true if Find() can be called successfully.
+func CanFind(evt event.Event) {
+	if f := findable(); f == nil || !f.CanFind() {
+		evt.(*event.Validate).MarkInvalid()
+	}
+}
+
+// findable returns the current keyboard focus, or its nearest ancestor, that is Findable.
+func findable() Findable {
+	wnd := window.KeyWindow()
+	if wnd != nil {
+		for focus := wnd.Focus(); focus != nil; focus = focus.Parent() {
+			if f, ok := focus.(Findable); ok {
+				return f
+			}
+		}
+	}
+	return nil
+}
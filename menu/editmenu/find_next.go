@@ -0,0 +1,35 @@
+package editmenu
+
+import (
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/keys"
+	"github.com/richardwilkes/ui/menu"
+)
+
+// AppendFindNextItem adds the standard Find Next menu item to the specified menu.
+func AppendFindNextItem(m menu.Menu) {
+	InsertFindNextItem(m, -1)
+}
+
+// InsertFindNextItem adds the standard Find Next menu item to the specified menu.
+func InsertFindNextItem(m menu.Menu, index int) {
+	item := menu.NewItemWithKey(i18n.Text("Find Next"), keys.VirtualKeyG, FindNext)
+	item.EventHandlers().Add(event.ValidateType, CanFindNext)
+	m.InsertItem(item, index)
+}
+
+// FindNext selects the next occurrence of the text being searched for within the current keyboard
+// focus.
+func FindNext(evt event.Event) {
+	if f := findable(); f != nil {
+		f.FindNext()
+	}
+}
+
+// CanFindNext returns true if FindNext() can be called successfully.
+func CanFindNext(evt event.Event) {
+	if f := findable(); f == nil || !f.CanFindNext() {
+		evt.(*event.Validate).MarkInvalid()
+	}
+}
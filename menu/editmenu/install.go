@@ -9,6 +9,10 @@ import (
 func Install(bar menu.Bar) menu.Menu {
 	editMenu := menu.NewMenu(i18n.Text("Edit"))
 
+	AppendUndoItem(editMenu)
+	AppendRedoItem(editMenu)
+
+	editMenu.AppendItem(menu.NewSeparator())
 	AppendCutItem(editMenu)
 	AppendCopyItem(editMenu)
 	AppendPasteItem(editMenu)
@@ -17,6 +21,11 @@ func Install(bar menu.Bar) menu.Menu {
 	AppendDeleteItem(editMenu)
 	AppendSelectAllItem(editMenu)
 
+	editMenu.AppendItem(menu.NewSeparator())
+	AppendFindItem(editMenu)
+	AppendFindNextItem(editMenu)
+	AppendReplaceItem(editMenu)
+
 	bar.AppendMenu(editMenu)
 	return editMenu
 }
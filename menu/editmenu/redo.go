@@ -0,0 +1,34 @@
+package editmenu
+
+import (
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/keys"
+	"github.com/richardwilkes/ui/menu"
+)
+
+// AppendRedoItem appends the standard Redo menu item to the specified menu.
+func AppendRedoItem(m menu.Menu) {
+	InsertRedoItem(m, -1)
+}
+
+// InsertRedoItem adds the standard Redo menu item to the specified menu.
+func InsertRedoItem(m menu.Menu, index int) {
+	item := menu.NewItemWithKeyAndModifiers(i18n.Text("Redo"), keys.VirtualKeyZ, keys.ShiftModifier|keys.PlatformMenuModifier(), Redo)
+	item.EventHandlers().Add(event.ValidateType, CanRedo)
+	m.InsertItem(item, index)
+}
+
+// Redo makes the most recently undone edit to the current keyboard focus again.
+func Redo(evt event.Event) {
+	if u := undoable(); u != nil {
+		u.Redo()
+	}
+}
+
+// CanRedo returns true if Redo() can be called successfully.
+func CanRedo(evt event.Event) {
+	if u := undoable(); u == nil || !u.CanRedo() {
+		evt.(*event.Validate).MarkInvalid()
+	}
+}
@@ -0,0 +1,34 @@
+package editmenu
+
+import (
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/keys"
+	"github.com/richardwilkes/ui/menu"
+)
+
+// AppendReplaceItem adds the standard Replace menu item to the specified menu.
+func AppendReplaceItem(m menu.Menu) {
+	InsertReplaceItem(m, -1)
+}
+
+// InsertReplaceItem adds the standard Replace menu item to the specified menu.
+func InsertReplaceItem(m menu.Menu, index int) {
+	item := menu.NewItemWithKeyAndModifiers(i18n.Text("Replace…"), keys.VirtualKeyF, keys.OptionModifier|keys.PlatformMenuModifier(), Replace)
+	item.EventHandlers().Add(event.ValidateType, CanReplace)
+	m.InsertItem(item, index)
+}
+
+// Replace presents the controls for finding and replacing text within the current keyboard focus.
+func Replace(evt event.Event) {
+	if f := findable(); f != nil {
+		f.Replace()
+	}
+}
+
+// CanReplace returns true if Replace() can be called successfully.
+func CanReplace(evt event.Event) {
+	if f := findable(); f == nil || !f.CanReplace() {
+		evt.(*event.Validate).MarkInvalid()
+	}
+}
@@ -0,0 +1,59 @@
+package editmenu
+
+import (
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/keys"
+	"github.com/richardwilkes/ui/menu"
+	"github.com/richardwilkes/ui/window"
+)
+
+// Undoable defines the methods required of objects that can respond to the Undo and Redo menu
+// items.
+type Undoable interface {
+	// CanUndo returns true if Undo() can be called successfully.
+	CanUndo() bool
+	// Undo reverts the most recent edit.
+	Undo()
+	// CanRedo returns true if Redo() can be called successfully.
+	CanRedo() bool
+	// Redo makes the most recently undone edit again.
+	Redo()
+}
+
+// AppendUndoItem appends the standard Undo menu item to the specified menu.
+func AppendUndoItem(m menu.Menu) {
+	InsertUndoItem(m, -1)
+}
+
+// InsertUndoItem adds the standard Undo menu item to the specified menu.
+func InsertUndoItem(m menu.Menu, index int) {
+	item := menu.NewItemWithKey(i18n.Text("Undo"), keys.VirtualKeyZ, Undo)
+	item.EventHandlers().Add(event.ValidateType, CanUndo)
+	m.InsertItem(item, index)
+}
+
+// Undo reverts the most recent edit made to the current keyboard focus.
+func Undo(evt event.Event) {
+	if u := undoable(); u != nil {
+		u.Undo()
+	}
+}
+
+// CanUndo returns true if Undo() can be called successfully.
+func CanUndo(evt event.Event) {
+	if u := undoable(); u == nil || !u.CanUndo() {
+		evt.(*event.Validate).MarkInvalid()
+	}
+}
+
+// undoable returns the current keyboard focus, if it is Undoable.
+func undoable() Undoable {
+	wnd := window.KeyWindow()
+	if wnd != nil {
+		if u, ok := wnd.Focus().(Undoable); ok {
+			return u
+		}
+	}
+	return nil
+}
@@ -5,9 +5,13 @@ import (
 	"github.com/richardwilkes/ui/menu"
 )
 
-// Install adds a standard 'Help' menu to the end of the menu bar.
+// Install adds a standard 'Help' menu to the end of the menu bar. On platforms that don't provide
+// their own, the menu starts with a field for searching the items in the menu bar.
 func Install(bar menu.Bar) {
 	helpMenu := menu.NewMenu(i18n.Text("Help"))
+	if menu.NewSearchItem != nil {
+		helpMenu.AppendItem(menu.NewSearchItem(bar))
+	}
 	bar.AppendMenu(helpMenu)
 	bar.SetupSpecialMenu(menu.HelpMenu, helpMenu)
 }
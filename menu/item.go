@@ -1,6 +1,7 @@
 package menu
 
 import (
+	"github.com/richardwilkes/ui/draw"
 	"github.com/richardwilkes/ui/event"
 	"github.com/richardwilkes/ui/keys"
 )
@@ -19,6 +20,22 @@ type Item interface {
 	SubMenu() Menu
 	// Enabled returns true if this item is enabled.
 	Enabled() bool
+	// CheckState returns the state of this item's check mark.
+	CheckState() CheckState
+	// SetCheckState sets the state of this item's check mark. This is typically called from a
+	// handler for the Validate event, so that the check mark reflects the application's state each
+	// time the item is shown.
+	SetCheckState(state CheckState)
+	// Radio returns true if this item's check mark is drawn as a radio indicator.
+	Radio() bool
+	// SetRadio sets whether this item's check mark is drawn as a radio indicator, as is
+	// appropriate for one of a set of mutually exclusive choices. RadioGroup sets this for the items
+	// added to it.
+	SetRadio(radio bool)
+	// Image returns the image shown alongside this item's title, or nil.
+	Image() *draw.Image
+	// SetImage sets the image shown alongside this item's title. Pass in nil to remove it.
+	SetImage(img *draw.Image)
 	// Dispose releases any operating system resources associated with this item.
 	Dispose()
 }
@@ -32,4 +49,7 @@ var (
 	NewItemWithKeyAndModifiers func(title string, keyCode int, modifiers keys.Modifiers, handler event.Handler) Item
 	// NewSeparator creates a new separator item.
 	NewSeparator func() Item
+	// NewSearchItem creates a new item that searches the titles of all of the items within the
+	// menu bar. This will be nil on platforms that provide their own menu search.
+	NewSearchItem func(bar Bar) Item
 )
@@ -76,3 +76,8 @@ func (bar *Bar) SetupSpecialMenu(which menu.SpecialMenuType, mnu menu.Menu) {
 func (bar *Bar) ProcessKeyDown(evt *event.KeyDown) {
 	// Unused. The native implementation already gets the keys before we do.
 }
+
+// ProcessKeyUp is called to process KeyUp events prior to anything else receiving them.
+func (bar *Bar) ProcessKeyUp(evt *event.KeyUp) {
+	// Unused. The native implementation already gets the keys before we do.
+}
@@ -3,6 +3,7 @@ package macmenus
 import (
 	"fmt"
 
+	"github.com/richardwilkes/ui/draw"
 	"github.com/richardwilkes/ui/event"
 	"github.com/richardwilkes/ui/keys"
 	"github.com/richardwilkes/ui/menu"
@@ -28,7 +29,9 @@ func NewItemWithKey(title string, keyCode int, handler event.Handler) menu.Item
 
 // NewItemWithKeyAndModifiers creates a new item.
 func NewItemWithKeyAndModifiers(title string, keyCode int, modifiers keys.Modifiers, handler event.Handler) menu.Item {
-	item := &platformItem{item: platformNewItem(title, keyCode, modifiers), title: title, keyCode: keyCode, keyModifiers: modifiers, enabled: true}
+	// Mac menus don't use mnemonics, so strip any markers out
+	text, _, _ := menu.ParseMnemonic(title)
+	item := &platformItem{item: platformNewItem(text, keyCode, modifiers), title: title, keyCode: keyCode, keyModifiers: modifiers, enabled: true}
 	item.InitTypeAndID(item)
 	if handler != nil {
 		item.EventHandlers().Add(event.SelectionType, handler)
@@ -83,6 +86,45 @@ func (item *platformItem) Enabled() bool {
 	return item.enabled
 }
 
+// CheckState returns the state of this item's check mark.
+func (item *platformItem) CheckState() menu.CheckState {
+	return item.checkState
+}
+
+// SetCheckState sets the state of this item's check mark.
+func (item *platformItem) SetCheckState(state menu.CheckState) {
+	if item.checkState != state {
+		item.checkState = state
+		item.platformSetCheckState(state)
+	}
+}
+
+// Radio returns true if this item's check mark is drawn as a radio indicator.
+func (item *platformItem) Radio() bool {
+	return item.radio
+}
+
+// SetRadio sets whether this item's check mark is drawn as a radio indicator.
+func (item *platformItem) SetRadio(radio bool) {
+	if item.radio != radio {
+		item.radio = radio
+		item.platformSetRadio(radio)
+	}
+}
+
+// Image returns the image shown alongside this item's title, or nil.
+func (item *platformItem) Image() *draw.Image {
+	return item.image
+}
+
+// SetImage sets the image shown alongside this item's title. Pass in nil to remove it.
+func (item *platformItem) SetImage(img *draw.Image) {
+	if item.image != img {
+		item.image = img
+		item.platformSetImage(img)
+	}
+}
+
 func (item *platformItem) Dispose() {
 	if _, ok := itemMap[item.item]; ok {
 		if subMenu := item.SubMenu(); subMenu != nil {
@@ -13,7 +13,9 @@ import (
 
 // NewMenu creates a new menu.
 func NewMenu(title string) menu.Menu {
-	mnu := &platformMenu{title: title, menu: platformNewMenu(title)}
+	// Mac menus don't use mnemonics, so strip any markers out
+	text, _, _ := menu.ParseMnemonic(title)
+	mnu := &platformMenu{title: title, menu: platformNewMenu(text)}
 	menuMap[mnu.menu] = mnu
 	return mnu
 }
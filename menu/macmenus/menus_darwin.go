@@ -10,6 +10,8 @@ import (
 
 	"github.com/richardwilkes/toolbox/xmath/geom"
 	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/draw"
 	"github.com/richardwilkes/ui/event"
 	"github.com/richardwilkes/ui/keys"
 	"github.com/richardwilkes/ui/menu"
@@ -28,6 +30,9 @@ type platformItem struct {
 	title         string
 	keyCode       int
 	keyModifiers  keys.Modifiers
+	image         *draw.Image
+	checkState    menu.CheckState
+	radio         bool
 	enabled       bool
 }
 
@@ -93,6 +98,41 @@ func (item *platformItem) platformDispose() {
 	C.disposeItem(item.item)
 }
 
+func (item *platformItem) platformSetCheckState(state menu.CheckState) {
+	C.setItemState(item.item, C.int(state))
+}
+
+func (item *platformItem) platformSetRadio(radio bool) {
+	C.setItemRadio(item.item, C.bool(radio))
+}
+
+func (item *platformItem) platformSetImage(img *draw.Image) {
+	var imgData *draw.ImageData
+	if img != nil {
+		imgData = img.Data()
+	}
+	if imgData == nil || len(imgData.Pixels) == 0 {
+		C.setItemImage(item.item, nil, 0, 0)
+		return
+	}
+	colorspace := C.CGColorSpaceCreateWithName(C.kCGColorSpaceGenericRGB)
+	defer C.CGColorSpaceRelease(colorspace)
+	// The image may outlive this call, so the pixels are placed in memory the data provider frees
+	// once it is done with them.
+	byteCount := len(imgData.Pixels) * 4
+	pixels := C.malloc(C.size_t(byteCount))
+	buffer := (*[1 << 30]color.Color)(pixels)[:len(imgData.Pixels):len(imgData.Pixels)]
+	for i, pixel := range imgData.Pixels {
+		buffer[i] = pixel.Premultiply()
+	}
+	provider := C.CGDataProviderCreateWithData(nil, pixels, C.size_t(byteCount), C.CGDataProviderReleaseDataCallback(C.freeImageData))
+	defer C.CGDataProviderRelease(provider)
+	cgImage := C.CGImageCreate(C.size_t(imgData.Width), C.size_t(imgData.Height), 8, 32, C.size_t(imgData.Width*4), colorspace, C.kCGBitmapByteOrder32Host|C.kCGImageAlphaPremultipliedFirst, provider, nil, C.bool(false), C.kCGRenderingIntentDefault)
+	defer C.CGImageRelease(cgImage)
+	size := img.Size()
+	C.setItemImage(item.item, unsafe.Pointer(cgImage), C.double(size.Width), C.double(size.Height))
+}
+
 func (item *platformItem) platformSubMenu() C.Menu {
 	return C.subMenu(item.item)
 }
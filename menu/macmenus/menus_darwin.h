@@ -5,6 +5,10 @@ typedef void *Item;
 
 Item newItem(const char *title, const char *key, int modifiers);
 Menu subMenu(Item item);
+void setItemState(Item item, int state);
+void setItemRadio(Item item, bool radio);
+void setItemImage(Item item, void *img, double width, double height);
+void freeImageData(void *info, const void *data, size_t size);
 void setBar(Menu bar);
 Menu newMenu(const char *title);
 Item newSeparator();
@@ -37,6 +37,29 @@ Menu subMenu(Item item) {
 	return nil;
 }
 
+void setItemState(Item item, int state) {
+	// The state values are Unchecked, Mixed and Checked, in that order
+	static const NSInteger states[] = { NSOffState, NSMixedState, NSOnState };
+	[((NSMenuItem *)item) setState:(state >= 0 && state <= 2) ? states[state] : NSOffState];
+}
+
+void setItemRadio(Item item, bool radio) {
+	[((NSMenuItem *)item) setOnStateImage:[NSImage imageNamed:radio ? @"NSMenuRadio" : @"NSMenuCheckmark"]];
+}
+
+void setItemImage(Item item, void *img, double width, double height) {
+	NSImage *nsimg = nil;
+	if (img) {
+		// CGImage has no notion of scale, so give the NSImage the logical size
+		nsimg = [[[NSImage alloc] initWithCGImage:img size:NSMakeSize(width, height)] autorelease];
+	}
+	[((NSMenuItem *)item) setImage:nsimg];
+}
+
+void freeImageData(void *info, const void *data, size_t size) {
+	free((void *)data);
+}
+
 void setBar(Menu bar) {
 	[NSApp setMainMenu:bar];
 }
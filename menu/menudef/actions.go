@@ -0,0 +1,47 @@
+package menudef
+
+import (
+	"github.com/richardwilkes/ui/app"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/menu/editmenu"
+	"github.com/richardwilkes/ui/menu/filemenu"
+	"github.com/richardwilkes/ui/menu/windowmenu"
+)
+
+// Action holds the handlers bound to menu items that refer to an action ID.
+type Action struct {
+	// Handler is called when the menu item is chosen.
+	Handler event.Handler
+	// Validator, if not nil, is added as a handler for the item's Validate
+	// events.
+	Validator event.Handler
+}
+
+var actions = make(map[string]*Action)
+
+func init() {
+	RegisterAction("app.hide", func(evt event.Event) { app.Hide() }, nil)
+	RegisterAction("app.hideOthers", func(evt event.Event) { app.HideOthers() }, nil)
+	RegisterAction("app.showAll", func(evt event.Event) { app.ShowAll() }, nil)
+	RegisterAction("app.quit", func(evt event.Event) { app.AttemptQuit() }, nil)
+	RegisterAction("file.close", filemenu.CloseKeyWindow, filemenu.ValidateCloseKeyWindow)
+	RegisterAction("edit.cut", editmenu.Cut, editmenu.CanCut)
+	RegisterAction("edit.copy", editmenu.Copy, editmenu.CanCopy)
+	RegisterAction("edit.paste", editmenu.Paste, editmenu.CanPaste)
+	RegisterAction("edit.delete", editmenu.Delete, editmenu.CanDelete)
+	RegisterAction("edit.selectAll", editmenu.SelectAll, editmenu.CanSelectAll)
+	RegisterAction("window.minimize", windowmenu.Minimize, windowmenu.CanMinimize)
+	RegisterAction("window.zoom", windowmenu.Zoom, windowmenu.CanZoom)
+	RegisterAction("window.bringAllToFront", windowmenu.BringAllToFront, nil)
+}
+
+// RegisterAction registers the handlers for an action ID, replacing any
+// existing action with the same ID. validator may be nil.
+func RegisterAction(id string, handler, validator event.Handler) {
+	actions[id] = &Action{Handler: handler, Validator: validator}
+}
+
+// LookupAction returns the action registered for the ID, or nil.
+func LookupAction(id string) *Action {
+	return actions[id]
+}
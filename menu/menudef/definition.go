@@ -0,0 +1,200 @@
+package menudef
+
+import (
+	"runtime"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/cmdline"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/menu"
+)
+
+// Special menu markers that may be used for Menu.Special.
+const (
+	ServicesSpecial = "services"
+	WindowSpecial   = "window"
+	HelpSpecial     = "help"
+)
+
+// Bar describes the contents of a menu bar.
+type Bar struct {
+	Menus []*Menu `json:"menus" yaml:"menus"` // The menus to place in the bar, in order.
+}
+
+// Menu describes a menu.
+type Menu struct {
+	Title   string   `json:"title" yaml:"title"`                         // The i18n key for the menu's title.
+	Special string   `json:"special,omitempty" yaml:"special,omitempty"` // One of "services", "window" or "help" if this is a special menu.
+	OS      []string `json:"os,omitempty" yaml:"os,omitempty"`           // The operating systems the menu is present on. Empty means all of them.
+	Items   []*Item  `json:"items,omitempty" yaml:"items,omitempty"`     // The items within the menu, in order.
+}
+
+// Item describes a menu item, separator or sub-menu.
+type Item struct {
+	Title     string   `json:"title,omitempty" yaml:"title,omitempty"`         // The i18n key for the item's title.
+	Action    string   `json:"action,omitempty" yaml:"action,omitempty"`       // The ID of the registered action to bind to the item.
+	Key       string   `json:"key,omitempty" yaml:"key,omitempty"`             // The key that triggers the item, such as "Q" or "F5".
+	Modifiers string   `json:"modifiers,omitempty" yaml:"modifiers,omitempty"` // The modifiers for the key, such as "menu+shift". Empty means the platform's menu modifier.
+	Separator bool     `json:"separator,omitempty" yaml:"separator,omitempty"` // True if this is a separator.
+	Menu      *Menu    `json:"menu,omitempty" yaml:"menu,omitempty"`           // The sub-menu, if this item has one.
+	OS        []string `json:"os,omitempty" yaml:"os,omitempty"`               // The operating systems the item is present on. Empty means all of them.
+}
+
+// Install builds the menus described by the definition and appends them to
+// the menu bar. Titles are looked up via i18n.Text, after which any "{app}"
+// within them is replaced with the application's name. If an error occurs,
+// no menus will have been added to the bar.
+func (def *Bar) Install(bar menu.Bar) error {
+	specials := make(map[menu.SpecialMenuType]menu.Menu)
+	var menus []menu.Menu
+	for _, one := range def.Menus {
+		if !forThisOS(one.OS) {
+			continue
+		}
+		m, err := one.build(specials)
+		if err != nil {
+			for _, built := range menus {
+				built.Dispose()
+			}
+			return err
+		}
+		menus = append(menus, m)
+	}
+	for _, m := range menus {
+		bar.AppendMenu(m)
+	}
+	for which, m := range specials {
+		bar.SetupSpecialMenu(which, m)
+	}
+	return nil
+}
+
+// validate checks the definition for mistakes that can be found without
+// building the menus.
+func (def *Bar) validate() error {
+	for _, one := range def.Menus {
+		if err := one.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (def *Menu) validate() error {
+	for _, item := range def.Items {
+		switch {
+		case item.Separator:
+		case item.Menu != nil:
+			if err := item.Menu.validate(); err != nil {
+				return err
+			}
+		default:
+			if err := item.checkKey(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkKey returns an error if the item has modifiers without a key for
+// them to apply to.
+func (def *Item) checkKey() error {
+	if def.Key == "" && def.Modifiers != "" {
+		return errs.Newf("menu item '%s' has modifiers '%s' but no key", def.Title, def.Modifiers)
+	}
+	return nil
+}
+
+func (def *Menu) build(specials map[menu.SpecialMenuType]menu.Menu) (menu.Menu, error) {
+	m := menu.NewMenu(title(def.Title))
+	for _, item := range def.Items {
+		if !forThisOS(item.OS) {
+			continue
+		}
+		if err := item.appendTo(m, specials); err != nil {
+			m.Dispose()
+			return nil, err
+		}
+	}
+	if def.Special != "" {
+		var which menu.SpecialMenuType
+		switch strings.ToLower(def.Special) {
+		case ServicesSpecial:
+			which = menu.ServicesMenu
+		case WindowSpecial:
+			which = menu.WindowMenu
+		case HelpSpecial:
+			which = menu.HelpMenu
+		default:
+			m.Dispose()
+			return nil, errs.Newf("menu '%s' has unknown special marker '%s'", def.Title, def.Special)
+		}
+		specials[which] = m
+	}
+	return m, nil
+}
+
+func (def *Item) appendTo(m menu.Menu, specials map[menu.SpecialMenuType]menu.Menu) error {
+	if def.Separator {
+		m.AppendItem(menu.NewSeparator())
+		return nil
+	}
+	if def.Menu != nil {
+		sub, err := def.Menu.build(specials)
+		if err != nil {
+			return err
+		}
+		m.AppendMenu(sub)
+		return nil
+	}
+	if err := def.checkKey(); err != nil {
+		return err
+	}
+	var handler, validator event.Handler
+	if def.Action != "" {
+		action := LookupAction(def.Action)
+		if action == nil {
+			return errs.Newf("menu item '%s' refers to unknown action '%s'", def.Title, def.Action)
+		}
+		handler = action.Handler
+		validator = action.Validator
+	}
+	var item menu.Item
+	if def.Key != "" {
+		code, err := parseKey(def.Key)
+		if err != nil {
+			return err
+		}
+		modifiers, err := parseModifiers(def.Modifiers)
+		if err != nil {
+			return err
+		}
+		item = menu.NewItemWithKeyAndModifiers(title(def.Title), code, modifiers, handler)
+	} else {
+		item = menu.NewItem(title(def.Title), handler)
+	}
+	if validator != nil {
+		item.EventHandlers().Add(event.ValidateType, validator)
+	}
+	m.AppendItem(item)
+	return nil
+}
+
+func title(key string) string {
+	return strings.Replace(i18n.Text(key), "{app}", cmdline.AppName, -1)
+}
+
+func forThisOS(list []string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, one := range list {
+		if strings.EqualFold(one, runtime.GOOS) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package menudef
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/ui/keys"
+)
+
+var namedKeys = map[string]int{
+	"up":        keys.VirtualKeyUp,
+	"down":      keys.VirtualKeyDown,
+	"left":      keys.VirtualKeyLeft,
+	"right":     keys.VirtualKeyRight,
+	"home":      keys.VirtualKeyHome,
+	"end":       keys.VirtualKeyEnd,
+	"pageup":    keys.VirtualKeyPageUp,
+	"pagedown":  keys.VirtualKeyPageDown,
+	"insert":    keys.VirtualKeyInsert,
+	"delete":    keys.VirtualKeyDelete,
+	"backspace": keys.VirtualKeyBackspace,
+	"tab":       keys.VirtualKeyTab,
+	"return":    keys.VirtualKeyReturn,
+	"enter":     keys.VirtualKeyNumPadEnter,
+	"escape":    keys.VirtualKeyEscape,
+	"space":     keys.VirtualKeySpace,
+	"f1":        keys.VirtualKeyF1,
+	"f2":        keys.VirtualKeyF2,
+	"f3":        keys.VirtualKeyF3,
+	"f4":        keys.VirtualKeyF4,
+	"f5":        keys.VirtualKeyF5,
+	"f6":        keys.VirtualKeyF6,
+	"f7":        keys.VirtualKeyF7,
+	"f8":        keys.VirtualKeyF8,
+	"f9":        keys.VirtualKeyF9,
+	"f10":       keys.VirtualKeyF10,
+	"f11":       keys.VirtualKeyF11,
+	"f12":       keys.VirtualKeyF12,
+	"f13":       keys.VirtualKeyF13,
+	"f14":       keys.VirtualKeyF14,
+	"f15":       keys.VirtualKeyF15,
+	"f16":       keys.VirtualKeyF16,
+	"f17":       keys.VirtualKeyF17,
+	"f18":       keys.VirtualKeyF18,
+	"f19":       keys.VirtualKeyF19,
+}
+
+var punctuationKeys = map[rune]int{
+	'\'': keys.VirtualKeyQuote,
+	',':  keys.VirtualKeyComma,
+	'-':  keys.VirtualKeyMinus,
+	'.':  keys.VirtualKeyPeriod,
+	'/':  keys.VirtualKeySlash,
+	';':  keys.VirtualKeySemiColon,
+	'=':  keys.VirtualKeyEqual,
+	'[':  keys.VirtualKeyLeftBracket,
+	'\\': keys.VirtualKeyBackSlash,
+	']':  keys.VirtualKeyRightBracket,
+	'`':  keys.VirtualKeyBacktick,
+}
+
+func parseKey(key string) (int, error) {
+	if code, ok := namedKeys[strings.ToLower(key)]; ok {
+		return code, nil
+	}
+	if runes := []rune(key); len(runes) == 1 {
+		ch := runes[0]
+		switch {
+		case ch >= 'a' && ch <= 'z':
+			return int(ch - 'a' + 'A'), nil
+		case ch >= 'A' && ch <= 'Z', ch >= '0' && ch <= '9':
+			return int(ch), nil
+		}
+		if code, ok := punctuationKeys[ch]; ok {
+			return code, nil
+		}
+	}
+	return 0, errs.Newf("unknown key '%s'", key)
+}
+
+func parseModifiers(modifiers string) (keys.Modifiers, error) {
+	if modifiers == "" {
+		return keys.PlatformMenuModifier(), nil
+	}
+	var result keys.Modifiers
+	for _, one := range strings.Split(modifiers, "+") {
+		switch strings.ToLower(strings.TrimSpace(one)) {
+		case "menu":
+			result |= keys.PlatformMenuModifier()
+		case "shift":
+			result |= keys.ShiftModifier
+		case "control", "ctrl":
+			result |= keys.ControlModifier
+		case "option", "alt":
+			result |= keys.OptionModifier
+		case "command", "cmd":
+			result |= keys.CommandModifier
+		case "none":
+		default:
+			return 0, errs.Newf("unknown modifier '%s'", one)
+		}
+	}
+	return result, nil
+}
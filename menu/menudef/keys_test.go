@@ -0,0 +1,67 @@
+package menudef
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/ui/keys"
+)
+
+func TestParseKey(t *testing.T) {
+	for i, one := range []struct {
+		key  string
+		code int
+		ok   bool
+	}{
+		{"a", keys.VirtualKeyA, true},
+		{"Q", keys.VirtualKeyQ, true},
+		{"7", keys.VirtualKey7, true},
+		{"F5", keys.VirtualKeyF5, true},
+		{"f19", keys.VirtualKeyF19, true},
+		{"PageDown", keys.VirtualKeyPageDown, true},
+		{"return", keys.VirtualKeyReturn, true},
+		{"enter", keys.VirtualKeyNumPadEnter, true},
+		{",", keys.VirtualKeyComma, true},
+		{"\\", keys.VirtualKeyBackSlash, true},
+		{"`", keys.VirtualKeyBacktick, true},
+		{"", 0, false},
+		{"ab", 0, false},
+		{"F20", 0, false},
+		{"é", 0, false},
+		{"!", 0, false},
+	} {
+		code, err := parseKey(one.key)
+		if (err == nil) != one.ok {
+			t.Errorf("%d: parseKey(%q) returned error %v", i, one.key, err)
+		} else if code != one.code {
+			t.Errorf("%d: parseKey(%q) = %d, expected %d", i, one.key, code, one.code)
+		}
+	}
+}
+
+func TestParseModifiers(t *testing.T) {
+	menuKey := keys.PlatformMenuModifier()
+	for i, one := range []struct {
+		modifiers string
+		result    keys.Modifiers
+		ok        bool
+	}{
+		{"", menuKey, true},
+		{"none", 0, true},
+		{"menu", menuKey, true},
+		{"menu+shift", menuKey | keys.ShiftModifier, true},
+		{" Shift + Alt ", keys.ShiftModifier | keys.OptionModifier, true},
+		{"ctrl+option", keys.ControlModifier | keys.OptionModifier, true},
+		{"control+cmd", keys.ControlModifier | keys.CommandModifier, true},
+		{"command+command", keys.CommandModifier, true},
+		{"hyper", 0, false},
+		{"shift+", 0, false},
+		{"shift+meta", 0, false},
+	} {
+		result, err := parseModifiers(one.modifiers)
+		if (err == nil) != one.ok {
+			t.Errorf("%d: parseModifiers(%q) returned error %v", i, one.modifiers, err)
+		} else if result != one.result {
+			t.Errorf("%d: parseModifiers(%q) = %v, expected %v", i, one.modifiers, result, one.result)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package menudef
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"gopkg.in/yaml.v2"
+)
+
+// Format identifies the encoding of a menu bar definition file.
+type Format int
+
+// Possible values for Format.
+const (
+	JSON Format = iota
+	YAML
+)
+
+// Load a menu bar definition from a file. The format is determined by the
+// file's extension: ".yaml" and ".yml" files are treated as YAML, while all
+// others are treated as JSON.
+func Load(path string) (*Bar, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	format := JSON
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		format = YAML
+	}
+	return Decode(data, format)
+}
+
+// Decode a menu bar definition from data in the specified format. Mistakes
+// that can be found without building the menus, such as an item with
+// modifiers but no key, are reported as errors.
+func Decode(data []byte, format Format) (*Bar, error) {
+	var def Bar
+	var err error
+	switch format {
+	case YAML:
+		err = yaml.Unmarshal(data, &def)
+	default:
+		err = json.Unmarshal(data, &def)
+	}
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	if err = def.validate(); err != nil {
+		return nil, err
+	}
+	return &def, nil
+}
@@ -0,0 +1,27 @@
+package menudef
+
+import "testing"
+
+func TestDecode(t *testing.T) {
+	for i, one := range []struct {
+		data   string
+		format Format
+		ok     bool
+	}{
+		{`{"menus":[{"title":"File","items":[{"title":"Open","key":"O"},{"separator":true},{"title":"Quit","key":"Q","modifiers":"menu"}]}]}`, JSON, true},
+		{`{"menus":[{"title":"File","items":[{"title":"Open","modifiers":"shift"}]}]}`, JSON, false},
+		{`{"menus":[{"title":"File","items":[{"menu":{"title":"Recent","items":[{"title":"Clear","modifiers":"menu"}]}}]}]}`, JSON, false},
+		{`{"menus":[{"title":"File","items":[{"separator":true,"modifiers":"menu"}]}]}`, JSON, true},
+		{`{"menus":[`, JSON, false},
+		{"menus:\n  - title: Edit\n    items:\n      - title: Undo\n        key: Z\n      - title: Redo\n        key: Z\n        modifiers: menu+shift\n", YAML, true},
+		{"menus:\n  - title: Edit\n    items:\n      - title: Redo\n        modifiers: menu+shift\n", YAML, false},
+		{"menus: [", YAML, false},
+	} {
+		def, err := Decode([]byte(one.data), one.format)
+		if (err == nil) != one.ok {
+			t.Errorf("%d: Decode() returned error %v", i, err)
+		} else if err == nil && len(def.Menus) != 1 {
+			t.Errorf("%d: Decode() produced %d menus, expected 1", i, len(def.Menus))
+		}
+	}
+}
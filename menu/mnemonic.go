@@ -0,0 +1,26 @@
+package menu
+
+import (
+	"unicode"
+)
+
+// ParseMnemonic extracts the mnemonic from a title. The mnemonic is marked by placing an '&'
+// immediately before it, e.g. "&File". A literal '&' may be included by doubling it. Returns the
+// title with the markers removed, the mnemonic in lower case (or 0 if there isn't one) and the rune
+// index of the mnemonic within the returned text (or -1 if there isn't one).
+func ParseMnemonic(title string) (text string, mnemonic rune, index int) {
+	index = -1
+	runes := []rune(title)
+	result := make([]rune, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '&' && i+1 < len(runes) {
+			i++
+			if runes[i] != '&' && mnemonic == 0 && !unicode.IsSpace(runes[i]) {
+				mnemonic = unicode.ToLower(runes[i])
+				index = len(result)
+			}
+		}
+		result = append(result, runes[i])
+	}
+	return string(result), mnemonic, index
+}
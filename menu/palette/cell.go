@@ -0,0 +1,79 @@
+package palette
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/font"
+	"github.com/richardwilkes/ui/keys"
+	"github.com/richardwilkes/ui/widget"
+)
+
+const cellMargin = 4
+
+type cellFactory struct {
+}
+
+// CellHeight implements the widget.CellFactory interface.
+func (f *cellFactory) CellHeight() float64 {
+	return math.Ceil(font.Views.Height()) + cellMargin
+}
+
+// CreateCell implements the widget.CellFactory interface.
+func (f *cellFactory) CreateCell(owner ui.Widget, element interface{}, index int, selected, focused bool) ui.Widget {
+	c := &cell{entry: element.(*entry), selected: selected}
+	c.InitTypeAndID(c)
+	c.Describer = func() string { return fmt.Sprintf("Palette Cell #%d (%s)", c.ID(), c.entry.title) }
+	c.SetSizer(c)
+	c.EventHandlers().Add(event.PaintType, c.paint)
+	return c
+}
+
+type cell struct {
+	widget.Block
+	entry    *entry
+	selected bool
+}
+
+// Sizes implements Sizer
+func (c *cell) Sizes(hint geom.Size) (min, pref, max geom.Size) {
+	pref = font.Views.Measure(c.entry.title)
+	if binding := c.keyBinding(); binding != "" {
+		pref.Width += font.Views.Measure(binding).Width + cellMargin*4
+	}
+	pref.Width += cellMargin * 2
+	pref.Height += cellMargin
+	pref.GrowToInteger()
+	return pref, pref, pref
+}
+
+func (c *cell) keyBinding() string {
+	if c.entry.keyCode != 0 {
+		if mapping := keys.MappingForKeyCode(c.entry.keyCode); mapping != nil {
+			return c.entry.keyModifiers.String() + mapping.Name
+		}
+	}
+	return ""
+}
+
+func (c *cell) paint(evt event.Event) {
+	if e, ok := evt.(*event.Paint); ok {
+		gc := e.GC()
+		bounds := c.LocalBounds()
+		if c.selected {
+			gc.SetColor(color.SelectedText)
+		} else {
+			gc.SetColor(color.Text)
+		}
+		y := bounds.Y + (bounds.Height-font.Views.Height())/2
+		gc.DrawString(bounds.X+cellMargin, y, c.entry.title, font.Views)
+		if binding := c.keyBinding(); binding != "" {
+			width := font.Views.Measure(binding).Width
+			gc.DrawString(bounds.X+bounds.Width-(width+cellMargin), y, binding, font.Views)
+		}
+	}
+}
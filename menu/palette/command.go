@@ -0,0 +1,85 @@
+package palette
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/keys"
+	"github.com/richardwilkes/ui/object"
+)
+
+var commands []*Command
+
+// Command is an action that can be run from the palette without being present in the menu bar.
+// Like menu items, commands receive a Validate event to determine whether they are enabled and a
+// Selection event when they are chosen.
+type Command struct {
+	object.Base
+	eventHandlers *event.Handlers
+	title         string
+	keyCode       int
+	keyModifiers  keys.Modifiers
+}
+
+// NewCommand creates a new command. 'keyCode' and 'modifiers' are only used to show the key
+// binding alongside the command's title; pass in 0 for 'keyCode' if there is none.
+func NewCommand(title string, keyCode int, modifiers keys.Modifiers, handler event.Handler) *Command {
+	cmd := &Command{title: title, keyCode: keyCode, keyModifiers: modifiers}
+	cmd.InitTypeAndID(cmd)
+	if handler != nil {
+		cmd.EventHandlers().Add(event.SelectionType, handler)
+	}
+	return cmd
+}
+
+// Title returns this command's title.
+func (cmd *Command) Title() string {
+	return cmd.title
+}
+
+// KeyCode returns the key code shown as this command's key binding. A value of 0 indicates no key
+// is attached.
+func (cmd *Command) KeyCode() int {
+	return cmd.keyCode
+}
+
+// KeyModifiers returns the key modifiers shown as part of this command's key binding.
+func (cmd *Command) KeyModifiers() keys.Modifiers {
+	return cmd.keyModifiers
+}
+
+// EventHandlers implements the event.Target interface.
+func (cmd *Command) EventHandlers() *event.Handlers {
+	if cmd.eventHandlers == nil {
+		cmd.eventHandlers = &event.Handlers{}
+	}
+	return cmd.eventHandlers
+}
+
+// ParentTarget implements the event.Target interface.
+func (cmd *Command) ParentTarget() event.Target {
+	return event.GlobalTarget()
+}
+
+func (cmd *Command) String() string {
+	return fmt.Sprintf("Command #%d (%s)", cmd.ID(), cmd.title)
+}
+
+// Register a command so that it will be listed in the palette.
+func Register(cmd *Command) {
+	Unregister(cmd)
+	commands = append(commands, cmd)
+}
+
+// Unregister a previously registered command.
+func Unregister(cmd *Command) {
+	for i, one := range commands {
+		if one == cmd {
+			copy(commands[i:], commands[i+1:])
+			count := len(commands) - 1
+			commands[count] = nil
+			commands = commands[:count]
+			break
+		}
+	}
+}
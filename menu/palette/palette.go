@@ -0,0 +1,257 @@
+package palette
+
+import (
+	"math"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/border"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/draw/align"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/keys"
+	"github.com/richardwilkes/ui/layout/flex"
+	"github.com/richardwilkes/ui/menu"
+	"github.com/richardwilkes/ui/widget/list"
+	"github.com/richardwilkes/ui/widget/scrollarea"
+	"github.com/richardwilkes/ui/widget/textfield"
+	"github.com/richardwilkes/ui/window"
+)
+
+var (
+	// Width holds the width of the palette.
+	Width = 480.0
+	// VisibleRows holds the number of commands that are visible in the palette at one time.
+	VisibleRows = 12
+	// PathSeparator is placed between the titles of the menus leading to a menu item.
+	PathSeparator = " ▸ "
+	// paletteItems holds the menu items that open the palette, so that they are not listed within it.
+	paletteItems = make(map[menu.Item]bool)
+	current      *palette
+)
+
+type entry struct {
+	title        string
+	target       event.Target
+	keyCode      int
+	keyModifiers keys.Modifiers
+	score        int
+}
+
+func (e *entry) String() string {
+	return e.title
+}
+
+type palette struct {
+	wnd      *window.Window
+	owner    ui.Window
+	field    *textfield.TextField
+	list     *list.List
+	scroller *scrollarea.ScrollArea
+	factory  *cellFactory
+	entries  []*entry
+	shown    []*entry
+}
+
+// AppendItem appends the standard Command Palette menu item to the specified menu.
+func AppendItem(m menu.Menu) {
+	InsertItem(m, -1)
+}
+
+// InsertItem adds the standard Command Palette menu item to the specified menu.
+func InsertItem(m menu.Menu, index int) {
+	item := menu.NewItemWithKeyAndModifiers(i18n.Text("Command Palette…"), keys.VirtualKeyP, keys.ShiftModifier|keys.PlatformMenuModifier(), Show)
+	item.EventHandlers().Add(event.ValidateType, CanShow)
+	paletteItems[item] = true
+	m.InsertItem(item, index)
+}
+
+// Show the command palette over the current key window.
+func Show(evt event.Event) {
+	if current != nil {
+		current.wnd.ToFront()
+		return
+	}
+	if owner := window.KeyWindow(); owner != nil {
+		newPalette(owner)
+	}
+}
+
+// CanShow marks the menu item invalid if there is no key window to show the palette over.
+func CanShow(evt event.Event) {
+	if window.KeyWindow() == nil {
+		evt.(*event.Validate).MarkInvalid()
+	}
+}
+
+func newPalette(owner ui.Window) {
+	p := &palette{owner: owner, factory: &cellFactory{}}
+	p.collect()
+	p.field = textfield.New()
+	p.field.SetWatermark(i18n.Text("Type a command"))
+	flexData := flex.NewData()
+	flexData.HGrab = true
+	flexData.HAlign = align.Fill
+	p.field.SetLayoutData(flexData)
+	handlers := p.field.EventHandlers()
+	handlers.Add(event.ModifiedType, func(evt event.Event) { p.filter() })
+	// The field consumes the navigation keys, so intercept the ones the list needs first
+	handlers.Prepend(event.KeyDownType, p.keyDown)
+	p.list = list.New(p.factory)
+	p.list.EventHandlers().Add(event.ClickType, func(evt event.Event) { p.run() })
+	p.scroller = scrollarea.New(p.list, scrollarea.Fill)
+	flexData = flex.NewData()
+	flexData.HGrab = true
+	flexData.VGrab = true
+	flexData.HAlign = align.Fill
+	flexData.VAlign = align.Fill
+	flexData.SizeHint.Height = p.factory.CellHeight()*float64(VisibleRows) + 2
+	p.scroller.SetLayoutData(flexData)
+	frame := owner.ContentFrame()
+	where := geom.Point{X: frame.X + math.Max((frame.Width-Width)/2, 0), Y: frame.Y + 8}
+	p.wnd = window.NewPopupWindow(owner, where, geom.Size{Width: Width, Height: 100})
+	content := p.wnd.Content()
+	content.SetBackground(color.Background)
+	content.SetBorder(border.NewCompound(border.NewLine(color.MenuBorder, geom.NewUniformInsets(1)), border.NewEmpty(geom.NewUniformInsets(4))))
+	lay := flex.NewLayout(content)
+	lay.VSpacing = 4
+	content.AddChild(p.field)
+	content.AddChild(p.scroller)
+	p.wnd.EventHandlers().Add(event.FocusLostType, func(evt event.Event) { p.close() })
+	p.filter()
+	p.wnd.Pack()
+	frame = p.wnd.ContentFrame()
+	frame.Width = Width
+	p.wnd.SetContentFrame(frame)
+	current = p
+	p.wnd.ToFront()
+	p.wnd.SetFocus(p.field)
+}
+
+// collect gathers the enabled items from the owner's menu bar, followed by the enabled registered
+// commands.
+func (p *palette) collect() {
+	if bar := p.owner.MenuBar(); bar != nil {
+		titles := make([]string, 0, 8)
+		menu.Walk(bar, func(path []string, item menu.Item) bool {
+			if item.SubMenu() != nil || item.Title() == "" || paletteItems[item] || !valid(item) {
+				return true
+			}
+			titles = titles[:0]
+			for _, one := range path {
+				text, _, _ := menu.ParseMnemonic(one)
+				titles = append(titles, text)
+			}
+			text, _, _ := menu.ParseMnemonic(item.Title())
+			p.entries = append(p.entries, &entry{
+				title:        strings.Join(append(titles, text), PathSeparator),
+				target:       item,
+				keyCode:      item.KeyCode(),
+				keyModifiers: item.KeyModifiers(),
+			})
+			return true
+		})
+	}
+	for _, cmd := range commands {
+		if valid(cmd) {
+			p.entries = append(p.entries, &entry{
+				title:        cmd.Title(),
+				target:       cmd,
+				keyCode:      cmd.KeyCode(),
+				keyModifiers: cmd.KeyModifiers(),
+			})
+		}
+	}
+}
+
+func (p *palette) filter() {
+	for i := len(p.shown) - 1; i >= 0; i-- {
+		p.list.Remove(i)
+	}
+	p.shown = rank(p.entries, p.field.Text())
+	rows := make([]interface{}, len(p.shown))
+	for i, one := range p.shown {
+		rows[i] = one
+	}
+	p.list.Append(rows...)
+	p.list.Select(false, 0)
+	p.scroller.SetNeedLayout(true)
+	p.scroller.ValidateLayout()
+	p.scroller.SetScrolledPosition(false, 0)
+	p.scroller.Repaint()
+}
+
+func (p *palette) keyDown(evt event.Event) {
+	if e, ok := evt.(*event.KeyDown); ok {
+		switch e.Code() {
+		case keys.VirtualKeyUp, keys.VirtualKeyNumPadUp:
+			p.moveSelection(-1)
+		case keys.VirtualKeyDown, keys.VirtualKeyNumPadDown:
+			p.moveSelection(1)
+		case keys.VirtualKeyPageUp, keys.VirtualKeyNumPadPageUp:
+			p.moveSelection(-VisibleRows)
+		case keys.VirtualKeyPageDown, keys.VirtualKeyNumPadPageDown:
+			p.moveSelection(VisibleRows)
+		case keys.VirtualKeyReturn, keys.VirtualKeyNumPadEnter:
+			p.run()
+		case keys.VirtualKeyEscape:
+			p.close()
+		default:
+			return
+		}
+		evt.Finish()
+	}
+}
+
+func (p *palette) moveSelection(delta int) {
+	count := len(p.shown)
+	if count == 0 {
+		return
+	}
+	index := p.list.Selection.FirstSet() + delta
+	if index < 0 {
+		index = 0
+	} else if index >= count {
+		index = count - 1
+	}
+	p.list.Select(false, index)
+	height := p.factory.CellHeight()
+	top := float64(index) * height
+	pos := p.scroller.ScrolledPosition(false)
+	visible := p.scroller.VisibleSize(false)
+	if top < pos {
+		p.scroller.SetScrolledPosition(false, top)
+	} else if top+height > pos+visible {
+		p.scroller.SetScrolledPosition(false, top+height-visible)
+	}
+}
+
+// run closes the palette and then chooses the selected command, provided it is still enabled.
+func (p *palette) run() {
+	index := p.list.Selection.FirstSet()
+	if index < 0 || index >= len(p.shown) {
+		return
+	}
+	chosen := p.shown[index]
+	p.close()
+	if valid(chosen.target) {
+		addRecent(chosen.title)
+		event.Dispatch(event.NewSelection(chosen.target))
+	}
+}
+
+func (p *palette) close() {
+	if current == p {
+		current = nil
+		p.wnd.Close()
+		p.owner.ToFront()
+	}
+}
+
+func valid(target event.Target) bool {
+	evt := event.NewValidate(target)
+	event.Dispatch(evt)
+	return evt.Valid()
+}
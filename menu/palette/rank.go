@@ -0,0 +1,97 @@
+package palette
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var (
+	// MaxRecentCommands holds the number of recently run commands that are remembered and ranked
+	// ahead of others.
+	MaxRecentCommands = 10
+	// RecencyWeight holds the score added to a recently run command for each position it is from
+	// the end of the recent list.
+	RecencyWeight = 4
+	recent        []string
+)
+
+// fuzzyScore returns how well 'query' matches 'text', or -1 if the runes of 'query' do not all
+// appear within 'text' in order. Matches at the start of the text or a word, and runs of adjacent
+// matches, score higher, while gaps between matches reduce the score. Both should already be
+// lower-cased.
+func fuzzyScore(query, text []rune) int {
+	if len(query) == 0 {
+		return 0
+	}
+	score := 0
+	last := -1
+	qi := 0
+	for ti, r := range text {
+		if r != query[qi] {
+			continue
+		}
+		switch {
+		case ti == 0:
+			score += 8
+		case last == ti-1:
+			score += 5
+		case !unicode.IsLetter(text[ti-1]) && !unicode.IsDigit(text[ti-1]):
+			score += 6
+		default:
+			score++
+		}
+		if last >= 0 {
+			gap := ti - last - 1
+			if gap > 3 {
+				gap = 3
+			}
+			score -= gap
+		}
+		last = ti
+		qi++
+		if qi == len(query) {
+			return score
+		}
+	}
+	return -1
+}
+
+func recencyBonus(key string) int {
+	for i, one := range recent {
+		if one == key {
+			return (MaxRecentCommands - i) * RecencyWeight
+		}
+	}
+	return 0
+}
+
+func addRecent(key string) {
+	list := make([]string, 0, len(recent)+1)
+	list = append(list, key)
+	for _, one := range recent {
+		if one != key {
+			list = append(list, one)
+		}
+	}
+	if len(list) > MaxRecentCommands {
+		list = list[:MaxRecentCommands]
+	}
+	recent = list
+}
+
+// rank scores the entries against 'query', removing those that don't match, and sorts the
+// remainder from best to worst.
+func rank(entries []*entry, query string) []*entry {
+	q := []rune(strings.ToLower(strings.TrimSpace(query)))
+	matches := make([]*entry, 0, len(entries))
+	for _, one := range entries {
+		score := fuzzyScore(q, []rune(strings.ToLower(one.title)))
+		if score >= 0 {
+			one.score = score + recencyBonus(one.title)
+			matches = append(matches, one)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	return matches
+}
@@ -0,0 +1,66 @@
+package palette
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	for i, one := range []struct {
+		query string
+		text  string
+		score int
+	}{
+		{"", "open", 0},
+		{"", "", 0},
+		{"o", "open", 8},
+		{"e", "open", 1},
+		{"abc", "abc", 18},
+		{"ac", "abc", 8},
+		{"az", "abcdefz", 6},
+		{"f", "new file", 6},
+		{"nf", "new file", 11},
+		{"f", "save-file", 6},
+		{"x", "open", -1},
+		{"ba", "ab", -1},
+		{"aa", "a", -1},
+		{"o", "", -1},
+	} {
+		if score := fuzzyScore([]rune(one.query), []rune(one.text)); score != one.score {
+			t.Errorf("%d: fuzzyScore(%q, %q) = %d, expected %d", i, one.query, one.text, score, one.score)
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	defer func(saved []string) { recent = saved }(recent)
+	titles := []string{"Close", "Copy", "Open", "Paste", "Open Recent"}
+	for i, one := range []struct {
+		query  string
+		recent []string
+		result string
+	}{
+		{"op", nil, "Open|Open Recent|Copy"},
+		{" OP ", nil, "Open|Open Recent|Copy"},
+		{"op", []string{"Copy"}, "Copy|Open|Open Recent"},
+		{"op", []string{"Open", "Open Recent"}, "Open|Open Recent|Copy"},
+		{"op", []string{"Open Recent", "Open"}, "Open Recent|Open|Copy"},
+		{"", nil, "Close|Copy|Open|Paste|Open Recent"},
+		{"", []string{"Paste"}, "Paste|Close|Copy|Open|Open Recent"},
+		{"zz", nil, ""},
+	} {
+		recent = one.recent
+		entries := make([]*entry, len(titles))
+		for j, title := range titles {
+			entries[j] = &entry{title: title}
+		}
+		matches := rank(entries, one.query)
+		result := make([]string, len(matches))
+		for j, match := range matches {
+			result[j] = match.title
+		}
+		if got := strings.Join(result, "|"); got != one.result {
+			t.Errorf("%d: rank(%q) = %q, expected %q", i, one.query, got, one.result)
+		}
+	}
+}
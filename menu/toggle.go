@@ -0,0 +1,54 @@
+package menu
+
+import (
+	"github.com/richardwilkes/ui/event"
+)
+
+// BindToggle turns the item into a toggle. Each time the item is validated, its check mark is set
+// from the value returned by 'checked'. This happens ahead of the item's other validation, since
+// that stops as soon as the item is found to be invalid. Selecting the item calls 'toggle'. Returns
+// the item.
+func BindToggle(item Item, checked func() bool, toggle func()) Item {
+	handlers := item.EventHandlers()
+	handlers.Prepend(event.ValidateType, func(evt event.Event) { item.SetCheckState(CheckStateFor(checked())) })
+	handlers.Add(event.SelectionType, func(evt event.Event) { toggle() })
+	return item
+}
+
+// RadioGroup ties together a set of items, only one of which is checked at a time. Like toggles,
+// the check marks are set each time the items are validated, so they always reflect the current
+// state of the application.
+type RadioGroup struct {
+	items    []Item
+	selected func() int
+	choose   func(index int)
+}
+
+// NewRadioGroup creates a new, empty radio group. 'selected' should return the index of the item
+// that is currently chosen, or -1 if none are. 'choose' is called with the index of an item when
+// the user selects it.
+func NewRadioGroup(selected func() int, choose func(index int)) *RadioGroup {
+	return &RadioGroup{selected: selected, choose: choose}
+}
+
+// Add an item to the group, marking it as a radio item. Its index within the group is the number of
+// items that were added before it. Returns the item.
+func (group *RadioGroup) Add(item Item) Item {
+	index := len(group.items)
+	group.items = append(group.items, item)
+	item.SetRadio(true)
+	handlers := item.EventHandlers()
+	handlers.Prepend(event.ValidateType, func(evt event.Event) { item.SetCheckState(CheckStateFor(group.selected() == index)) })
+	handlers.Add(event.SelectionType, func(evt event.Event) { group.choose(index) })
+	return item
+}
+
+// Count of items in this group.
+func (group *RadioGroup) Count() int {
+	return len(group.items)
+}
+
+// Item at the specified index.
+func (group *RadioGroup) Item(index int) Item {
+	return group.items[index]
+}
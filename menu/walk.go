@@ -0,0 +1,31 @@
+package menu
+
+// Walk calls 'visitor' for each item within the menu bar, descending into sub-menus after visiting
+// the item they are attached to. 'path' holds the titles of the menus leading to the item. The
+// visitor should not retain 'path', as its contents may change after it returns. Return false
+// from the visitor to stop the walk.
+func Walk(bar Bar, visitor func(path []string, item Item) bool) {
+	path := make([]string, 0, 8)
+	for i := 0; i < bar.Count(); i++ {
+		if mnu := bar.Menu(i); mnu != nil {
+			if !walkMenu(mnu, append(path, mnu.Title()), visitor) {
+				return
+			}
+		}
+	}
+}
+
+func walkMenu(mnu Menu, path []string, visitor func(path []string, item Item) bool) bool {
+	for i := 0; i < mnu.Count(); i++ {
+		item := mnu.Item(i)
+		if !visitor(path, item) {
+			return false
+		}
+		if subMenu := item.SubMenu(); subMenu != nil {
+			if !walkMenu(subMenu, append(path, subMenu.Title()), visitor) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -12,37 +12,54 @@ import (
 func Install(bar menu.Bar) {
 	windowMenu := menu.NewMenu(i18n.Text("Window"))
 
-	item := menu.NewItemWithKey(i18n.Text("Minimize"), keys.VirtualKeyM, func(evt event.Event) {
-		wnd := window.KeyWindow()
-		if wnd != nil {
-			wnd.Minimize()
-		}
-	})
-	item.EventHandlers().Add(event.ValidateType, func(evt event.Event) {
-		w := window.KeyWindow()
-		if w == nil || !w.Minimizable() {
-			evt.(*event.Validate).MarkInvalid()
-		}
-	})
+	item := menu.NewItemWithKey(i18n.Text("Minimize"), keys.VirtualKeyM, Minimize)
+	item.EventHandlers().Add(event.ValidateType, CanMinimize)
 	windowMenu.AppendItem(item)
 
-	item = menu.NewItemWithKeyAndModifiers(i18n.Text("Zoom"), keys.VirtualKeyZ, keys.ShiftModifier|keys.PlatformMenuModifier(), func(evt event.Event) {
-		wnd := window.KeyWindow()
-		if wnd != nil {
-			wnd.Zoom()
-		}
-	})
-	item.EventHandlers().Add(event.ValidateType, func(evt event.Event) {
-		w := window.KeyWindow()
-		if w == nil || !w.Resizable() {
-			evt.(*event.Validate).MarkInvalid()
-		}
-	})
+	item = menu.NewItemWithKeyAndModifiers(i18n.Text("Zoom"), keys.VirtualKeyZ, keys.ShiftModifier|keys.PlatformMenuModifier(), Zoom)
+	item.EventHandlers().Add(event.ValidateType, CanZoom)
 	windowMenu.AppendItem(item)
 	windowMenu.AppendItem(menu.NewSeparator())
 
-	windowMenu.AppendItem(menu.NewItem(i18n.Text("Bring All to Front"), func(evt event.Event) { window.AllWindowsToFront() }))
+	windowMenu.AppendItem(menu.NewItem(i18n.Text("Bring All to Front"), BringAllToFront))
 
 	bar.AppendMenu(windowMenu)
 	bar.SetupSpecialMenu(menu.WindowMenu, windowMenu)
 }
+
+// Minimize the current key window.
+func Minimize(evt event.Event) {
+	wnd := window.KeyWindow()
+	if wnd != nil {
+		wnd.Minimize()
+	}
+}
+
+// CanMinimize marks the menu item invalid if the current key window cannot be minimized.
+func CanMinimize(evt event.Event) {
+	w := window.KeyWindow()
+	if w == nil || !w.Minimizable() {
+		evt.(*event.Validate).MarkInvalid()
+	}
+}
+
+// Zoom the current key window.
+func Zoom(evt event.Event) {
+	wnd := window.KeyWindow()
+	if wnd != nil {
+		wnd.Zoom()
+	}
+}
+
+// CanZoom marks the menu item invalid if the current key window cannot be zoomed.
+func CanZoom(evt event.Event) {
+	w := window.KeyWindow()
+	if w == nil || !w.Resizable() {
+		evt.(*event.Validate).MarkInvalid()
+	}
+}
+
+// BringAllToFront brings all of the application's windows to the foreground.
+func BringAllToFront(evt event.Event) {
+	window.AllWindowsToFront()
+}
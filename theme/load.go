@@ -0,0 +1,76 @@
+package theme
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+// Format identifies the encoding of a theme file.
+type Format int
+
+// Possible values for Format.
+const (
+	JSON Format = iota
+	TOML
+)
+
+// Load a theme from a file. The format is determined by the file's
+// extension: ".toml" files are treated as TOML, while all others are treated
+// as JSON. The loaded theme is not registered.
+func Load(path string) (*Theme, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	format := JSON
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		format = TOML
+	}
+	return Decode(data, format)
+}
+
+// Decode a theme from data in the specified format. Colors may be specified
+// in any of the forms accepted by color.Decode. Any values not present in the
+// data are taken from the registered theme named by the "extends" key, or
+// from the Light theme if that key is not present.
+func Decode(data []byte, format Format) (*Theme, error) {
+	var header struct {
+		Name    string `json:"name" toml:"name"`
+		Extends string `json:"extends" toml:"extends"`
+	}
+	if err := unmarshal(data, format, &header); err != nil {
+		return nil, err
+	}
+	if header.Name == "" {
+		return nil, errs.New("theme has no name")
+	}
+	extends := header.Extends
+	if extends == "" {
+		extends = LightName
+	}
+	base := Lookup(extends)
+	if base == nil {
+		return nil, errs.Newf("theme '%s' extends unknown theme '%s'", header.Name, extends)
+	}
+	theme := base.Clone()
+	if err := unmarshal(data, format, theme); err != nil {
+		return nil, err
+	}
+	return theme, nil
+}
+
+func unmarshal(data []byte, format Format, v interface{}) error {
+	var err error
+	switch format {
+	case TOML:
+		err = toml.Unmarshal(data, v)
+	default:
+		err = json.Unmarshal(data, v)
+	}
+	return errs.Wrap(err)
+}
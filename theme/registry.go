@@ -0,0 +1,129 @@
+package theme
+
+import (
+	"sort"
+
+	"github.com/richardwilkes/ui/color"
+)
+
+var (
+	themes       = make(map[string]*Theme)
+	current      *Theme
+	initializers []func()
+	customizers  []func()
+	listeners    []func()
+)
+
+func init() {
+	light := newLightTheme()
+	Register(light)
+	Register(newDarkTheme())
+	Register(newHighContrastTheme())
+	current = light
+}
+
+// Register a theme, replacing any existing theme with the same name. If the
+// replaced theme was the active theme, the new theme is activated in its
+// place.
+func Register(theme *Theme) {
+	themes[theme.Name] = theme
+	if current != nil && current.Name == theme.Name {
+		Activate(theme)
+	}
+}
+
+// Lookup returns the registered theme with the specified name, or nil.
+func Lookup(name string) *Theme {
+	return themes[name]
+}
+
+// Names returns the sorted names of all registered themes.
+func Names() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Current returns the active theme.
+func Current() *Theme {
+	return current
+}
+
+// ActivateByName activates the registered theme with the specified name.
+// Returns false if no such theme has been registered.
+func ActivateByName(name string) bool {
+	if theme, ok := themes[name]; ok {
+		Activate(theme)
+		return true
+	}
+	return false
+}
+
+// Activate makes the theme the active one, updating the system colors in the
+// color package, re-initializing all widget themes and notifying all change
+// listeners.
+func Activate(theme *Theme) {
+	current = theme
+	color.Background = theme.Background
+	color.ControlBackground = theme.ControlBackground
+	color.KeyboardFocus = theme.KeyboardFocus
+	color.SelectedTextBackground = theme.SelectedTextBackground
+	color.SelectedText = theme.SelectedText
+	color.TextBackground = theme.TextBackground
+	color.Text = theme.Text
+	color.TextWhenLight = theme.TextWhenLight
+	color.TextWhenDark = theme.TextWhenDark
+	color.TextWhenDisabled = theme.TextWhenDisabled
+	color.Divider = theme.Divider
+	color.MenuBorder = theme.MenuBorder
+	color.InvalidTextBackground = theme.InvalidTextBackground
+	color.ToolTipBackground = theme.ToolTipBackground
+	color.ToolTipBorder = theme.ToolTipBorder
+	Refresh()
+}
+
+// Refresh re-initializes all widget themes and notifies all change listeners
+// without altering the active theme. Useful when some other aspect the
+// widget themes depend upon, such as the standard fonts, has changed.
+func Refresh() {
+	for _, initializer := range initializers {
+		initializer()
+	}
+	for _, customizer := range customizers {
+		customizer()
+	}
+	for _, listener := range listeners {
+		listener()
+	}
+}
+
+// RegisterInitializer adds a function to be called whenever the active theme
+// changes. This is intended for widget themes that derive their default
+// values from the active theme, so the function should simply re-initialize
+// those values, typically by calling the widget theme's Init() method. Since
+// Init() resets every value, any changes an app made directly to those widget
+// themes are discarded; apps should make such changes with Customize()
+// instead. All initializers are called before any customizers and change
+// listeners.
+func RegisterInitializer(initializer func()) {
+	initializers = append(initializers, initializer)
+}
+
+// Customize adds a function that applies an app's changes to the widget
+// themes, such as using a different font for buttons. The function is called
+// immediately, and again each time the active theme changes, after the
+// initializers have reset the widget themes and before any change listeners
+// are notified. This allows the changes to survive a change of theme.
+func Customize(customizer func()) {
+	customizers = append(customizers, customizer)
+	customizer()
+}
+
+// AddChangeListener adds a function to be called whenever the active theme
+// changes, after all initializers have been called.
+func AddChangeListener(listener func()) {
+	listeners = append(listeners, listener)
+}
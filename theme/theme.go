@@ -0,0 +1,108 @@
+package theme
+
+import (
+	"github.com/richardwilkes/ui/color"
+)
+
+// Names of the built-in themes.
+const (
+	LightName        = "Light"
+	DarkName         = "Dark"
+	HighContrastName = "High Contrast"
+)
+
+// Theme holds the set of colors that widgets draw their defaults from.
+type Theme struct {
+	Name                   string      `json:"name" toml:"name"`                                         // The name the theme is registered under.
+	Extends                string      `json:"extends,omitempty" toml:"extends,omitempty"`               // The name of the theme to take unspecified values from when loading.
+	Background             color.Color `json:"background" toml:"background"`                             // The color used for the window background.
+	ControlBackground      color.Color `json:"control_background" toml:"control_background"`             // The color used for the background of controls, such as buttons.
+	KeyboardFocus          color.Color `json:"keyboard_focus" toml:"keyboard_focus"`                     // The color used to highlight controls that have the keyboard focus.
+	SelectedTextBackground color.Color `json:"selected_text_background" toml:"selected_text_background"` // The color used for the background of selected text.
+	SelectedText           color.Color `json:"selected_text" toml:"selected_text"`                       // The color used for selected text.
+	TextBackground         color.Color `json:"text_background" toml:"text_background"`                   // The color used for the background of editable text areas and lists.
+	Text                   color.Color `json:"text" toml:"text"`                                         // The color used for text.
+	TextWhenLight          color.Color `json:"text_when_light" toml:"text_when_light"`                   // The color used for text on top of a light background.
+	TextWhenDark           color.Color `json:"text_when_dark" toml:"text_when_dark"`                     // The color used for text on top of a dark background.
+	TextWhenDisabled       color.Color `json:"text_when_disabled" toml:"text_when_disabled"`             // The color used for text and marks when disabled.
+	Divider                color.Color `json:"divider" toml:"divider"`                                   // The color used for dividers and the outlines of text areas.
+	MenuBorder             color.Color `json:"menu_border" toml:"menu_border"`                           // The color used for the border of menus.
+	InvalidTextBackground  color.Color `json:"invalid_text_background" toml:"invalid_text_background"`   // The color used for the background of text areas marked invalid.
+	ToolTipBackground      color.Color `json:"tooltip_background" toml:"tooltip_background"`             // The color used for the background of tooltips.
+	ToolTipBorder          color.Color `json:"tooltip_border" toml:"tooltip_border"`                     // The color used for the border of tooltips.
+}
+
+// Clone returns a copy of this theme.
+func (theme *Theme) Clone() *Theme {
+	other := *theme
+	return &other
+}
+
+// Dark returns true if this theme uses light text on a dark background.
+func (theme *Theme) Dark() bool {
+	return theme.Background.Luminance() < 0.5
+}
+
+func newLightTheme() *Theme {
+	return &Theme{
+		Name:                   LightName,
+		Background:             color.Background,
+		ControlBackground:      color.ControlBackground,
+		KeyboardFocus:          color.KeyboardFocus,
+		SelectedTextBackground: color.SelectedTextBackground,
+		SelectedText:           color.SelectedText,
+		TextBackground:         color.TextBackground,
+		Text:                   color.Text,
+		TextWhenLight:          color.TextWhenLight,
+		TextWhenDark:           color.TextWhenDark,
+		TextWhenDisabled:       color.TextWhenDisabled,
+		Divider:                color.Divider,
+		MenuBorder:             color.MenuBorder,
+		InvalidTextBackground:  color.InvalidTextBackground,
+		ToolTipBackground:      color.ToolTipBackground,
+		ToolTipBorder:          color.ToolTipBorder,
+	}
+}
+
+func newDarkTheme() *Theme {
+	background := color.RGB(50, 50, 50)
+	return &Theme{
+		Name:                   DarkName,
+		Background:             background,
+		ControlBackground:      color.RGB(90, 90, 90),
+		KeyboardFocus:          color.KeyboardFocus,
+		SelectedTextBackground: color.KeyboardFocus.AdjustBrightness(-0.2),
+		SelectedText:           color.White,
+		TextBackground:         color.RGB(30, 30, 30),
+		Text:                   color.RGB(221, 221, 221),
+		TextWhenLight:          color.Black,
+		TextWhenDark:           color.RGB(221, 221, 221),
+		TextWhenDisabled:       color.Gray,
+		Divider:                background.AdjustBrightness(0.15),
+		MenuBorder:             color.DimGray,
+		InvalidTextBackground:  color.RGB(96, 40, 40),
+		ToolTipBackground:      color.RGB(70, 70, 56),
+		ToolTipBorder:          color.Gray,
+	}
+}
+
+func newHighContrastTheme() *Theme {
+	return &Theme{
+		Name:                   HighContrastName,
+		Background:             color.Black,
+		ControlBackground:      color.Black,
+		KeyboardFocus:          color.Yellow,
+		SelectedTextBackground: color.Cyan,
+		SelectedText:           color.Black,
+		TextBackground:         color.Black,
+		Text:                   color.White,
+		TextWhenLight:          color.Black,
+		TextWhenDark:           color.White,
+		TextWhenDisabled:       color.Lime,
+		Divider:                color.White,
+		MenuBorder:             color.White,
+		InvalidTextBackground:  color.Maroon,
+		ToolTipBackground:      color.Black,
+		ToolTipBorder:          color.Yellow,
+	}
+}
@@ -0,0 +1,99 @@
+package undo
+
+// DefaultLimit holds the number of edits new stacks keep.
+var DefaultLimit = 100
+
+// Edit is a change that has been made and can be reverted and made again.
+type Edit interface {
+	// Undo reverts the change.
+	Undo()
+	// Redo makes the change again after it has been reverted.
+	Redo()
+}
+
+// Merger may be implemented by an Edit that can absorb the edit made after it, such as when
+// characters are typed one after another.
+type Merger interface {
+	// Merge returns true if the edit absorbed 'next', so that undoing it also undoes 'next'.
+	Merge(next Edit) bool
+}
+
+// Stack holds the edits that have been made to something, such as the content of a text widget, so
+// that they may be undone and redone.
+type Stack struct {
+	Limit int // The maximum number of edits to keep. Zero or less means there is no limit.
+	edits []Edit
+	next  int  // The index of the edit that Redo() would make again.
+	open  bool // True if the most recent edit may absorb the next one.
+}
+
+// NewStack creates a new, empty stack holding up to DefaultLimit edits.
+func NewStack() *Stack {
+	return &Stack{Limit: DefaultLimit}
+}
+
+// Add records an edit that has just been made, discarding any edits that were undone. If the most
+// recent edit implements Merger and absorbs this one, no new entry is made.
+func (s *Stack) Add(edit Edit) {
+	s.edits = s.edits[:s.next]
+	if s.open && s.next > 0 {
+		if merger, ok := s.edits[s.next-1].(Merger); ok && merger.Merge(edit) {
+			return
+		}
+	}
+	s.edits = append(s.edits, edit)
+	if s.Limit > 0 && len(s.edits) > s.Limit {
+		excess := len(s.edits) - s.Limit
+		copy(s.edits, s.edits[excess:])
+		for i := len(s.edits) - excess; i < len(s.edits); i++ {
+			s.edits[i] = nil
+		}
+		s.edits = s.edits[:s.Limit]
+	}
+	s.next = len(s.edits)
+	s.open = true
+}
+
+// Seal prevents the most recent edit from absorbing the next one, such as when the caret is moved
+// away from where characters were being typed.
+func (s *Stack) Seal() {
+	s.open = false
+}
+
+// CanUndo returns true if there is an edit to undo.
+func (s *Stack) CanUndo() bool {
+	return s.next > 0
+}
+
+// Undo reverts the most recent edit that hasn't been undone.
+func (s *Stack) Undo() {
+	if s.CanUndo() {
+		s.open = false
+		s.next--
+		s.edits[s.next].Undo()
+	}
+}
+
+// CanRedo returns true if there is an undone edit to make again.
+func (s *Stack) CanRedo() bool {
+	return s.next < len(s.edits)
+}
+
+// Redo makes the most recently undone edit again.
+func (s *Stack) Redo() {
+	if s.CanRedo() {
+		s.open = false
+		s.edits[s.next].Redo()
+		s.next++
+	}
+}
+
+// Clear discards all of the edits.
+func (s *Stack) Clear() {
+	for i := range s.edits {
+		s.edits[i] = nil
+	}
+	s.edits = s.edits[:0]
+	s.next = 0
+	s.open = false
+}
@@ -0,0 +1,99 @@
+package undo
+
+import (
+	"strings"
+	"testing"
+)
+
+// logEdit records its name in 'log' each time it is undone.
+type logEdit struct {
+	name string
+	log  *[]string
+}
+
+func (edit *logEdit) Undo() {
+	*edit.log = append(*edit.log, edit.name)
+}
+
+func (edit *logEdit) Redo() {
+}
+
+// mergeEdit absorbs any mergeEdit made after it.
+type mergeEdit struct {
+	logEdit
+}
+
+func (edit *mergeEdit) Merge(next Edit) bool {
+	other, ok := next.(*mergeEdit)
+	if ok {
+		edit.name += other.name
+	}
+	return ok
+}
+
+// TestStack runs scripts of operations against a stack. In a script, a plain name adds an edit, a
+// name prefixed by '+' adds an edit that absorbs the mergeable edits after it, '<' is Undo(), '>'
+// is Redo() and '|' is Seal(). Afterwards, the edits left to redo are counted and every edit that
+// can be undone is undone in turn.
+func TestStack(t *testing.T) {
+	for i, one := range []struct {
+		script   string
+		limit    int
+		redoable int
+		undone   string
+	}{
+		{"a b c", 0, 0, "c b a"},
+		{"a b c", 2, 0, "c b"},
+		{"a b", 1, 0, "b"},
+		{"a b c d", 3, 0, "d c b"},
+		{"a b <", 0, 1, "a"},
+		{"a b < <", 0, 2, ""},
+		{"a b < < >", 0, 1, "a"},
+		{"a b < c", 0, 0, "c a"},
+		{"a b < < c", 0, 0, "c"},
+		{"+a +b +c", 0, 0, "abc"},
+		{"+a +b | +c", 0, 0, "c ab"},
+		{"+a < > +b", 0, 0, "b a"},
+		{"+a +b < +c", 0, 0, "c"},
+		{"+a b +c", 0, 0, "c b a"},
+		{"a +b +c", 0, 0, "bc a"},
+		{"a b +c +d", 2, 0, "cd b"},
+		{"+a +b c", 2, 0, "c ab"},
+	} {
+		var log []string
+		s := NewStack()
+		s.Limit = one.limit
+		for _, op := range strings.Fields(one.script) {
+			switch {
+			case op == "<":
+				s.Undo()
+			case op == ">":
+				s.Redo()
+			case op == "|":
+				s.Seal()
+			case strings.HasPrefix(op, "+"):
+				s.Add(&mergeEdit{logEdit{name: op[1:], log: &log}})
+			default:
+				s.Add(&logEdit{name: op, log: &log})
+			}
+		}
+		redoable := 0
+		for s.CanRedo() {
+			s.Redo()
+			redoable++
+		}
+		if redoable != one.redoable {
+			t.Errorf("%d: %q left %d edits to redo, expected %d", i, one.script, redoable, one.redoable)
+		}
+		for j := 0; j < redoable; j++ {
+			s.Undo()
+		}
+		log = nil
+		for s.CanUndo() {
+			s.Undo()
+		}
+		if undone := strings.Join(log, " "); undone != one.undone {
+			t.Errorf("%d: %q undid %q, expected %q", i, one.script, undone, one.undone)
+		}
+	}
+}
@@ -17,8 +17,8 @@ type BaseTextTheme struct {
 // Init initializes the theme with its default values.
 func (theme *BaseTextTheme) Init() {
 	theme.BaseTheme.Init()
-	theme.TextWhenLight = color.Black
-	theme.TextWhenDark = color.White
-	theme.TextWhenDisabled = color.Gray
+	theme.TextWhenLight = color.TextWhenLight
+	theme.TextWhenDark = color.TextWhenDark
+	theme.TextWhenDisabled = color.TextWhenDisabled
 	theme.Font = font.System
 }
@@ -22,7 +22,7 @@ type BaseTheme struct {
 func (theme *BaseTheme) Init() {
 	theme.ClickAnimationTime = time.Millisecond * 100
 	theme.CornerRadius = 6
-	theme.Background = color.White
+	theme.Background = color.ControlBackground
 	theme.BackgroundWhenPressed = color.KeyboardFocus
 	theme.GradientAdjustment = 0.15
 	theme.DisabledAdjustment = -0.05
@@ -1,10 +1,18 @@
 package button
 
+import (
+	"github.com/richardwilkes/ui/theme"
+)
+
 var (
 	// StdButton is the theme all new Buttons get by default.
 	StdButton = NewTheme()
 )
 
+func init() {
+	theme.RegisterInitializer(func() { StdButton.Init() })
+}
+
 // Theme contains the theme elements for Buttons.
 type Theme struct {
 	BaseTextTheme
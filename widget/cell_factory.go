@@ -15,3 +15,13 @@ type CellFactory interface {
 	// selected state. 'focused' indicates the cell should be created in its focused state.
 	CreateCell(owner ui.Widget, element interface{}, index int, selected, focused bool) ui.Widget
 }
+
+// CellUpdater may be implemented by cell factories that are able to reuse the cells they created
+// earlier. Lists use it to recycle cells rather than creating new ones for each row every time
+// they are painted.
+type CellUpdater interface {
+	// UpdateCell changes 'cell', which was previously returned by CreateCell, so that it is
+	// equivalent to what CreateCell would have returned for the other arguments. Returns false if
+	// the cell cannot be reused, in which case CreateCell will be called instead.
+	UpdateCell(cell ui.Widget, owner ui.Widget, element interface{}, index int, selected, focused bool) bool
+}
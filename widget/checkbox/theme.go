@@ -1,6 +1,7 @@
 package checkbox
 
 import (
+	"github.com/richardwilkes/ui/theme"
 	"github.com/richardwilkes/ui/widget/button"
 )
 
@@ -9,6 +10,10 @@ var (
 	StdCheckBox = NewTheme()
 )
 
+func init() {
+	theme.RegisterInitializer(func() { StdCheckBox.Init() })
+}
+
 // Theme contains the theme elements for CheckBoxes.
 type Theme struct {
 	button.BaseTextTheme
@@ -0,0 +1,246 @@
+package codeeditor
+
+import "sort"
+
+// Buffer holds text as a piece table. The original text and everything inserted since are kept in
+// two buffers that are only ever appended to, and the content is a sequence of pieces referring to
+// spans of them. Edits only split, add and remove pieces, so their cost doesn't depend on the size
+// of the text. Offsets are rune indexes into the content.
+type Buffer struct {
+	original       []rune
+	added          []rune
+	originalBreaks []int // The offsets of the newlines within original.
+	addedBreaks    []int // The offsets of the newlines within added.
+	pieces         []piece
+	length         int
+	breaks         int
+}
+
+type piece struct {
+	added  bool
+	start  int
+	length int
+	breaks int // The number of newlines within the piece.
+}
+
+// NewBuffer creates a new buffer holding 'text'.
+func NewBuffer(text string) *Buffer {
+	b := &Buffer{}
+	b.SetText(text)
+	return b
+}
+
+// SetText replaces the content of the buffer.
+func (b *Buffer) SetText(text string) {
+	b.original = []rune(text)
+	b.originalBreaks = findBreaks(b.original, 0, nil)
+	b.added = nil
+	b.addedBreaks = nil
+	b.pieces = nil
+	b.length = len(b.original)
+	b.breaks = len(b.originalBreaks)
+	if b.length != 0 {
+		b.pieces = append(b.pieces, piece{length: b.length, breaks: b.breaks})
+	}
+}
+
+func findBreaks(runes []rune, offset int, breaks []int) []int {
+	for i, r := range runes {
+		if r == '\n' {
+			breaks = append(breaks, offset+i)
+		}
+	}
+	return breaks
+}
+
+// countBreaks returns the number of newline offsets in 'breaks' from 'start' up to, but not
+// including, 'end'.
+func countBreaks(breaks []int, start, end int) int {
+	return sort.SearchInts(breaks, end) - sort.SearchInts(breaks, start)
+}
+
+func (b *Buffer) source(p piece) (runes []rune, breaks []int) {
+	if p.added {
+		return b.added, b.addedBreaks
+	}
+	return b.original, b.originalBreaks
+}
+
+// Len returns the number of runes in the buffer.
+func (b *Buffer) Len() int {
+	return b.length
+}
+
+// LineCount returns the number of lines in the buffer. This is always at least one, as an empty
+// buffer holds a single empty line.
+func (b *Buffer) LineCount() int {
+	return b.breaks + 1
+}
+
+func (b *Buffer) clamp(offset int) int {
+	if offset < 0 {
+		return 0
+	}
+	if offset > b.length {
+		return b.length
+	}
+	return offset
+}
+
+// locate returns the index of the piece containing 'offset' and the offset within it. An offset at
+// the end of the content returns the number of pieces.
+func (b *Buffer) locate(offset int) (index, within int) {
+	for i, p := range b.pieces {
+		if offset < p.length {
+			return i, offset
+		}
+		offset -= p.length
+	}
+	return len(b.pieces), offset
+}
+
+// boundary ensures a piece starts at 'offset', splitting the piece containing it if necessary, and
+// returns that piece's index.
+func (b *Buffer) boundary(offset int) int {
+	index, within := b.locate(offset)
+	if within == 0 {
+		return index
+	}
+	p := b.pieces[index]
+	_, breaks := b.source(p)
+	left := piece{added: p.added, start: p.start, length: within, breaks: countBreaks(breaks, p.start, p.start+within)}
+	right := piece{added: p.added, start: p.start + within, length: p.length - within, breaks: p.breaks - left.breaks}
+	b.pieces = append(b.pieces, piece{})
+	copy(b.pieces[index+2:], b.pieces[index+1:])
+	b.pieces[index] = left
+	b.pieces[index+1] = right
+	return index + 1
+}
+
+// Insert inserts 'text' at 'offset'.
+func (b *Buffer) Insert(offset int, text []rune) {
+	if len(text) == 0 {
+		return
+	}
+	start := len(b.added)
+	b.added = append(b.added, text...)
+	b.addedBreaks = findBreaks(text, start, b.addedBreaks)
+	p := piece{added: true, start: start, length: len(text), breaks: countBreaks(b.addedBreaks, start, start+len(text))}
+	index := b.boundary(b.clamp(offset))
+	b.length += p.length
+	b.breaks += p.breaks
+	if index > 0 {
+		// Text typed a character at a time extends a single piece
+		if prev := &b.pieces[index-1]; prev.added && prev.start+prev.length == start {
+			prev.length += p.length
+			prev.breaks += p.breaks
+			return
+		}
+	}
+	b.pieces = append(b.pieces, piece{})
+	copy(b.pieces[index+1:], b.pieces[index:])
+	b.pieces[index] = p
+}
+
+// Delete removes the runes from 'start' up to, but not including, 'end'.
+func (b *Buffer) Delete(start, end int) {
+	start = b.clamp(start)
+	end = b.clamp(end)
+	if start >= end {
+		return
+	}
+	first := b.boundary(start)
+	last := b.boundary(end)
+	for _, p := range b.pieces[first:last] {
+		b.length -= p.length
+		b.breaks -= p.breaks
+	}
+	b.pieces = append(b.pieces[:first], b.pieces[last:]...)
+}
+
+// Slice returns the runes from 'start' up to, but not including, 'end'.
+func (b *Buffer) Slice(start, end int) []rune {
+	start = b.clamp(start)
+	end = b.clamp(end)
+	if start >= end {
+		return nil
+	}
+	result := make([]rune, 0, end-start)
+	index, within := b.locate(start)
+	for remaining := end - start; remaining > 0 && index < len(b.pieces); index++ {
+		p := b.pieces[index]
+		runes, _ := b.source(p)
+		count := p.length - within
+		if count > remaining {
+			count = remaining
+		}
+		result = append(result, runes[p.start+within:p.start+within+count]...)
+		remaining -= count
+		within = 0
+	}
+	return result
+}
+
+// RuneAt returns the rune at 'offset', or zero if the offset is out of range.
+func (b *Buffer) RuneAt(offset int) rune {
+	if offset < 0 || offset >= b.length {
+		return 0
+	}
+	index, within := b.locate(offset)
+	p := b.pieces[index]
+	runes, _ := b.source(p)
+	return runes[p.start+within]
+}
+
+// String returns the content of the buffer.
+func (b *Buffer) String() string {
+	return string(b.Slice(0, b.length))
+}
+
+// LineStart returns the offset of the start of 'line'.
+func (b *Buffer) LineStart(line int) int {
+	if line <= 0 {
+		return 0
+	}
+	if line > b.breaks {
+		return b.length
+	}
+	offset := 0
+	for _, p := range b.pieces {
+		if line <= p.breaks {
+			_, breaks := b.source(p)
+			return offset + breaks[sort.SearchInts(breaks, p.start)+line-1] - p.start + 1
+		}
+		line -= p.breaks
+		offset += p.length
+	}
+	return b.length
+}
+
+// LineEnd returns the offset of the end of 'line', which is that of its newline, if it has one.
+func (b *Buffer) LineEnd(line int) int {
+	if line >= b.breaks {
+		return b.length
+	}
+	return b.LineStart(line+1) - 1
+}
+
+// Line returns the runes of 'line', excluding its newline.
+func (b *Buffer) Line(line int) []rune {
+	return b.Slice(b.LineStart(line), b.LineEnd(line))
+}
+
+// LineOf returns the line containing 'offset'.
+func (b *Buffer) LineOf(offset int) int {
+	offset = b.clamp(offset)
+	line := 0
+	for _, p := range b.pieces {
+		if offset < p.length {
+			_, breaks := b.source(p)
+			return line + countBreaks(breaks, p.start, p.start+offset)
+		}
+		offset -= p.length
+		line += p.breaks
+	}
+	return line
+}
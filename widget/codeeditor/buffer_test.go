@@ -0,0 +1,119 @@
+package codeeditor
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// checkBuffer verifies every query the buffer answers against a naive model of its content.
+func checkBuffer(t *testing.T, b *Buffer, model []rune, step int) {
+	t.Helper()
+	if b.Len() != len(model) {
+		t.Fatalf("step %d: Len() = %d, expected %d", step, b.Len(), len(model))
+	}
+	if text := b.String(); text != string(model) {
+		t.Fatalf("step %d: String() = %q, expected %q", step, text, string(model))
+	}
+	lines := strings.Split(string(model), "\n")
+	if b.LineCount() != len(lines) {
+		t.Fatalf("step %d: LineCount() = %d, expected %d", step, b.LineCount(), len(lines))
+	}
+	offset := 0
+	for i, line := range lines {
+		length := len([]rune(line))
+		if start := b.LineStart(i); start != offset {
+			t.Fatalf("step %d: LineStart(%d) = %d, expected %d", step, i, start, offset)
+		}
+		if end := b.LineEnd(i); end != offset+length {
+			t.Fatalf("step %d: LineEnd(%d) = %d, expected %d", step, i, end, offset+length)
+		}
+		if text := string(b.Line(i)); text != line {
+			t.Fatalf("step %d: Line(%d) = %q, expected %q", step, i, text, line)
+		}
+		for j := offset; j <= offset+length; j++ {
+			if got := b.LineOf(j); got != i {
+				t.Fatalf("step %d: LineOf(%d) = %d, expected %d", step, j, got, i)
+			}
+		}
+		offset += length + 1
+	}
+	if start := b.LineStart(len(lines)); start != len(model) {
+		t.Fatalf("step %d: LineStart past the last line = %d, expected %d", step, start, len(model))
+	}
+	for i, r := range model {
+		if got := b.RuneAt(i); got != r {
+			t.Fatalf("step %d: RuneAt(%d) = %q, expected %q", step, i, got, r)
+		}
+	}
+	if r := b.RuneAt(len(model)); r != 0 {
+		t.Fatalf("step %d: RuneAt(%d) = %q, expected 0", step, len(model), r)
+	}
+}
+
+func randomText(rnd *rand.Rand) []rune {
+	const alphabet = "ab\nč世 \n"
+	choices := []rune(alphabet)
+	text := make([]rune, rnd.Intn(6))
+	for i := range text {
+		text[i] = choices[rnd.Intn(len(choices))]
+	}
+	return text
+}
+
+func TestBufferAgainstModel(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for round := 0; round < 20; round++ {
+		model := randomText(rnd)
+		b := NewBuffer(string(model))
+		checkBuffer(t, b, model, 0)
+		for step := 1; step <= 300; step++ {
+			switch op := rnd.Intn(10); {
+			case op < 5:
+				offset := rnd.Intn(len(model)+3) - 1
+				text := randomText(rnd)
+				b.Insert(offset, text)
+				if offset < 0 {
+					offset = 0
+				} else if offset > len(model) {
+					offset = len(model)
+				}
+				model = append(model[:offset:offset], append(text, model[offset:]...)...)
+			case op < 9:
+				start := rnd.Intn(len(model)+3) - 1
+				end := start + rnd.Intn(8) - 1
+				b.Delete(start, end)
+				if start < 0 {
+					start = 0
+				}
+				if end > len(model) {
+					end = len(model)
+				}
+				if start < end {
+					model = append(model[:start:start], model[end:]...)
+				}
+			default:
+				start := rnd.Intn(len(model) + 1)
+				end := start + rnd.Intn(len(model)-start+1)
+				if got := string(b.Slice(start, end)); got != string(model[start:end]) {
+					t.Fatalf("step %d: Slice(%d, %d) = %q, expected %q", step, start, end, got, string(model[start:end]))
+				}
+			}
+			checkBuffer(t, b, model, step)
+		}
+		text := randomText(rnd)
+		b.SetText(string(text))
+		checkBuffer(t, b, text, -1)
+	}
+}
+
+func TestBufferEmpty(t *testing.T) {
+	b := NewBuffer("")
+	checkBuffer(t, b, nil, 0)
+	b.Insert(0, []rune("x\n"))
+	b.Delete(0, 2)
+	checkBuffer(t, b, nil, 1)
+	if b.Slice(0, 1) != nil {
+		t.Fatal("Slice of an empty buffer should be nil")
+	}
+}
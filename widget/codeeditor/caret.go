@@ -0,0 +1,155 @@
+package codeeditor
+
+import "sort"
+
+// caret is one of the editor's cursors, along with the selection it extends.
+type caret struct {
+	pos     int  // The offset of the cursor.
+	anchor  int  // The offset of the other end of the selection; the same as pos when there is none.
+	column  int  // The visual column to aim for when moving vertically, or -1 if not yet established.
+	primary bool // True for the caret that was placed most recently, which scrolling follows.
+}
+
+func newCaret(pos, anchor int, primary bool) caret {
+	return caret{pos: pos, anchor: anchor, column: -1, primary: primary}
+}
+
+func (c caret) start() int {
+	if c.pos < c.anchor {
+		return c.pos
+	}
+	return c.anchor
+}
+
+func (c caret) end() int {
+	if c.pos > c.anchor {
+		return c.pos
+	}
+	return c.anchor
+}
+
+func (c caret) hasRange() bool {
+	return c.pos != c.anchor
+}
+
+// normalizeCarets sorts the carets and merges any that overlap or share a starting point.
+func (editor *CodeEditor) normalizeCarets() {
+	length := editor.buffer.Len()
+	for i := range editor.carets {
+		c := &editor.carets[i]
+		c.pos = clampInt(c.pos, 0, length)
+		c.anchor = clampInt(c.anchor, 0, length)
+	}
+	sort.Slice(editor.carets, func(i, j int) bool { return editor.carets[i].start() < editor.carets[j].start() })
+	merged := editor.carets[:1]
+	for _, c := range editor.carets[1:] {
+		last := &merged[len(merged)-1]
+		if c.start() < last.end() || c.start() == last.start() {
+			start := last.start()
+			end := last.end()
+			if c.end() > end {
+				end = c.end()
+			}
+			forward := last.pos >= last.anchor
+			if c.primary {
+				forward = c.pos >= c.anchor
+				last.primary = true
+				last.column = c.column
+			}
+			if forward {
+				last.anchor = start
+				last.pos = end
+			} else {
+				last.anchor = end
+				last.pos = start
+			}
+		} else {
+			merged = append(merged, c)
+		}
+	}
+	editor.carets = merged
+}
+
+// primaryCaret returns the caret that scrolling follows.
+func (editor *CodeEditor) primaryCaret() *caret {
+	for i := range editor.carets {
+		if editor.carets[i].primary {
+			return &editor.carets[i]
+		}
+	}
+	editor.carets[len(editor.carets)-1].primary = true
+	return &editor.carets[len(editor.carets)-1]
+}
+
+// setCaret replaces all of the carets with a single one.
+func (editor *CodeEditor) setCaret(pos, anchor int) {
+	editor.carets = append(editor.carets[:0], newCaret(pos, anchor, true))
+	editor.caretsMoved()
+}
+
+// addCaret adds a caret, making it the primary one.
+func (editor *CodeEditor) addCaret(pos, anchor int) {
+	for i := range editor.carets {
+		editor.carets[i].primary = false
+	}
+	editor.carets = append(editor.carets, newCaret(pos, anchor, true))
+	editor.caretsMoved()
+}
+
+// removeCaretAt removes the caret at 'pos', unless it is the only one. Returns true if one was
+// removed.
+func (editor *CodeEditor) removeCaretAt(pos int) bool {
+	if len(editor.carets) < 2 {
+		return false
+	}
+	for i, c := range editor.carets {
+		if c.pos == pos && !c.hasRange() {
+			editor.carets = append(editor.carets[:i], editor.carets[i+1:]...)
+			editor.primaryCaret()
+			editor.caretsMoved()
+			return true
+		}
+	}
+	return false
+}
+
+// moveCarets moves every caret to the offset returned for it by 'target'. If 'extend' is true,
+// the selections are extended rather than collapsed. If 'keepColumn' is true, the visual column
+// the carets aim for when moving vertically is retained.
+func (editor *CodeEditor) moveCarets(extend, keepColumn bool, target func(c *caret) int) {
+	for i := range editor.carets {
+		c := &editor.carets[i]
+		pos := target(c)
+		if !keepColumn {
+			c.column = -1
+		}
+		c.pos = pos
+		if !extend {
+			c.anchor = pos
+		}
+	}
+	editor.caretsMoved()
+}
+
+// caretsMoved tidies up after the carets have been changed. Unless they moved as a result of typing,
+// the next edit is kept apart from the previous one in the history.
+func (editor *CodeEditor) caretsMoved() {
+	if !editor.typing {
+		editor.history.Seal()
+	}
+	editor.normalizeCarets()
+	editor.showCursor = true
+	editor.resetBlink()
+	editor.ScrollOffsetIntoView(editor.primaryCaret().pos)
+	editor.Repaint()
+}
+
+func clampInt(value, min, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
@@ -0,0 +1,940 @@
+package codeeditor
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/richardwilkes/toolbox/xmath"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/cursor"
+	"github.com/richardwilkes/ui/draw"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/event/button"
+	"github.com/richardwilkes/ui/keys"
+	"github.com/richardwilkes/ui/layout"
+	"github.com/richardwilkes/ui/menu"
+	"github.com/richardwilkes/ui/menu/editmenu"
+	"github.com/richardwilkes/ui/undo"
+	"github.com/richardwilkes/ui/widget"
+	"github.com/richardwilkes/ui/widget/scrollbar"
+	"github.com/richardwilkes/ui/window"
+)
+
+var (
+	// BracketScanLimit is the maximum number of lines searched for the bracket matching the one at
+	// the cursor.
+	BracketScanLimit = 1000
+)
+
+const brackets = "()[]{}"
+
+// CodeEditor provides a multi-line editor for source code, with line numbers, syntax highlighting,
+// bracket matching, automatic indentation and multiple cursors. It is intended to be placed within
+// a ScrollArea. Only the lines that are visible are drawn, so it copes with very large files.
+type CodeEditor struct {
+	widget.Block
+	Theme          *Theme // The theme the editor will use to draw itself.
+	buffer         *Buffer
+	lexer          Lexer
+	states         []int // The lexer state at the start of each line, for as many lines as have been lexed.
+	carets         []caret
+	tabWidth       int
+	insertSpaces   bool
+	autoIndent     bool
+	widest         int // The visual width of the widest line, in columns.
+	sizedLines     int // The number of lines when the size last changed.
+	sizedWidest    int // The widest line when the size last changed.
+	forceShowUntil time.Time
+	showCursor     bool
+	pending        bool
+	extendByWord   bool
+	dragAnchor     int
+	highlights     []widget.TextRange
+	history        *undo.Stack
+	typing         bool
+}
+
+// New creates a new, empty, code editor.
+func New() *CodeEditor {
+	editor := &CodeEditor{Theme: StdTheme, buffer: NewBuffer(""), tabWidth: 4, autoIndent: true, history: undo.NewStack()}
+	editor.InitTypeAndID(editor)
+	editor.Describer = func() string { return fmt.Sprintf("CodeEditor #%d", editor.ID()) }
+	editor.carets = []caret{newCaret(0, 0, true)}
+	editor.states = []int{0}
+	editor.sizedLines = 1
+	editor.SetBackground(color.TextBackground)
+	editor.SetFocusable(true)
+	editor.SetGrabFocusWhenClickedOn(true)
+	editor.SetSizer(editor)
+	handlers := editor.EventHandlers()
+	handlers.Add(event.PaintType, editor.paint)
+	handlers.Add(event.FocusGainedType, editor.focusChanged)
+	handlers.Add(event.FocusLostType, editor.focusChanged)
+	handlers.Add(event.MouseDownType, editor.mouseDown)
+	handlers.Add(event.MouseDraggedType, editor.mouseDragged)
+	handlers.Add(event.ContextMenuType, editor.contextMenu)
+	handlers.Add(event.KeyDownType, editor.keyDown)
+	handlers.Add(event.UpdateCursorType, editor.setCursor)
+	handlers.Add(event.ThemeChangedType, editor.themeChanged)
+	return editor
+}
+
+// Text returns the content of the editor.
+func (editor *CodeEditor) Text() string {
+	return editor.buffer.String()
+}
+
+// SetText replaces the content of the editor, placing a single cursor at its start and discarding
+// the edit history.
+func (editor *CodeEditor) SetText(text string) {
+	editor.buffer.SetText(strings.Replace(text, "\r\n", "\n", -1))
+	editor.states = editor.states[:1]
+	editor.widest = 0
+	editor.measureLines(0, editor.buffer.LineCount()-1)
+	editor.carets = append(editor.carets[:0], newCaret(0, 0, true))
+	editor.highlights = nil
+	editor.history.Clear()
+	editor.contentChanged()
+	editor.caretsMoved()
+}
+
+// Buffer returns the buffer holding the editor's content. It must not be modified directly.
+func (editor *CodeEditor) Buffer() *Buffer {
+	return editor.buffer
+}
+
+// Lexer returns the lexer used for syntax highlighting, or nil.
+func (editor *CodeEditor) Lexer() Lexer {
+	return editor.lexer
+}
+
+// SetLexer sets the lexer used for syntax highlighting. Passing nil turns highlighting off.
+func (editor *CodeEditor) SetLexer(lexer Lexer) {
+	editor.lexer = lexer
+	editor.states = editor.states[:1]
+	editor.Repaint()
+}
+
+// TabWidth returns the number of columns between tab stops.
+func (editor *CodeEditor) TabWidth() int {
+	return editor.tabWidth
+}
+
+// SetTabWidth sets the number of columns between tab stops.
+func (editor *CodeEditor) SetTabWidth(width int) {
+	if width < 1 {
+		width = 1
+	}
+	if editor.tabWidth != width {
+		editor.tabWidth = width
+		editor.widest = 0
+		editor.measureLines(0, editor.buffer.LineCount()-1)
+		editor.sizeChanged()
+	}
+}
+
+// InsertSpaces returns true if indentation is made of spaces rather than tabs.
+func (editor *CodeEditor) InsertSpaces() bool {
+	return editor.insertSpaces
+}
+
+// SetInsertSpaces sets whether indentation is made of spaces rather than tabs. When it is, the Tab
+// key inserts spaces up to the next tab stop and Backspace within leading spaces removes them back
+// to the previous one.
+func (editor *CodeEditor) SetInsertSpaces(insertSpaces bool) {
+	editor.insertSpaces = insertSpaces
+}
+
+// AutoIndent returns true if new lines are automatically indented.
+func (editor *CodeEditor) AutoIndent() bool {
+	return editor.autoIndent
+}
+
+// SetAutoIndent sets whether new lines are automatically indented. When they are, a new line gets
+// the indentation of the one it was split from, plus one level after an opening bracket, and
+// typing a closing bracket at the start of a line removes one level.
+func (editor *CodeEditor) SetAutoIndent(autoIndent bool) {
+	editor.autoIndent = autoIndent
+}
+
+// CaretCount returns the number of cursors.
+func (editor *CodeEditor) CaretCount() int {
+	return len(editor.carets)
+}
+
+// Selection returns the start and end offsets of the primary cursor's selection.
+func (editor *CodeEditor) Selection() (start, end int) {
+	c := editor.primaryCaret()
+	return c.start(), c.end()
+}
+
+// SetSelection replaces all of the cursors with a single one selecting from 'start' to 'end'.
+func (editor *CodeEditor) SetSelection(start, end int) {
+	editor.setCaret(end, start)
+}
+
+// AddSelection adds a cursor selecting from 'start' to 'end', making it the primary one.
+func (editor *CodeEditor) AddSelection(start, end int) {
+	editor.addCaret(end, start)
+}
+
+// Sizes implements Sizer
+func (editor *CodeEditor) Sizes(hint geom.Size) (min, pref, max geom.Size) {
+	advance := editor.advance()
+	pref.Width = editor.gutterWidth(advance) + editor.Theme.Padding*2 + float64(editor.widest+1)*advance
+	pref.Height = float64(editor.buffer.LineCount()) * editor.lineHeight()
+	pref.GrowToInteger()
+	if border := editor.Border(); border != nil {
+		pref.AddInsets(border.Insets())
+	}
+	return pref, pref, layout.DefaultMaxSize(pref)
+}
+
+// LineScrollAmount implements Pager.
+func (editor *CodeEditor) LineScrollAmount(horizontal, towardsStart bool) float64 {
+	if horizontal {
+		return editor.advance()
+	}
+	return editor.lineHeight()
+}
+
+// PageScrollAmount implements Pager.
+func (editor *CodeEditor) PageScrollAmount(horizontal, towardsStart bool) float64 {
+	if scroller := editor.scroller(); scroller != nil {
+		if horizontal {
+			return scroller.VisibleSize(true) - editor.gutterWidth(editor.advance())
+		}
+		lineHeight := editor.lineHeight()
+		return math.Max(math.Floor(scroller.VisibleSize(false)/lineHeight)-1, 1) * lineHeight
+	}
+	return editor.lineHeight()
+}
+
+func (editor *CodeEditor) scroller() scrollbar.Scrollable {
+	for parent := editor.Parent(); parent != nil; parent = parent.Parent() {
+		if scroller, ok := parent.(scrollbar.Scrollable); ok {
+			return scroller
+		}
+	}
+	return nil
+}
+
+// ScrollOffsetIntoView scrolls the enclosing ScrollArea, if any, to reveal the specified offset.
+func (editor *CodeEditor) ScrollOffsetIntoView(offset int) {
+	scroller := editor.scroller()
+	if scroller == nil {
+		return
+	}
+	advance := editor.advance()
+	lineHeight := editor.lineHeight()
+	line := editor.buffer.LineOf(offset)
+	top := editor.lineTop(line)
+	pos := scroller.ScrolledPosition(false)
+	visible := scroller.VisibleSize(false)
+	if top < pos {
+		scroller.SetScrolledPosition(false, top)
+	} else if top+lineHeight > pos+visible {
+		scroller.SetScrolledPosition(false, top+lineHeight-visible)
+	}
+	x := editor.xForIndex(editor.buffer.Line(line), offset-editor.buffer.LineStart(line), advance)
+	// The gutter stays put, covering the left edge of the view
+	left := editor.gutterWidth(advance) + editor.Theme.Padding
+	pos = scroller.ScrolledPosition(true)
+	visible = scroller.VisibleSize(true)
+	if x-left < pos {
+		scroller.SetScrolledPosition(true, math.Max(x-left, 0))
+	} else if x+advance > pos+visible {
+		scroller.SetScrolledPosition(true, x+advance-visible)
+	}
+}
+
+func (editor *CodeEditor) lineHeight() float64 {
+	return math.Ceil(editor.Theme.Font.Height())
+}
+
+// advance returns the width of a column.
+func (editor *CodeEditor) advance() float64 {
+	return editor.Theme.Font.Measure("0").Width
+}
+
+func (editor *CodeEditor) gutterWidth(advance float64) float64 {
+	digits := len(strconv.Itoa(editor.buffer.LineCount()))
+	if digits < 2 {
+		digits = 2
+	}
+	return float64(digits)*advance + editor.Theme.Padding*2
+}
+
+func (editor *CodeEditor) lineTop(line int) float64 {
+	return editor.LocalInsetBounds().Y + float64(line)*editor.lineHeight()
+}
+
+// lineAt returns the line at the vertical coordinate, constrained to the lines that exist.
+func (editor *CodeEditor) lineAt(y float64) int {
+	line := int(math.Floor((y - editor.LocalInsetBounds().Y) / editor.lineHeight()))
+	return clampInt(line, 0, editor.buffer.LineCount()-1)
+}
+
+// textLeft returns the horizontal coordinate where lines start.
+func (editor *CodeEditor) textLeft(advance float64) float64 {
+	return editor.LocalInsetBounds().X + editor.gutterWidth(advance) + editor.Theme.Padding
+}
+
+// gutterLeft returns the horizontal coordinate of the gutter, which stays at the left edge of the
+// visible area as the editor is scrolled horizontally.
+func (editor *CodeEditor) gutterLeft() float64 {
+	left := editor.LocalInsetBounds().X
+	if scroller := editor.scroller(); scroller != nil {
+		left += scroller.ScrolledPosition(true)
+	}
+	return left
+}
+
+// xForIndex returns the horizontal coordinate of the rune index within the line.
+func (editor *CodeEditor) xForIndex(line []rune, index int, advance float64) float64 {
+	if index > len(line) {
+		index = len(line)
+	}
+	x := editor.textLeft(advance)
+	if editor.Theme.Font.Monospaced() {
+		return x + float64(editor.visualColumn(line, index))*advance
+	}
+	return x + editor.Theme.Font.Measure(editor.expandTabs(line[:index], 0)).Width
+}
+
+// indexForX returns the rune index within the line closest to the horizontal coordinate.
+func (editor *CodeEditor) indexForX(line []rune, x float64, advance float64) int {
+	x -= editor.textLeft(advance)
+	var column int
+	if editor.Theme.Font.Monospaced() {
+		column = int(math.Floor(x/advance + 0.5))
+	} else {
+		// Each column of the expanded text is a single rune
+		column = editor.Theme.Font.IndexForPosition(x, editor.expandTabs(line, 0))
+	}
+	return editor.indexForColumn(line, column)
+}
+
+// visualColumn returns the column at which the rune index within the line is drawn.
+func (editor *CodeEditor) visualColumn(line []rune, index int) int {
+	column := 0
+	for _, r := range line[:index] {
+		if r == '\t' {
+			column += editor.tabWidth - column%editor.tabWidth
+		} else {
+			column++
+		}
+	}
+	return column
+}
+
+// indexForColumn returns the rune index within the line closest to the visual column.
+func (editor *CodeEditor) indexForColumn(line []rune, column int) int {
+	current := 0
+	for i, r := range line {
+		next := current + 1
+		if r == '\t' {
+			next = current + editor.tabWidth - current%editor.tabWidth
+		}
+		if column < next {
+			if column-current > next-column {
+				return i + 1
+			}
+			return i
+		}
+		current = next
+	}
+	return len(line)
+}
+
+// expandTabs returns the runes with tabs replaced by spaces, given the starting visual column.
+func (editor *CodeEditor) expandTabs(runes []rune, column int) string {
+	var buffer strings.Builder
+	for _, r := range runes {
+		if r == '\t' {
+			count := editor.tabWidth - column%editor.tabWidth
+			buffer.WriteString(strings.Repeat(" ", count))
+			column += count
+		} else {
+			buffer.WriteRune(r)
+			column++
+		}
+	}
+	return buffer.String()
+}
+
+// measureLines grows the recorded width of the widest line to cover the specified lines.
+func (editor *CodeEditor) measureLines(first, last int) {
+	for line := first; line <= last; line++ {
+		runes := editor.buffer.Line(line)
+		if width := editor.visualColumn(runes, len(runes)); width > editor.widest {
+			editor.widest = width
+		}
+	}
+}
+
+// contentChanged checks for a change in the number of lines or the width of the widest one.
+func (editor *CodeEditor) contentChanged() {
+	lines := editor.buffer.LineCount()
+	if lines != editor.sizedLines || editor.widest != editor.sizedWidest {
+		editor.sizeChanged()
+	}
+}
+
+func (editor *CodeEditor) sizeChanged() {
+	editor.sizedLines = editor.buffer.LineCount()
+	editor.sizedWidest = editor.widest
+	var w ui.Widget = editor
+	for w != nil {
+		w.SetNeedLayout(true)
+		w = w.Parent()
+	}
+	editor.Repaint()
+}
+
+// lineState returns the lexer's state at the start of the line.
+func (editor *CodeEditor) lineState(line int) int {
+	for len(editor.states) <= line {
+		last := len(editor.states) - 1
+		_, state := editor.lexer.Lex(editor.buffer.Line(last), editor.states[last])
+		editor.states = append(editor.states, state)
+	}
+	return editor.states[line]
+}
+
+// tokens returns the highlighted tokens of the line.
+func (editor *CodeEditor) tokens(line int, runes []rune) []Token {
+	if editor.lexer == nil {
+		return nil
+	}
+	tokens, _ := editor.lexer.Lex(runes, editor.lineState(line))
+	return tokens
+}
+
+// invalidateStates discards the lexer states that may have been affected by a change to the line.
+func (editor *CodeEditor) invalidateStates(line int) {
+	if len(editor.states) > line+1 {
+		editor.states = editor.states[:line+1]
+	}
+}
+
+func (editor *CodeEditor) paint(evt event.Event) {
+	e, ok := evt.(*event.Paint)
+	if !ok {
+		return
+	}
+	gc := e.GC()
+	dirty := e.DirtyRect()
+	bounds := editor.LocalInsetBounds()
+	advance := editor.advance()
+	lineHeight := editor.lineHeight()
+	first := editor.lineAt(dirty.Y)
+	last := editor.lineAt(dirty.Y + dirty.Height)
+	primary := editor.primaryCaret()
+	primaryLine := editor.buffer.LineOf(primary.pos)
+	focused := editor.Focused()
+	var matches []int
+	if !primary.hasRange() {
+		matches = editor.matchingBrackets(primary.pos)
+	}
+	font := editor.Theme.Font
+	ascent := (lineHeight - font.Height()) / 2
+	for line := first; line <= last; line++ {
+		y := bounds.Y + float64(line)*lineHeight
+		runes := editor.buffer.Line(line)
+		lineStart := editor.buffer.LineStart(line)
+		lineEnd := lineStart + len(runes)
+		if line == primaryLine && !primary.hasRange() {
+			gc.SetColor(editor.Theme.CurrentLineBackground)
+			gc.FillRect(geom.Rect{Point: geom.Point{X: dirty.X, Y: y}, Size: geom.Size{Width: dirty.Width, Height: lineHeight}})
+		}
+		editor.paintSearchHighlights(gc, runes, lineStart, y, advance)
+		for _, c := range editor.carets {
+			if c.hasRange() && c.start() <= lineEnd && c.end() >= lineStart {
+				left := editor.xForIndex(runes, xmath.MaxInt(c.start()-lineStart, 0), advance)
+				right := editor.xForIndex(runes, c.end()-lineStart, advance)
+				if c.end() > lineEnd {
+					// Show that the newline is included
+					right += advance / 2
+				}
+				if focused {
+					gc.SetColor(color.SelectedTextBackground)
+				} else {
+					gc.SetColor(color.SelectedTextBackground.SetAlphaIntensity(0.4))
+				}
+				gc.FillRect(geom.Rect{Point: geom.Point{X: left, Y: y}, Size: geom.Size{Width: right - left, Height: lineHeight}})
+			}
+		}
+		editor.paintLine(gc, line, runes, y+ascent, advance)
+		for _, match := range matches {
+			if match >= lineStart && match < lineEnd {
+				left := editor.xForIndex(runes, match-lineStart, advance)
+				right := editor.xForIndex(runes, match-lineStart+1, advance)
+				gc.SetColor(editor.Theme.BracketMatch)
+				gc.SetStrokeWidth(1)
+				gc.StrokeRect(geom.Rect{Point: geom.Point{X: left + 0.5, Y: y + 0.5}, Size: geom.Size{Width: right - left - 1, Height: lineHeight - 1}})
+			}
+		}
+		if focused && editor.showCursor {
+			for _, c := range editor.carets {
+				if c.pos >= lineStart && c.pos <= lineEnd {
+					x := math.Floor(editor.xForIndex(runes, c.pos-lineStart, advance)) + 0.5
+					gc.SetColor(editor.cursorColor())
+					gc.SetStrokeWidth(1)
+					gc.StrokeLine(x, y, x, y+lineHeight-1)
+				}
+			}
+		}
+	}
+	if focused {
+		editor.scheduleBlink()
+	}
+	editor.paintGutter(gc, dirty, first, last, primaryLine, advance)
+}
+
+// paintLine draws the text of a line with its syntax highlighting.
+func (editor *CodeEditor) paintLine(gc *draw.Graphics, line int, runes []rune, y, advance float64) {
+	font := editor.Theme.Font
+	pos := 0
+	emit := func(end int, kind TokenKind) {
+		if end > pos {
+			gc.SetColor(editor.Theme.TokenColor(kind))
+			gc.DrawString(editor.xForIndex(runes, pos, advance), y, editor.expandTabs(runes[pos:end], editor.visualColumn(runes, pos)), font)
+			pos = end
+		}
+	}
+	for _, token := range editor.tokens(line, runes) {
+		start := clampInt(token.Start, pos, len(runes))
+		emit(start, Plain)
+		emit(clampInt(token.End, pos, len(runes)), token.Kind)
+	}
+	emit(len(runes), Plain)
+}
+
+func (editor *CodeEditor) paintGutter(gc *draw.Graphics, dirty geom.Rect, first, last, primaryLine int, advance float64) {
+	bounds := editor.LocalInsetBounds()
+	lineHeight := editor.lineHeight()
+	width := editor.gutterWidth(advance)
+	left := editor.gutterLeft()
+	gc.SetColor(editor.Theme.GutterBackground)
+	gc.FillRect(geom.Rect{Point: geom.Point{X: left, Y: dirty.Y}, Size: geom.Size{Width: width, Height: dirty.Height}})
+	gc.SetColor(editor.Theme.GutterBackground.AdjustBrightness(-0.1))
+	gc.StrokeLine(left+width-0.5, dirty.Y, left+width-0.5, dirty.Y+dirty.Height)
+	font := editor.Theme.Font
+	ascent := (lineHeight - font.Height()) / 2
+	for line := first; line <= last; line++ {
+		text := strconv.Itoa(line + 1)
+		if line == primaryLine {
+			gc.SetColor(editor.Theme.GutterCurrentLine)
+		} else {
+			gc.SetColor(editor.Theme.GutterText)
+		}
+		x := left + width - editor.Theme.Padding - font.Measure(text).Width
+		gc.DrawString(x, bounds.Y+float64(line)*lineHeight+ascent, text, font)
+	}
+}
+
+func (editor *CodeEditor) cursorColor() color.Color {
+	if editor.Background().Luminance() > 0.6 {
+		return color.TextWhenLight
+	}
+	return color.TextWhenDark
+}
+
+// matchingBrackets returns the offsets of the bracket next to 'pos' and of the one matching it, if
+// there are both.
+func (editor *CodeEditor) matchingBrackets(pos int) []int {
+	for _, offset := range []int{pos - 1, pos} {
+		if strings.ContainsRune(brackets, editor.buffer.RuneAt(offset)) {
+			if match := editor.findMatch(offset); match != -1 {
+				return []int{offset, match}
+			}
+		}
+	}
+	return nil
+}
+
+// findMatch returns the offset of the bracket matching the one at 'offset', or -1. Brackets within
+// strings and comments are ignored.
+func (editor *CodeEditor) findMatch(offset int) int {
+	r := editor.buffer.RuneAt(offset)
+	which := strings.IndexRune(brackets, r)
+	if which == -1 {
+		return -1
+	}
+	partner := rune(brackets[which^1])
+	forward := which%2 == 0
+	line := editor.buffer.LineOf(offset)
+	lineStart := editor.buffer.LineStart(line)
+	runes := editor.buffer.Line(line)
+	code := editor.codeMask(line, runes)
+	if !code[offset-lineStart] {
+		return -1
+	}
+	depth := 0
+	i := offset - lineStart
+	for scanned := 0; scanned < BracketScanLimit; scanned++ {
+		if forward {
+			for ; i < len(runes); i++ {
+				if code[i] {
+					if runes[i] == r {
+						depth++
+					} else if runes[i] == partner {
+						if depth--; depth == 0 {
+							return lineStart + i
+						}
+					}
+				}
+			}
+			if line++; line >= editor.buffer.LineCount() {
+				break
+			}
+			i = 0
+		} else {
+			for ; i >= 0; i-- {
+				if code[i] {
+					if runes[i] == r {
+						depth++
+					} else if runes[i] == partner {
+						if depth--; depth == 0 {
+							return lineStart + i
+						}
+					}
+				}
+			}
+			if line--; line < 0 {
+				break
+			}
+		}
+		runes = editor.buffer.Line(line)
+		lineStart = editor.buffer.LineStart(line)
+		code = editor.codeMask(line, runes)
+		if !forward {
+			i = len(runes) - 1
+		}
+	}
+	return -1
+}
+
+// codeMask returns, for each rune of the line, whether it is outside of strings and comments.
+func (editor *CodeEditor) codeMask(line int, runes []rune) []bool {
+	mask := make([]bool, len(runes))
+	for i := range mask {
+		mask[i] = true
+	}
+	for _, token := range editor.tokens(line, runes) {
+		if token.Kind == String || token.Kind == Comment {
+			for i := clampInt(token.Start, 0, len(runes)); i < token.End && i < len(runes); i++ {
+				mask[i] = false
+			}
+		}
+	}
+	return mask
+}
+
+func (editor *CodeEditor) scheduleBlink() {
+	wnd := editor.Window()
+	if editor.Theme.BlinkRate <= 0 {
+		editor.showCursor = true
+	} else if wnd.Valid() && !editor.pending && editor.Focused() {
+		editor.pending = true
+		wnd.InvokeAfter(editor.blink, editor.Theme.BlinkRate)
+	}
+}
+
+func (editor *CodeEditor) blink() {
+	if editor.Window().Valid() {
+		editor.pending = false
+		if time.Now().After(editor.forceShowUntil) {
+			editor.showCursor = !editor.showCursor
+			editor.Repaint()
+		}
+		editor.scheduleBlink()
+	}
+}
+
+func (editor *CodeEditor) resetBlink() {
+	editor.forceShowUntil = time.Now().Add(editor.Theme.BlinkRate)
+}
+
+func (editor *CodeEditor) focusChanged(evt event.Event) {
+	editor.showCursor = true
+	editor.Repaint()
+}
+
+func (editor *CodeEditor) themeChanged(evt event.Event) {
+	editor.SetBackground(color.TextBackground)
+	editor.sizeChanged()
+}
+
+// offsetAt returns the offset closest to the point, which is in local coordinates.
+func (editor *CodeEditor) offsetAt(where geom.Point) int {
+	line := editor.lineAt(where.Y)
+	return editor.buffer.LineStart(line) + editor.indexForX(editor.buffer.Line(line), where.X, editor.advance())
+}
+
+func (editor *CodeEditor) overGutter(where geom.Point) bool {
+	return where.X < editor.gutterLeft()+editor.gutterWidth(editor.advance())
+}
+
+func (editor *CodeEditor) mouseDown(evt event.Event) {
+	editor.Window().SetFocus(editor)
+	e, ok := evt.(*event.MouseDown)
+	if !ok || e.Button() != button.Left {
+		return
+	}
+	where := editor.FromWindow(e.Where())
+	editor.extendByWord = false
+	if editor.overGutter(where) {
+		line := editor.lineAt(where.Y)
+		editor.dragAnchor = editor.buffer.LineStart(line)
+		editor.setCaret(editor.buffer.LineStart(line+1), editor.dragAnchor)
+		return
+	}
+	offset := editor.offsetAt(where)
+	switch e.Clicks() {
+	case 2:
+		start, end := editor.wordAt(offset)
+		editor.setCaret(end, start)
+		editor.dragAnchor = start
+		editor.extendByWord = true
+	case 3:
+		line := editor.buffer.LineOf(offset)
+		editor.setCaret(editor.buffer.LineStart(line+1), editor.buffer.LineStart(line))
+	default:
+		mods := e.Modifiers()
+		switch {
+		case mods.OptionDown():
+			if !editor.removeCaretAt(offset) {
+				editor.addCaret(offset, offset)
+			}
+			editor.dragAnchor = offset
+		case mods.ShiftDown():
+			editor.dragAnchor = editor.primaryCaret().anchor
+			editor.setCaret(offset, editor.dragAnchor)
+		default:
+			editor.dragAnchor = offset
+			editor.setCaret(offset, offset)
+		}
+	}
+}
+
+func (editor *CodeEditor) mouseDragged(evt event.Event) {
+	where := editor.FromWindow(evt.(*event.MouseDragged).Where())
+	offset := editor.offsetAt(where)
+	anchor := editor.dragAnchor
+	if editor.extendByWord {
+		start, end := editor.wordAt(offset)
+		if offset < anchor {
+			offset = start
+			_, anchor = editor.wordAt(anchor)
+		} else {
+			offset = end
+		}
+	}
+	c := editor.primaryCaret()
+	c.pos = offset
+	c.anchor = anchor
+	c.column = -1
+	editor.caretsMoved()
+}
+
+func (editor *CodeEditor) contextMenu(evt event.Event) {
+	editmenu.AppendContextMenuItems(menu.ForContextMenu(evt.(*event.ContextMenu)))
+}
+
+func (editor *CodeEditor) setCursor(evt event.Event) {
+	c := cursor.Text
+	if editor.overGutter(editor.FromWindow(evt.(*event.UpdateCursor).Where())) {
+		c = cursor.Arrow
+	}
+	editor.Window().SetCursor(c)
+	evt.Finish()
+}
+
+// wordAt returns the start and end offsets of the word containing 'offset'.
+func (editor *CodeEditor) wordAt(offset int) (start, end int) {
+	start = offset
+	end = offset
+	for start > 0 && isWordRune(editor.buffer.RuneAt(start-1)) {
+		start--
+	}
+	length := editor.buffer.Len()
+	for end < length && isWordRune(editor.buffer.RuneAt(end)) {
+		end++
+	}
+	return start, end
+}
+
+func (editor *CodeEditor) keyDown(evt event.Event) {
+	window.HideCursorUntilMouseMoves()
+	e, ok := evt.(*event.KeyDown)
+	if !ok {
+		return
+	}
+	mods := e.Modifiers()
+	extend := mods.ShiftDown()
+	switch e.Code() {
+	case keys.VirtualKeyReturn, keys.VirtualKeyNumPadEnter:
+		editor.newline()
+	case keys.VirtualKeyBackspace:
+		editor.backspace()
+	case keys.VirtualKeyDelete, keys.VirtualKeyNumPadDelete:
+		editor.deleteForward()
+	case keys.VirtualKeyTab:
+		if mods&(keys.NonStickyModifiers&^keys.ShiftModifier) != 0 {
+			return
+		}
+		if extend {
+			editor.indentLines(-1)
+		} else {
+			editor.tab()
+		}
+		// Keep the window from moving the focus
+		e.Discard()
+	case keys.VirtualKeyEscape:
+		if len(editor.carets) < 2 {
+			return
+		}
+		c := *editor.primaryCaret()
+		editor.setCaret(c.pos, c.anchor)
+	case keys.VirtualKeyLeft, keys.VirtualKeyNumPadLeft:
+		switch {
+		case mods.CommandDown():
+			editor.moveCarets(extend, false, editor.smartLineStart)
+		case mods.OptionDown():
+			editor.moveCarets(extend, false, editor.previousWord)
+		default:
+			editor.moveCarets(extend, false, func(c *caret) int {
+				if c.hasRange() && !extend {
+					return c.start()
+				}
+				return c.pos - 1
+			})
+		}
+	case keys.VirtualKeyRight, keys.VirtualKeyNumPadRight:
+		switch {
+		case mods.CommandDown():
+			editor.moveCarets(extend, false, func(c *caret) int { return editor.buffer.LineEnd(editor.buffer.LineOf(c.pos)) })
+		case mods.OptionDown():
+			editor.moveCarets(extend, false, editor.nextWord)
+		default:
+			editor.moveCarets(extend, false, func(c *caret) int {
+				if c.hasRange() && !extend {
+					return c.end()
+				}
+				return c.pos + 1
+			})
+		}
+	case keys.VirtualKeyUp, keys.VirtualKeyNumPadUp:
+		if mods.CommandDown() && mods.OptionDown() {
+			editor.addCaretVertically(-1)
+		} else {
+			editor.moveCarets(extend, true, func(c *caret) int { return editor.vertical(c, -1) })
+		}
+	case keys.VirtualKeyDown, keys.VirtualKeyNumPadDown:
+		if mods.CommandDown() && mods.OptionDown() {
+			editor.addCaretVertically(1)
+		} else {
+			editor.moveCarets(extend, true, func(c *caret) int { return editor.vertical(c, 1) })
+		}
+	case keys.VirtualKeyPageUp, keys.VirtualKeyNumPadPageUp:
+		lines := editor.pageLines()
+		editor.moveCarets(extend, true, func(c *caret) int { return editor.vertical(c, -lines) })
+	case keys.VirtualKeyPageDown, keys.VirtualKeyNumPadPageDown:
+		lines := editor.pageLines()
+		editor.moveCarets(extend, true, func(c *caret) int { return editor.vertical(c, lines) })
+	case keys.VirtualKeyHome, keys.VirtualKeyNumPadHome:
+		if mods.CommandDown() {
+			editor.moveCarets(extend, false, func(c *caret) int { return 0 })
+		} else {
+			editor.moveCarets(extend, false, editor.smartLineStart)
+		}
+	case keys.VirtualKeyEnd, keys.VirtualKeyNumPadEnd:
+		if mods.CommandDown() {
+			editor.moveCarets(extend, false, func(c *caret) int { return editor.buffer.Len() })
+		} else {
+			editor.moveCarets(extend, false, func(c *caret) int { return editor.buffer.LineEnd(editor.buffer.LineOf(c.pos)) })
+		}
+	default:
+		r := e.Rune()
+		if unicode.IsControl(r) {
+			return
+		}
+		editor.typed(r)
+	}
+	evt.Finish()
+}
+
+// smartLineStart returns the offset of the first non-blank rune of the caret's line, or of the
+// start of the line if the caret is already there.
+func (editor *CodeEditor) smartLineStart(c *caret) int {
+	line := editor.buffer.LineOf(c.pos)
+	start := editor.buffer.LineStart(line)
+	indent := start + len(leadingSpace(editor.buffer.Line(line)))
+	if c.pos == indent {
+		return start
+	}
+	return indent
+}
+
+func (editor *CodeEditor) previousWord(c *caret) int {
+	pos := c.pos
+	for pos > 0 && !isWordRune(editor.buffer.RuneAt(pos-1)) {
+		pos--
+	}
+	for pos > 0 && isWordRune(editor.buffer.RuneAt(pos-1)) {
+		pos--
+	}
+	return pos
+}
+
+func (editor *CodeEditor) nextWord(c *caret) int {
+	pos := c.pos
+	length := editor.buffer.Len()
+	for pos < length && !isWordRune(editor.buffer.RuneAt(pos)) {
+		pos++
+	}
+	for pos < length && isWordRune(editor.buffer.RuneAt(pos)) {
+		pos++
+	}
+	return pos
+}
+
+// vertical returns the offset 'delta' lines from the caret, aiming for the caret's visual column.
+func (editor *CodeEditor) vertical(c *caret, delta int) int {
+	line := editor.buffer.LineOf(c.pos)
+	if c.column < 0 {
+		c.column = editor.visualColumn(editor.buffer.Line(line), c.pos-editor.buffer.LineStart(line))
+	}
+	target := line + delta
+	if target < 0 {
+		return 0
+	}
+	if target >= editor.buffer.LineCount() {
+		return editor.buffer.Len()
+	}
+	return editor.buffer.LineStart(target) + editor.indexForColumn(editor.buffer.Line(target), c.column)
+}
+
+// addCaretVertically adds a caret on the line above or below the primary one.
+func (editor *CodeEditor) addCaretVertically(delta int) {
+	primary := *editor.primaryCaret()
+	line := editor.buffer.LineOf(primary.pos) + delta
+	if line < 0 || line >= editor.buffer.LineCount() {
+		return
+	}
+	pos := editor.vertical(&primary, delta)
+	editor.addCaret(pos, pos)
+	editor.primaryCaret().column = primary.column
+}
+
+func (editor *CodeEditor) pageLines() int {
+	return xmath.MaxInt(int(editor.PageScrollAmount(false, false)/editor.lineHeight()), 1)
+}
@@ -0,0 +1,334 @@
+package codeeditor
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/ui/clipboard"
+	"github.com/richardwilkes/ui/clipboard/datatypes"
+	"github.com/richardwilkes/ui/event"
+)
+
+// edit describes the replacement of a span of the buffer on behalf of a caret.
+type edit struct {
+	start  int    // The offset of the start of the span being replaced.
+	end    int    // The offset of the end of the span being replaced.
+	text   []rune // The replacement text.
+	anchor int    // The caret's new anchor, relative to start.
+	pos    int    // The caret's new position, relative to start.
+}
+
+// replacement returns an edit replacing the caret's selection with 'text', leaving the caret after
+// it.
+func replacement(c caret, text []rune) edit {
+	return edit{start: c.start(), end: c.end(), text: text, anchor: len(text), pos: len(text)}
+}
+
+// perform applies the edit returned by 'makeEdit' for each caret, if any. Each caret is passed to
+// 'makeEdit' with its offsets adjusted for the edits already made on behalf of the carets before
+// it. The edits are recorded in the history together. Returns true if a modification was made.
+func (editor *CodeEditor) perform(makeEdit func(c caret) (edit, bool)) bool {
+	shift := 0
+	firstLine := -1
+	before := append([]caret(nil), editor.carets...)
+	var changes []change
+	for i := range editor.carets {
+		c := &editor.carets[i]
+		c.pos += shift
+		c.anchor += shift
+		ed, ok := makeEdit(*c)
+		if !ok {
+			continue
+		}
+		line := editor.buffer.LineOf(ed.start)
+		if firstLine == -1 || line < firstLine {
+			firstLine = line
+		}
+		changes = append(changes, change{start: ed.start, removed: editor.buffer.Slice(ed.start, ed.end), inserted: append([]rune(nil), ed.text...)})
+		editor.buffer.Delete(ed.start, ed.end)
+		editor.buffer.Insert(ed.start, ed.text)
+		editor.measureLines(line, editor.buffer.LineOf(ed.start+len(ed.text)))
+		c.anchor = ed.start + ed.anchor
+		c.pos = ed.start + ed.pos
+		c.column = -1
+		shift += len(ed.text) - (ed.end - ed.start)
+	}
+	if firstLine == -1 {
+		return false
+	}
+	editor.invalidateStates(firstLine)
+	editor.contentChanged()
+	editor.caretsMoved()
+	editor.record(changes, before)
+	event.Dispatch(event.NewModified(editor))
+	return true
+}
+
+// ReplaceSelections replaces the selection of each cursor with 'text'.
+func (editor *CodeEditor) ReplaceSelections(text string) {
+	runes := []rune(sanitize(text))
+	editor.perform(func(c caret) (edit, bool) {
+		return replacement(c, runes), len(runes) != 0 || c.hasRange()
+	})
+}
+
+// typed inserts a rune typed by the user at each caret.
+func (editor *CodeEditor) typed(r rune) {
+	closing := editor.autoIndent && strings.ContainsRune(")]}", r)
+	editor.typing = true
+	editor.perform(func(c caret) (edit, bool) {
+		if closing && !c.hasRange() {
+			// A closing bracket typed into the indentation of a line removes a level of it
+			line := editor.buffer.LineOf(c.pos)
+			start := editor.buffer.LineStart(line)
+			before := editor.buffer.Slice(start, c.pos)
+			if len(before) != 0 && len(leadingSpace(before)) == len(before) {
+				text := append(editor.outdented(before), r)
+				return edit{start: start, end: c.pos, text: text, anchor: len(text), pos: len(text)}, true
+			}
+		}
+		return replacement(c, []rune{r}), true
+	})
+	editor.typing = false
+}
+
+// newline breaks the line at each caret, indenting the new line if automatic indentation is on.
+func (editor *CodeEditor) newline() {
+	editor.perform(func(c caret) (edit, bool) {
+		text := []rune{'\n'}
+		if !editor.autoIndent {
+			return replacement(c, text), true
+		}
+		line := editor.buffer.LineOf(c.start())
+		start := editor.buffer.LineStart(line)
+		before := editor.buffer.Slice(start, c.start())
+		indent := leadingSpace(before)
+		text = append(text, indent...)
+		trimmed := strings.TrimRight(string(before), " \t")
+		if trimmed == "" || !strings.ContainsRune("([{", rune(trimmed[len(trimmed)-1])) {
+			return replacement(c, text), true
+		}
+		text = append(text, editor.indentUnit()...)
+		pos := len(text)
+		if strings.ContainsRune(")]}", editor.buffer.RuneAt(c.end())) {
+			// Put the closing bracket on its own line, after the one the caret is left on
+			text = append(append(text, '\n'), indent...)
+		}
+		return edit{start: c.start(), end: c.end(), text: text, anchor: pos, pos: pos}, true
+	})
+}
+
+// backspace removes each caret's selection or the rune before it. Within leading spaces, when
+// indentation is made of spaces, it removes them back to the previous tab stop.
+func (editor *CodeEditor) backspace() {
+	editor.perform(func(c caret) (edit, bool) {
+		if c.hasRange() {
+			return replacement(c, nil), true
+		}
+		if c.pos == 0 {
+			return edit{}, false
+		}
+		count := 1
+		if editor.insertSpaces {
+			start := editor.buffer.LineStart(editor.buffer.LineOf(c.pos))
+			before := editor.buffer.Slice(start, c.pos)
+			if len(before) != 0 && strings.Trim(string(before), " ") == "" {
+				if count = len(before) % editor.tabWidth; count == 0 {
+					count = editor.tabWidth
+				}
+			}
+		}
+		return edit{start: c.pos - count, end: c.pos}, true
+	})
+}
+
+// deleteForward removes each caret's selection or the rune after it.
+func (editor *CodeEditor) deleteForward() {
+	length := editor.buffer.Len()
+	editor.perform(func(c caret) (edit, bool) {
+		if c.hasRange() {
+			return replacement(c, nil), true
+		}
+		if c.pos >= length {
+			return edit{}, false
+		}
+		return edit{start: c.pos, end: c.pos + 1}, true
+	})
+}
+
+// tab indents the selected lines if any selection spans lines, otherwise it inserts a tab, or the
+// spaces up to the next tab stop, at each caret.
+func (editor *CodeEditor) tab() {
+	for _, c := range editor.carets {
+		if c.hasRange() && editor.buffer.LineOf(c.start()) != editor.buffer.LineOf(c.end()) {
+			editor.indentLines(1)
+			return
+		}
+	}
+	editor.perform(func(c caret) (edit, bool) {
+		if !editor.insertSpaces {
+			return replacement(c, []rune{'\t'}), true
+		}
+		line := editor.buffer.LineOf(c.start())
+		start := editor.buffer.LineStart(line)
+		column := editor.visualColumn(editor.buffer.Slice(start, c.start()), c.start()-start)
+		return replacement(c, []rune(strings.Repeat(" ", editor.tabWidth-column%editor.tabWidth))), true
+	})
+}
+
+// indentLines adds a level of indentation to the lines spanned by each caret when 'delta' is
+// positive, or removes one when it is negative. The lines are left selected.
+func (editor *CodeEditor) indentLines(delta int) {
+	editor.perform(func(c caret) (edit, bool) {
+		first := editor.buffer.LineOf(c.start())
+		last := editor.buffer.LineOf(c.end())
+		if last > first && c.end() == editor.buffer.LineStart(last) {
+			last--
+		}
+		start := editor.buffer.LineStart(first)
+		end := editor.buffer.LineEnd(last)
+		lines := strings.Split(string(editor.buffer.Slice(start, end)), "\n")
+		for i, line := range lines {
+			if delta > 0 {
+				if line != "" {
+					lines[i] = string(editor.indentUnit()) + line
+				}
+			} else {
+				runes := []rune(line)
+				indent := leadingSpace(runes)
+				lines[i] = string(editor.outdented(indent)) + string(runes[len(indent):])
+			}
+		}
+		text := []rune(strings.Join(lines, "\n"))
+		return edit{start: start, end: end, text: text, anchor: 0, pos: len(text)}, true
+	})
+}
+
+// indentUnit returns the text making up a single level of indentation.
+func (editor *CodeEditor) indentUnit() []rune {
+	if editor.insertSpaces {
+		return []rune(strings.Repeat(" ", editor.tabWidth))
+	}
+	return []rune{'\t'}
+}
+
+// outdented returns the indentation with a level removed.
+func (editor *CodeEditor) outdented(indent []rune) []rune {
+	count := len(indent)
+	if count == 0 {
+		return nil
+	}
+	if indent[count-1] == '\t' {
+		return indent[:count-1]
+	}
+	for removed := 0; count > 0 && indent[count-1] == ' ' && removed < editor.tabWidth; removed++ {
+		count--
+	}
+	return indent[:count]
+}
+
+// leadingSpace returns the spaces and tabs at the start of the runes.
+func leadingSpace(runes []rune) []rune {
+	i := 0
+	for i < len(runes) && (runes[i] == ' ' || runes[i] == '\t') {
+		i++
+	}
+	return runes[:i:i]
+}
+
+func sanitize(text string) string {
+	return strings.Replace(text, "\r\n", "\n", -1)
+}
+
+// SelectedText returns the text selected by each cursor, separated by newlines.
+func (editor *CodeEditor) SelectedText() string {
+	var parts []string
+	for _, c := range editor.carets {
+		if c.hasRange() {
+			parts = append(parts, string(editor.buffer.Slice(c.start(), c.end())))
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// HasSelectionRange returns true if any cursor has a selection.
+func (editor *CodeEditor) HasSelectionRange() bool {
+	for _, c := range editor.carets {
+		if c.hasRange() {
+			return true
+		}
+	}
+	return false
+}
+
+// CanCut returns true if any text is selected.
+func (editor *CodeEditor) CanCut() bool {
+	return editor.HasSelectionRange()
+}
+
+// Cut the selected text to the clipboard.
+func (editor *CodeEditor) Cut() {
+	if editor.CanCut() {
+		editor.Copy()
+		editor.Delete()
+	}
+}
+
+// CanCopy returns true if any text is selected.
+func (editor *CodeEditor) CanCopy() bool {
+	return editor.HasSelectionRange()
+}
+
+// Copy the selected text to the clipboard.
+func (editor *CodeEditor) Copy() {
+	if editor.CanCopy() {
+		clipboard.SetData(datatypes.Data{MimeType: datatypes.PlainText, Bytes: []byte(editor.SelectedText())})
+	}
+}
+
+// CanPaste returns true if the clipboard has content that can be pasted into the editor.
+func (editor *CodeEditor) CanPaste() bool {
+	return clipboard.HasType(datatypes.PlainText)
+}
+
+// Paste any text on the clipboard into the editor. When there are several cursors and the text
+// has a line for each of them, each cursor receives one line.
+func (editor *CodeEditor) Paste() {
+	if !clipboard.HasType(datatypes.PlainText) {
+		return
+	}
+	text := sanitize(string(clipboard.Data(datatypes.PlainText)))
+	lines := strings.Split(text, "\n")
+	if len(editor.carets) < 2 || len(lines) != len(editor.carets) {
+		editor.ReplaceSelections(text)
+		return
+	}
+	i := 0
+	editor.perform(func(c caret) (edit, bool) {
+		line := []rune(lines[i])
+		i++
+		return replacement(c, line), true
+	})
+}
+
+// CanDelete returns true if any text is selected.
+func (editor *CodeEditor) CanDelete() bool {
+	return editor.HasSelectionRange()
+}
+
+// Delete removes the selected text.
+func (editor *CodeEditor) Delete() {
+	editor.perform(func(c caret) (edit, bool) {
+		return replacement(c, nil), c.hasRange()
+	})
+}
+
+// CanSelectAll returns true if the editor's selection can be expanded.
+func (editor *CodeEditor) CanSelectAll() bool {
+	start, end := editor.Selection()
+	return len(editor.carets) > 1 || start != 0 || end != editor.buffer.Len()
+}
+
+// SelectAll selects all of the text in the editor.
+func (editor *CodeEditor) SelectAll() {
+	editor.setCaret(editor.buffer.Len(), 0)
+}
@@ -0,0 +1,195 @@
+package codeeditor
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Possible kinds of tokens.
+const (
+	Plain TokenKind = iota
+	Keyword
+	Type
+	Number
+	String
+	Comment
+	Operator
+)
+
+// TokenKind identifies the kind of a token, which determines the color it is drawn with.
+type TokenKind int
+
+// Token identifies a span of a line that is highlighted.
+type Token struct {
+	Start int       // The rune index within the line of the start of the token.
+	End   int       // The rune index within the line just past the end of the token.
+	Kind  TokenKind // The kind of token.
+}
+
+// Lexer splits lines into tokens for syntax highlighting.
+type Lexer interface {
+	// Lex returns the tokens found in 'line', which doesn't include its newline. Runes not covered
+	// by a token are drawn as Plain. 'state' is the state at the start of the line, which is zero
+	// for the first line. The state at the end of the line is returned, allowing constructs such as
+	// block comments to span lines.
+	Lex(line []rune, state int) (tokens []Token, endState int)
+}
+
+// Possible states of a CLikeLexer at the end of a line.
+const (
+	normalState = iota
+	blockCommentState
+	multiLineStringState
+)
+
+// CLikeLexer is a Lexer for languages with C-like syntax: identifiers, numbers, quoted strings,
+// line and block comments, and punctuation for operators.
+type CLikeLexer struct {
+	Keywords          map[string]bool // Identifiers that are highlighted as keywords.
+	Types             map[string]bool // Identifiers that are highlighted as types.
+	LineComment       string          // The text that starts a comment running to the end of the line, such as "//".
+	BlockCommentStart string          // The text that starts a block comment, such as "/*".
+	BlockCommentEnd   string          // The text that ends a block comment, such as "*/".
+	Quotes            string          // The runes that delimit strings, which end with the line.
+	MultiLineQuote    rune            // The rune that delimits strings that may span lines, or zero.
+	Escape            rune            // The rune that escapes the following rune within a string, or zero.
+}
+
+// NewGoLexer creates a new lexer for Go source.
+func NewGoLexer() *CLikeLexer {
+	return &CLikeLexer{
+		Keywords:          wordSet("break case chan const continue default defer else fallthrough for func go goto if import interface map package range return select struct switch type var true false nil iota"),
+		Types:             wordSet("bool byte complex64 complex128 error float32 float64 int int8 int16 int32 int64 rune string uint uint8 uint16 uint32 uint64 uintptr"),
+		LineComment:       "//",
+		BlockCommentStart: "/*",
+		BlockCommentEnd:   "*/",
+		Quotes:            "\"'",
+		MultiLineQuote:    '`',
+		Escape:            '\\',
+	}
+}
+
+func wordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
+
+// Lex implements the Lexer interface.
+func (lexer *CLikeLexer) Lex(line []rune, state int) (tokens []Token, endState int) {
+	length := len(line)
+	i := 0
+	for i < length {
+		start := i
+		r := line[i]
+		switch {
+		case state == blockCommentState:
+			if i = indexOf(line, i, lexer.BlockCommentEnd); i == -1 {
+				return append(tokens, Token{Start: start, End: length, Kind: Comment}), state
+			}
+			i += len([]rune(lexer.BlockCommentEnd))
+			state = normalState
+			tokens = append(tokens, Token{Start: start, End: i, Kind: Comment})
+		case state == multiLineStringState:
+			for i < length && line[i] != lexer.MultiLineQuote {
+				i++
+			}
+			if i == length {
+				return append(tokens, Token{Start: start, End: length, Kind: String}), state
+			}
+			i++
+			state = normalState
+			tokens = append(tokens, Token{Start: start, End: i, Kind: String})
+		case hasPrefix(line, i, lexer.LineComment):
+			return append(tokens, Token{Start: start, End: length, Kind: Comment}), state
+		case hasPrefix(line, i, lexer.BlockCommentStart):
+			// Resume just past the start, so that the end can't overlap it
+			i += len([]rune(lexer.BlockCommentStart))
+			state = blockCommentState
+			if end := indexOf(line, i, lexer.BlockCommentEnd); end != -1 {
+				i = end + len([]rune(lexer.BlockCommentEnd))
+				state = normalState
+				tokens = append(tokens, Token{Start: start, End: i, Kind: Comment})
+			} else {
+				return append(tokens, Token{Start: start, End: length, Kind: Comment}), state
+			}
+		case lexer.MultiLineQuote != 0 && r == lexer.MultiLineQuote:
+			i++
+			for i < length && line[i] != lexer.MultiLineQuote {
+				i++
+			}
+			if i == length {
+				return append(tokens, Token{Start: start, End: length, Kind: String}), multiLineStringState
+			}
+			i++
+			tokens = append(tokens, Token{Start: start, End: i, Kind: String})
+		case strings.ContainsRune(lexer.Quotes, r):
+			i++
+			for i < length && line[i] != r {
+				if lexer.Escape != 0 && line[i] == lexer.Escape {
+					i++
+				}
+				i++
+			}
+			if i < length {
+				i++
+			} else {
+				i = length
+			}
+			tokens = append(tokens, Token{Start: start, End: i, Kind: String})
+		case unicode.IsDigit(r) || (r == '.' && i+1 < length && unicode.IsDigit(line[i+1])):
+			for i < length && (isWordRune(line[i]) || line[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, Token{Start: start, End: i, Kind: Number})
+		case isWordRune(r):
+			for i < length && isWordRune(line[i]) {
+				i++
+			}
+			word := string(line[start:i])
+			if lexer.Keywords[word] {
+				tokens = append(tokens, Token{Start: start, End: i, Kind: Keyword})
+			} else if lexer.Types[word] {
+				tokens = append(tokens, Token{Start: start, End: i, Kind: Type})
+			}
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			i++
+			tokens = append(tokens, Token{Start: start, End: i, Kind: Operator})
+		default:
+			i++
+		}
+	}
+	return tokens, state
+}
+
+func hasPrefix(line []rune, i int, prefix string) bool {
+	if prefix == "" {
+		return false
+	}
+	for _, r := range prefix {
+		if i >= len(line) || line[i] != r {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+// indexOf returns the index of the first occurrence of 'text' in 'line' at or after 'from', or -1.
+func indexOf(line []rune, from int, text string) int {
+	if text == "" {
+		return -1
+	}
+	for i := from; i < len(line); i++ {
+		if hasPrefix(line, i, text) {
+			return i
+		}
+	}
+	return -1
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,88 @@
+package codeeditor
+
+import (
+	"sort"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/draw"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/widget"
+)
+
+// SearchText implements widget.Searchable.
+func (editor *CodeEditor) SearchText() string {
+	return editor.buffer.String()
+}
+
+// SearchSelection implements widget.Searchable. Only the primary cursor's selection is considered.
+func (editor *CodeEditor) SearchSelection() widget.TextRange {
+	start, end := editor.Selection()
+	return widget.TextRange{Start: start, End: end}
+}
+
+// SetSearchSelection implements widget.Searchable.
+func (editor *CodeEditor) SetSearchSelection(selection widget.TextRange) {
+	editor.SetSelection(selection.Start, selection.End)
+}
+
+// SetSearchHighlights implements widget.Searchable.
+func (editor *CodeEditor) SetSearchHighlights(ranges []widget.TextRange) {
+	if len(ranges) != 0 || len(editor.highlights) != 0 {
+		editor.highlights = ranges
+		editor.Repaint()
+	}
+}
+
+// ReplaceRanges implements widget.Searchable. The replacements are recorded in the history as a
+// single edit. A single cursor is left after the last replacement.
+func (editor *CodeEditor) ReplaceRanges(ranges []widget.TextRange, replacements []string) bool {
+	if len(ranges) == 0 || len(ranges) != len(replacements) {
+		return false
+	}
+	length := editor.buffer.Len()
+	shift := 0
+	before := append([]caret(nil), editor.carets...)
+	changes := make([]change, 0, len(ranges))
+	// Work backwards so that the offsets of the ranges still to be replaced remain valid
+	for i := len(ranges) - 1; i >= 0; i-- {
+		start := clampInt(ranges[i].Start, 0, length)
+		end := clampInt(ranges[i].End, start, length)
+		text := []rune(sanitize(replacements[i]))
+		changes = append(changes, change{start: start, removed: editor.buffer.Slice(start, end), inserted: text})
+		editor.buffer.Delete(start, end)
+		editor.buffer.Insert(start, text)
+		shift += len(text) - (end - start)
+		length = start
+	}
+	firstLine := editor.buffer.LineOf(clampInt(ranges[0].Start, 0, editor.buffer.Len()))
+	pos := clampInt(ranges[len(ranges)-1].End+shift, 0, editor.buffer.Len())
+	editor.measureLines(firstLine, editor.buffer.LineOf(pos))
+	editor.invalidateStates(firstLine)
+	editor.highlights = nil
+	editor.contentChanged()
+	editor.setCaret(pos, pos)
+	editor.record(changes, before)
+	event.Dispatch(event.NewModified(editor))
+	return true
+}
+
+// paintSearchHighlights fills the background of the parts of the line that are search matches.
+func (editor *CodeEditor) paintSearchHighlights(gc *draw.Graphics, runes []rune, lineStart int, y, advance float64) {
+	lineEnd := lineStart + len(runes)
+	i := sort.Search(len(editor.highlights), func(i int) bool { return editor.highlights[i].End > lineStart })
+	if i == len(editor.highlights) {
+		return
+	}
+	lineHeight := editor.lineHeight()
+	gc.SetColor(editor.Theme.SearchHighlight)
+	for ; i < len(editor.highlights) && editor.highlights[i].Start <= lineEnd; i++ {
+		one := editor.highlights[i]
+		left := editor.xForIndex(runes, clampInt(one.Start-lineStart, 0, len(runes)), advance)
+		right := editor.xForIndex(runes, clampInt(one.End-lineStart, 0, len(runes)), advance)
+		if one.End > lineEnd {
+			// Show that the newline is included
+			right += advance / 2
+		}
+		gc.FillRect(geom.Rect{Point: geom.Point{X: left, Y: y}, Size: geom.Size{Width: right - left, Height: lineHeight}})
+	}
+}
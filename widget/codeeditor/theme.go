@@ -0,0 +1,100 @@
+package codeeditor
+
+import (
+	"time"
+
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/font"
+	"github.com/richardwilkes/ui/theme"
+	"github.com/richardwilkes/ui/window"
+)
+
+var (
+	// StdTheme is the theme all new CodeEditors get by default.
+	StdTheme = NewTheme()
+)
+
+func init() {
+	theme.RegisterInitializer(func() { StdTheme.Init() })
+}
+
+// Theme contains the theme elements for CodeEditors.
+type Theme struct {
+	Font                  *font.Font    // The font to use. Monospaced fonts are laid out from their metrics alone, which is much faster.
+	BlinkRate             time.Duration // The rate at which the cursor blinks. Zero or less disables blinking.
+	Padding               float64       // The space to leave around the text and the line numbers.
+	GutterBackground      color.Color   // The background color of the line number gutter.
+	GutterText            color.Color   // The color of the line numbers.
+	GutterCurrentLine     color.Color   // The color of the line number of the line holding the primary cursor.
+	CurrentLineBackground color.Color   // The background color of the line holding the primary cursor.
+	BracketMatch          color.Color   // The color used to outline a bracket and its match.
+	SearchHighlight       color.Color   // The background color of text matching a search.
+	KeywordColor          color.Color   // The color of Keyword tokens.
+	TypeColor             color.Color   // The color of Type tokens.
+	NumberColor           color.Color   // The color of Number tokens.
+	StringColor           color.Color   // The color of String tokens.
+	CommentColor          color.Color   // The color of Comment tokens.
+	OperatorColor         color.Color   // The color of Operator tokens.
+}
+
+// NewTheme creates a new CodeEditor theme.
+func NewTheme() *Theme {
+	theme := &Theme{}
+	theme.Init()
+	return theme
+}
+
+// Init initializes the theme with its default values. The syntax colors are chosen to suit the
+// active theme.
+func (theme *Theme) Init() {
+	theme.Font = font.UserMonospaced
+	theme.BlinkRate = window.CursorBlinkRate
+	theme.Padding = 4
+	theme.GutterBackground = color.Background
+	theme.GutterText = color.TextWhenDisabled
+	theme.GutterCurrentLine = color.Text
+	theme.CurrentLineBackground = color.SelectedTextBackground.SetAlphaIntensity(0.1)
+	theme.BracketMatch = color.KeyboardFocus
+	theme.SearchHighlight = color.Yellow.SetAlphaIntensity(0.5)
+	if darkTheme() {
+		theme.KeywordColor = color.RGB(204, 120, 50)
+		theme.TypeColor = color.RGB(86, 156, 214)
+		theme.NumberColor = color.RGB(181, 206, 168)
+		theme.StringColor = color.RGB(206, 145, 120)
+		theme.CommentColor = color.RGB(106, 153, 85)
+	} else {
+		theme.KeywordColor = color.RGB(127, 0, 85)
+		theme.TypeColor = color.RGB(0, 80, 160)
+		theme.NumberColor = color.RGB(0, 128, 128)
+		theme.StringColor = color.RGB(42, 0, 255)
+		theme.CommentColor = color.RGB(63, 127, 95)
+	}
+	theme.OperatorColor = color.Text
+}
+
+// darkTheme returns true if the active theme uses light text on a dark background, which the
+// syntax colors must contrast with.
+func darkTheme() bool {
+	current := theme.Current()
+	return current != nil && current.Dark()
+}
+
+// TokenColor returns the color to draw tokens of the specified kind with.
+func (theme *Theme) TokenColor(kind TokenKind) color.Color {
+	switch kind {
+	case Keyword:
+		return theme.KeywordColor
+	case Type:
+		return theme.TypeColor
+	case Number:
+		return theme.NumberColor
+	case String:
+		return theme.StringColor
+	case Comment:
+		return theme.CommentColor
+	case Operator:
+		return theme.OperatorColor
+	default:
+		return color.Text
+	}
+}
@@ -0,0 +1,120 @@
+package codeeditor
+
+import (
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/undo"
+)
+
+// change records the replacement of 'removed' at 'start' by 'inserted', with 'start' relative to
+// the content as it was just before the change was made.
+type change struct {
+	start    int
+	removed  []rune
+	inserted []rune
+}
+
+// textEdit records the changes made together, such as on behalf of every caret, along with the
+// carets before and after them.
+type textEdit struct {
+	editor  *CodeEditor
+	changes []change
+	before  []caret
+	after   []caret
+	typing  bool
+}
+
+// Undo implements undo.Edit.
+func (edit *textEdit) Undo() {
+	first := edit.editor.buffer.Len()
+	last := 0
+	for i := len(edit.changes) - 1; i >= 0; i-- {
+		ch := edit.changes[i]
+		edit.editor.buffer.Delete(ch.start, ch.start+len(ch.inserted))
+		edit.editor.buffer.Insert(ch.start, ch.removed)
+		first, last = spanWith(first, last, ch.start, ch.start+len(ch.removed))
+	}
+	edit.editor.restore(first, last, edit.before)
+}
+
+// Redo implements undo.Edit.
+func (edit *textEdit) Redo() {
+	first := edit.editor.buffer.Len()
+	last := 0
+	for _, ch := range edit.changes {
+		edit.editor.buffer.Delete(ch.start, ch.start+len(ch.removed))
+		edit.editor.buffer.Insert(ch.start, ch.inserted)
+		first, last = spanWith(first, last, ch.start, ch.start+len(ch.inserted))
+	}
+	edit.editor.restore(first, last, edit.after)
+}
+
+// Merge implements undo.Merger, so that characters typed one after another with a single cursor are
+// undone together.
+func (edit *textEdit) Merge(next undo.Edit) bool {
+	other, ok := next.(*textEdit)
+	if !ok || !edit.typing || !other.typing || len(edit.changes) != 1 || len(other.changes) != 1 {
+		return false
+	}
+	ch := &edit.changes[0]
+	if len(other.changes[0].removed) != 0 || other.changes[0].start != ch.start+len(ch.inserted) {
+		return false
+	}
+	ch.inserted = append(ch.inserted, other.changes[0].inserted...)
+	edit.after = other.after
+	return true
+}
+
+// spanWith returns the span from 'first' to 'last' grown to cover 'start' to 'end'.
+func spanWith(first, last, start, end int) (newFirst, newLast int) {
+	if start < first {
+		first = start
+	}
+	if end > last {
+		last = end
+	}
+	return first, last
+}
+
+// record adds the changes, which were made with the carets as they were in 'before', to the
+// history as a single edit.
+func (editor *CodeEditor) record(changes []change, before []caret) {
+	editor.history.Add(&textEdit{
+		editor:  editor,
+		changes: changes,
+		before:  before,
+		after:   append([]caret(nil), editor.carets...),
+		typing:  editor.typing,
+	})
+}
+
+// restore tidies up after an undo or redo changed the content between the offsets 'first' and
+// 'last', putting the carets back as they were.
+func (editor *CodeEditor) restore(first, last int, carets []caret) {
+	firstLine := editor.buffer.LineOf(first)
+	editor.measureLines(firstLine, editor.buffer.LineOf(last))
+	editor.invalidateStates(firstLine)
+	editor.contentChanged()
+	editor.carets = append(editor.carets[:0], carets...)
+	editor.caretsMoved()
+	event.Dispatch(event.NewModified(editor))
+}
+
+// CanUndo returns true if there is an edit to undo.
+func (editor *CodeEditor) CanUndo() bool {
+	return editor.history.CanUndo()
+}
+
+// Undo reverts the most recent edit to the editor's content.
+func (editor *CodeEditor) Undo() {
+	editor.history.Undo()
+}
+
+// CanRedo returns true if there is an undone edit to make again.
+func (editor *CodeEditor) CanRedo() bool {
+	return editor.history.CanRedo()
+}
+
+// Redo makes the most recently undone edit to the editor's content again.
+func (editor *CodeEditor) Redo() {
+	editor.history.Redo()
+}
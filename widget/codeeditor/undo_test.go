@@ -0,0 +1,51 @@
+package codeeditor
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/ui/widget"
+)
+
+func checkText(t *testing.T, editor *CodeEditor, step, expected string) {
+	t.Helper()
+	if text := editor.Text(); text != expected {
+		t.Fatalf("%s: Text() = %q, expected %q", step, text, expected)
+	}
+}
+
+func TestReplaceAllUndoesAsOneStep(t *testing.T) {
+	editor := New()
+	editor.SetText("one two\none two one")
+	ranges := []widget.TextRange{{Start: 0, End: 3}, {Start: 8, End: 11}, {Start: 16, End: 19}}
+	if !editor.ReplaceRanges(ranges, []string{"1", "1", "1"}) {
+		t.Fatal("ReplaceRanges() made no modification")
+	}
+	checkText(t, editor, "replace", "1 two\n1 two 1")
+	editor.Undo()
+	checkText(t, editor, "undo", "one two\none two one")
+	if editor.CanUndo() {
+		t.Fatal("the replacements were recorded as more than one edit")
+	}
+	editor.Redo()
+	checkText(t, editor, "redo", "1 two\n1 two 1")
+	if editor.CanRedo() {
+		t.Fatal("the replacements were redone as more than one edit")
+	}
+}
+
+func TestTypingMergesUntilTheCaretMoves(t *testing.T) {
+	editor := New()
+	for _, r := range "ab" {
+		editor.typed(r)
+	}
+	editor.setCaret(0, 0)
+	editor.typed('c')
+	checkText(t, editor, "type", "cab")
+	editor.Undo()
+	checkText(t, editor, "first undo", "ab")
+	editor.Undo()
+	checkText(t, editor, "second undo", "")
+	if editor.CanUndo() {
+		t.Fatal("the typing before the caret moved was recorded as more than one edit")
+	}
+}
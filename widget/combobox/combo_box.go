@@ -0,0 +1,404 @@
+package combobox
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/border"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/draw/align"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/font"
+	"github.com/richardwilkes/ui/keys"
+	"github.com/richardwilkes/ui/layout/flex"
+	"github.com/richardwilkes/ui/widget"
+	"github.com/richardwilkes/ui/widget/label"
+	"github.com/richardwilkes/ui/widget/list"
+	"github.com/richardwilkes/ui/widget/scrollarea"
+	"github.com/richardwilkes/ui/widget/textfield"
+	"github.com/richardwilkes/ui/window"
+)
+
+// ComboBox provides a text field the user may either type into or fill in by choosing from a
+// drop-down list of items. As the user types, the text is completed from the first matching item
+// and the drop-down list is narrowed to the matching items.
+type ComboBox struct {
+	widget.Block
+	Theme    *Theme // The theme the combo box will use to draw itself.
+	field    *textfield.TextField
+	arrow    *widget.Block
+	static   staticProvider
+	provider Provider
+	popup    *window.Window
+	list     *list.List
+	scroller *scrollarea.ScrollArea
+	shown    []interface{}
+	request  int
+	deleting bool
+	updating bool
+}
+
+// New creates a new, empty, combo box.
+func New() *ComboBox {
+	cb := &ComboBox{Theme: StdTheme}
+	cb.provider = &cb.static
+	cb.InitTypeAndID(cb)
+	cb.Describer = func() string { return fmt.Sprintf("ComboBox #%d", cb.ID()) }
+	lay := flex.NewLayout(cb)
+	lay.Columns = 2
+	lay.HSpacing = 0
+	cb.field = textfield.New()
+	flexData := flex.NewData()
+	flexData.HGrab = true
+	flexData.HAlign = align.Fill
+	cb.field.SetLayoutData(flexData)
+	cb.AddChild(cb.field)
+	handlers := cb.field.EventHandlers()
+	handlers.Add(event.ModifiedType, cb.textChanged)
+	// The field consumes the navigation keys, so intercept the ones the drop-down list needs first
+	handlers.Prepend(event.KeyDownType, cb.keyDown)
+	cb.arrow = widget.NewBlock()
+	flexData = flex.NewData()
+	flexData.VAlign = align.Fill
+	flexData.SizeHint.Width = cb.Theme.ArrowWidth
+	cb.arrow.SetLayoutData(flexData)
+	handlers = cb.arrow.EventHandlers()
+	handlers.Add(event.PaintType, cb.paintArrow)
+	handlers.Add(event.MouseDownType, cb.arrowPressed)
+	cb.AddChild(cb.arrow)
+	return cb
+}
+
+// Text returns the content of the combo box.
+func (cb *ComboBox) Text() string {
+	return cb.field.Text()
+}
+
+// SetText sets the content of the combo box. Returns true if a modification was made.
+func (cb *ComboBox) SetText(text string) bool {
+	cb.updating = true
+	defer func() { cb.updating = false }()
+	return cb.field.SetText(text)
+}
+
+// Watermark returns the text shown when the combo box is empty.
+func (cb *ComboBox) Watermark() string {
+	return cb.field.Watermark()
+}
+
+// SetWatermark sets the text shown when the combo box is empty.
+func (cb *ComboBox) SetWatermark(text string) {
+	cb.field.SetWatermark(text)
+}
+
+// Items returns the items set by SetItems().
+func (cb *ComboBox) Items() []interface{} {
+	return append([]interface{}(nil), cb.static.items...)
+}
+
+// SetItems sets the items offered by the combo box. The items that contain the typed text,
+// ignoring case, are shown in the drop-down list. Any Provider previously set is discarded.
+func (cb *ComboBox) SetItems(items ...interface{}) {
+	cb.static.items = append([]interface{}(nil), items...)
+	cb.provider = &cb.static
+}
+
+// Provider returns the provider of the items offered by the combo box.
+func (cb *ComboBox) Provider() Provider {
+	return cb.provider
+}
+
+// SetProvider sets the provider of the items offered by the combo box. It will be asked for items
+// only when they are needed. Passing nil reverts to offering the items set by SetItems().
+func (cb *ComboBox) SetProvider(provider Provider) {
+	if provider == nil {
+		provider = &cb.static
+	}
+	cb.provider = provider
+}
+
+// PopupShown returns true if the drop-down list is being shown.
+func (cb *ComboBox) PopupShown() bool {
+	return cb.popup != nil
+}
+
+// ShowPopup shows the drop-down list of the items matching the current text.
+func (cb *ComboBox) ShowPopup() {
+	cb.fetch(cb.field.Text(), false, true)
+}
+
+// ClosePopup closes the drop-down list, if it is being shown.
+func (cb *ComboBox) ClosePopup() {
+	cb.closePopup(true)
+}
+
+func (cb *ComboBox) textChanged(evt event.Event) {
+	event.Dispatch(event.NewModified(cb))
+	if !cb.updating {
+		cb.fetch(cb.field.Text(), !cb.deleting, cb.field.Text() != "")
+	}
+}
+
+// fetch asks the provider for the items matching 'text'. When they arrive, the text is completed
+// from them if 'complete' is true, and the drop-down list is shown if 'show' is true or it is
+// already being shown.
+func (cb *ComboBox) fetch(text string, complete, show bool) {
+	cb.request++
+	request := cb.request
+	cb.provider.Items(text, func(items []interface{}) {
+		if request != cb.request || cb.field.Text() != text {
+			return
+		}
+		if complete {
+			cb.complete(text, items)
+		}
+		if show || cb.popup != nil {
+			cb.setShown(items)
+		}
+	})
+}
+
+// complete appends the remainder of the first item that starts with 'text' to the field, selecting
+// it so that typing further replaces it.
+func (cb *ComboBox) complete(text string, items []interface{}) {
+	start, end := cb.field.Selection()
+	typed := []rune(text)
+	if len(typed) == 0 || start != end || end != len(typed) {
+		return
+	}
+	lower := strings.ToLower(text)
+	for _, item := range items {
+		candidate := []rune(fmt.Sprint(item))
+		if len(candidate) > len(typed) && strings.ToLower(string(candidate[:len(typed)])) == lower {
+			cb.updating = true
+			cb.field.SetText(text + string(candidate[len(typed):]))
+			cb.updating = false
+			cb.field.SetSelection(len(typed), len(candidate))
+			return
+		}
+	}
+}
+
+func (cb *ComboBox) setShown(items []interface{}) {
+	cb.shown = items
+	if len(items) == 0 {
+		cb.closePopup(true)
+		return
+	}
+	if cb.popup == nil {
+		if cb.openPopup(); cb.popup == nil {
+			return
+		}
+	}
+	cb.list.SetDataSource(itemSource(items))
+	cb.sizePopup()
+}
+
+func (cb *ComboBox) openPopup() {
+	owner := cb.Window()
+	if owner == nil {
+		return
+	}
+	cb.list = list.New(&label.CellFactory{Height: cb.cellHeight()})
+	cb.list.EventHandlers().Add(event.MouseUpType, func(evt event.Event) { cb.choose(cb.list.Selection.FirstSet()) })
+	// The popup takes the keyboard focus, so pass on any keys the drop-down list doesn't need
+	cb.list.EventHandlers().Prepend(event.KeyDownType, cb.forwardKey)
+	cb.scroller = scrollarea.New(cb.list, scrollarea.Fill)
+	cb.scroller.SetBorder(nil)
+	cb.popup = window.NewPopupWindow(owner, cb.popupLocation(), geom.Size{Width: cb.Size().Width, Height: 100})
+	content := cb.popup.Content()
+	content.SetBackground(color.TextBackground)
+	content.SetBorder(border.NewLine(color.MenuBorder, geom.NewUniformInsets(1)))
+	lay := flex.NewLayout(content)
+	lay.HAlign = align.Fill
+	lay.VAlign = align.Fill
+	flexData := flex.NewData()
+	flexData.HGrab = true
+	flexData.VGrab = true
+	flexData.HAlign = align.Fill
+	flexData.VAlign = align.Fill
+	cb.scroller.SetLayoutData(flexData)
+	content.AddChild(cb.scroller)
+	popup := cb.popup
+	popup.EventHandlers().Add(event.FocusLostType, func(evt event.Event) {
+		if cb.popup == popup {
+			cb.closePopup(false)
+		}
+	})
+	popup.ToFront()
+}
+
+func (cb *ComboBox) sizePopup() {
+	rows := len(cb.shown)
+	if rows > cb.Theme.VisibleItems {
+		rows = cb.Theme.VisibleItems
+	}
+	frame := cb.popup.ContentFrame()
+	frame.Point = cb.popupLocation()
+	frame.Width = cb.Size().Width
+	frame.Height = float64(rows)*cb.cellHeight() + 2
+	cb.popup.SetContentFrame(frame)
+	cb.scroller.SetNeedLayout(true)
+	cb.popup.Content().SetNeedLayout(true)
+	cb.popup.Content().ValidateLayout()
+	cb.scroller.SetScrolledPosition(false, 0)
+	cb.popup.Content().Repaint()
+}
+
+// popupLocation returns the location, in display coordinates, of the top-left corner of the
+// drop-down list.
+func (cb *ComboBox) popupLocation() geom.Point {
+	frame := cb.Window().ContentFrame()
+	pt := cb.ToWindow(geom.Point{Y: cb.Size().Height})
+	return geom.Point{X: frame.X + pt.X, Y: frame.Y + pt.Y}
+}
+
+func (cb *ComboBox) cellHeight() float64 {
+	return math.Ceil(font.Views.Height()) + 2
+}
+
+// closePopup closes the drop-down list. If 'refocus' is true, the window holding the combo box is
+// brought back to the front, since the drop-down list took the keyboard focus from it.
+func (cb *ComboBox) closePopup(refocus bool) {
+	if cb.popup != nil {
+		popup := cb.popup
+		cb.popup = nil
+		cb.list = nil
+		cb.scroller = nil
+		cb.shown = nil
+		popup.Close()
+		if owner := cb.Window(); refocus && owner != nil {
+			owner.ToFront()
+		}
+	}
+}
+
+// choose fills in the field with the item at 'index' in the drop-down list and closes it.
+func (cb *ComboBox) choose(index int) {
+	if index < 0 || index >= len(cb.shown) {
+		return
+	}
+	text := fmt.Sprint(cb.shown[index])
+	cb.closePopup(true)
+	cb.SetText(text)
+	cb.field.SetSelectionToEnd()
+	event.Dispatch(event.NewSelection(cb))
+}
+
+func (cb *ComboBox) keyDown(evt event.Event) {
+	if e, ok := evt.(*event.KeyDown); ok {
+		code := e.Code()
+		cb.deleting = code == keys.VirtualKeyBackspace || code == keys.VirtualKeyDelete || code == keys.VirtualKeyNumPadDelete
+		if cb.handleKey(code) {
+			evt.Finish()
+		}
+	}
+}
+
+func (cb *ComboBox) forwardKey(evt event.Event) {
+	if e, ok := evt.(*event.KeyDown); ok {
+		evt.Finish()
+		if !cb.handleKey(e.Code()) {
+			event.Dispatch(event.NewKeyDown(cb.field, e.Code(), e.Rune(), e.Modifiers(), e.Repeat()))
+		}
+	}
+}
+
+// handleKey performs the drop-down list navigation for the key. Returns true if the key was used.
+func (cb *ComboBox) handleKey(code int) bool {
+	switch code {
+	case keys.VirtualKeyDown, keys.VirtualKeyNumPadDown:
+		if cb.popup == nil {
+			cb.ShowPopup()
+		} else {
+			cb.moveSelection(1)
+		}
+	case keys.VirtualKeyUp, keys.VirtualKeyNumPadUp:
+		if cb.popup == nil {
+			return false
+		}
+		cb.moveSelection(-1)
+	case keys.VirtualKeyPageDown, keys.VirtualKeyNumPadPageDown:
+		if cb.popup == nil {
+			return false
+		}
+		cb.moveSelection(cb.Theme.VisibleItems)
+	case keys.VirtualKeyPageUp, keys.VirtualKeyNumPadPageUp:
+		if cb.popup == nil {
+			return false
+		}
+		cb.moveSelection(-cb.Theme.VisibleItems)
+	case keys.VirtualKeyReturn, keys.VirtualKeyNumPadEnter:
+		if cb.popup == nil || cb.list.Selection.Count() == 0 {
+			return false
+		}
+		cb.choose(cb.list.Selection.FirstSet())
+	case keys.VirtualKeyEscape:
+		if cb.popup == nil {
+			return false
+		}
+		cb.closePopup(true)
+	default:
+		return false
+	}
+	return true
+}
+
+func (cb *ComboBox) moveSelection(delta int) {
+	count := len(cb.shown)
+	if count == 0 {
+		return
+	}
+	index := cb.list.Selection.FirstSet()
+	if index == -1 {
+		if delta > 0 {
+			index = 0
+		} else {
+			index = count - 1
+		}
+	} else {
+		index += delta
+	}
+	if index < 0 {
+		index = 0
+	} else if index >= count {
+		index = count - 1
+	}
+	cb.list.Select(false, index)
+	cb.list.ScrollRowIntoView(index)
+}
+
+func (cb *ComboBox) paintArrow(evt event.Event) {
+	bounds := cb.arrow.LocalInsetBounds()
+	size := math.Min(bounds.Width, bounds.Height) / 2
+	x := bounds.X + (bounds.Width-size)/2
+	y := bounds.Y + (bounds.Height-size/2)/2
+	gc := evt.(*event.Paint).GC()
+	gc.BeginPath()
+	gc.MoveTo(x, y)
+	gc.LineTo(x+size, y)
+	gc.LineTo(x+size/2, y+size/2)
+	gc.ClosePath()
+	if cb.Enabled() {
+		gc.SetColor(color.Text)
+	} else {
+		gc.SetColor(color.TextWhenDisabled)
+	}
+	gc.FillPath()
+}
+
+func (cb *ComboBox) arrowPressed(evt event.Event) {
+	if cb.Enabled() {
+		if cb.popup != nil {
+			cb.closePopup(true)
+		} else {
+			if wnd := cb.Window(); wnd != nil {
+				wnd.SetFocus(cb.field)
+			}
+			cb.ShowPopup()
+		}
+	}
+	evt.(*event.MouseDown).Discard()
+}
@@ -0,0 +1,54 @@
+package combobox
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Provider supplies the items a ComboBox offers for the text that has been typed into it.
+type Provider interface {
+	// Items is called to obtain the items matching 'text'. They must be passed to 'done', which
+	// may happen after Items has returned, such as once a lookup performed in the background has
+	// completed, provided it is called on the UI thread. Items passed to 'done' after the text has
+	// changed again are ignored.
+	Items(text string, done func(items []interface{}))
+}
+
+// ProviderFunc adapts a function to the Provider interface.
+type ProviderFunc func(text string, done func(items []interface{}))
+
+// Items implements the Provider interface.
+func (f ProviderFunc) Items(text string, done func(items []interface{})) {
+	f(text, done)
+}
+
+// staticProvider offers the items that contain the text, ignoring case.
+type staticProvider struct {
+	items []interface{}
+}
+
+func (p *staticProvider) Items(text string, done func(items []interface{})) {
+	text = strings.ToLower(text)
+	if text == "" {
+		done(p.items)
+		return
+	}
+	var matches []interface{}
+	for _, item := range p.items {
+		if strings.Contains(strings.ToLower(fmt.Sprint(item)), text) {
+			matches = append(matches, item)
+		}
+	}
+	done(matches)
+}
+
+// itemSource presents the items in the drop-down list.
+type itemSource []interface{}
+
+func (s itemSource) RowCount() int {
+	return len(s)
+}
+
+func (s itemSource) Row(index int) interface{} {
+	return s[index]
+}
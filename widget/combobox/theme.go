@@ -0,0 +1,33 @@
+package combobox
+
+import (
+	"github.com/richardwilkes/ui/theme"
+)
+
+var (
+	// StdTheme is the theme all new ComboBoxes get by default.
+	StdTheme = NewTheme()
+)
+
+func init() {
+	theme.RegisterInitializer(func() { StdTheme.Init() })
+}
+
+// Theme contains the theme elements for ComboBoxes.
+type Theme struct {
+	VisibleItems int     // The maximum number of items visible in the drop-down list at one time.
+	ArrowWidth   float64 // The width of the button that shows the drop-down list.
+}
+
+// NewTheme creates a new ComboBox theme.
+func NewTheme() *Theme {
+	theme := &Theme{}
+	theme.Init()
+	return theme
+}
+
+// Init initializes the theme with its default values.
+func (theme *Theme) Init() {
+	theme.VisibleItems = 8
+	theme.ArrowWidth = 16
+}
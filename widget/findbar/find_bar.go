@@ -0,0 +1,343 @@
+package findbar
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/border"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/draw/align"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/keys"
+	"github.com/richardwilkes/ui/layout/flex"
+	"github.com/richardwilkes/ui/widget"
+	"github.com/richardwilkes/ui/widget/button"
+	"github.com/richardwilkes/ui/widget/checkbox"
+	"github.com/richardwilkes/ui/widget/label"
+	"github.com/richardwilkes/ui/widget/textfield"
+)
+
+// FindBar provides controls for finding, and optionally replacing, text within a widget that
+// implements widget.Searchable. While the bar is part of a window, every match is highlighted in
+// its target. The bar is usually shown and hidden by a Panel, but may be placed anywhere.
+type FindBar struct {
+	widget.Block
+	target       widget.Searchable
+	modified     event.Handler // Added to the target, and removed from it by the same value.
+	findRow      *widget.Block
+	replaceRow   *widget.Block
+	findField    *textfield.TextField
+	replaceField *textfield.TextField
+	caseBox      *checkbox.CheckBox
+	wordBox      *checkbox.CheckBox
+	regexBox     *checkbox.CheckBox
+	status       *label.Label
+}
+
+// New creates a new find bar for the target, which may be nil.
+func New(target widget.Searchable) *FindBar {
+	bar := &FindBar{}
+	bar.modified = func(evt event.Event) { bar.Refresh() }
+	bar.InitTypeAndID(bar)
+	bar.Describer = func() string { return fmt.Sprintf("FindBar #%d", bar.ID()) }
+	bar.SetBackground(color.Background)
+	bar.SetBorder(border.NewCompound(border.NewLine(color.Divider, geom.Insets{Bottom: 1}), border.NewEmpty(geom.NewUniformInsets(4))))
+	lay := flex.NewLayout(bar)
+	lay.VSpacing = 4
+
+	bar.findField = textfield.New()
+	bar.findField.SetWatermark(i18n.Text("Find"))
+	bar.findField.EventHandlers().Add(event.ModifiedType, bar.patternModified)
+	bar.findField.EventHandlers().Prepend(event.KeyDownType, bar.findKeyDown)
+	bar.caseBox = bar.newOption(i18n.Text("Match Case"))
+	bar.wordBox = bar.newOption(i18n.Text("Whole Word"))
+	bar.regexBox = bar.newOption(i18n.Text("Regular Expression"))
+	bar.status = label.New("")
+	bar.findRow = newRow(bar.findField, bar.caseBox, bar.wordBox, bar.regexBox,
+		newButton(i18n.Text("Previous"), func(evt event.Event) { bar.FindPrevious() }),
+		newButton(i18n.Text("Next"), func(evt event.Event) { bar.FindNext() }),
+		bar.status,
+		newButton(i18n.Text("Done"), func(evt event.Event) { bar.Close() }))
+	bar.AddChild(bar.findRow)
+
+	bar.replaceField = textfield.New()
+	bar.replaceField.SetWatermark(i18n.Text("Replace"))
+	bar.replaceField.EventHandlers().Prepend(event.KeyDownType, bar.replaceKeyDown)
+	bar.replaceRow = newRow(bar.replaceField,
+		newButton(i18n.Text("Replace"), func(evt event.Event) { bar.Replace() }),
+		newButton(i18n.Text("Replace All"), func(evt event.Event) { bar.ReplaceAll() }))
+
+	bar.SetTarget(target)
+	return bar
+}
+
+// newRow creates a block laying out the widgets in a single row, with the first one taking any
+// excess space.
+func newRow(children ...ui.Widget) *widget.Block {
+	row := widget.NewBlock()
+	lay := flex.NewLayout(row)
+	lay.Columns = len(children)
+	for i, child := range children {
+		flexData := flex.NewData()
+		if i == 0 {
+			flexData.HGrab = true
+			flexData.HAlign = align.Fill
+		}
+		child.SetLayoutData(flexData)
+		row.AddChild(child)
+	}
+	flexData := flex.NewData()
+	flexData.HGrab = true
+	flexData.HAlign = align.Fill
+	row.SetLayoutData(flexData)
+	return row
+}
+
+func newButton(title string, handler event.Handler) *button.Button {
+	b := button.New(title)
+	b.EventHandlers().Add(event.ClickType, handler)
+	return b
+}
+
+func (bar *FindBar) newOption(title string) *checkbox.CheckBox {
+	box := checkbox.NewCheckBox(title)
+	box.EventHandlers().Add(event.ClickType, bar.patternModified)
+	return box
+}
+
+// Target returns the widget being searched.
+func (bar *FindBar) Target() widget.Searchable {
+	return bar.target
+}
+
+// SetTarget sets the widget to be searched, which may be nil.
+func (bar *FindBar) SetTarget(target widget.Searchable) {
+	if bar.target != target {
+		if bar.target != nil {
+			bar.target.SetSearchHighlights(nil)
+			bar.target.EventHandlers().Remove(event.ModifiedType, bar.modified)
+		}
+		bar.target = target
+		if target != nil {
+			target.EventHandlers().Add(event.ModifiedType, bar.modified)
+		}
+		bar.Refresh()
+	}
+}
+
+// FindText returns the text being searched for.
+func (bar *FindBar) FindText() string {
+	return bar.findField.Text()
+}
+
+// SetFindText sets the text being searched for.
+func (bar *FindBar) SetFindText(text string) {
+	bar.findField.SetText(text)
+}
+
+// ReplaceText returns the text matches are replaced with. When searching with a regular
+// expression, it may refer to submatches with $1, ${name} and so on.
+func (bar *FindBar) ReplaceText() string {
+	return bar.replaceField.Text()
+}
+
+// SetReplaceText sets the text matches are replaced with.
+func (bar *FindBar) SetReplaceText(text string) {
+	bar.replaceField.SetText(text)
+}
+
+// CaseSensitive returns true if matches must have the same case as the text being searched for.
+func (bar *FindBar) CaseSensitive() bool {
+	return bar.caseBox.State() == checkbox.Checked
+}
+
+// SetCaseSensitive sets whether matches must have the same case as the text being searched for.
+func (bar *FindBar) SetCaseSensitive(caseSensitive bool) {
+	bar.setOption(bar.caseBox, caseSensitive)
+}
+
+// WholeWord returns true if matches must not be part of a larger word.
+func (bar *FindBar) WholeWord() bool {
+	return bar.wordBox.State() == checkbox.Checked
+}
+
+// SetWholeWord sets whether matches must not be part of a larger word.
+func (bar *FindBar) SetWholeWord(wholeWord bool) {
+	bar.setOption(bar.wordBox, wholeWord)
+}
+
+// Regex returns true if the text being searched for is a regular expression.
+func (bar *FindBar) Regex() bool {
+	return bar.regexBox.State() == checkbox.Checked
+}
+
+// SetRegex sets whether the text being searched for is a regular expression.
+func (bar *FindBar) SetRegex(regex bool) {
+	bar.setOption(bar.regexBox, regex)
+}
+
+func (bar *FindBar) setOption(box *checkbox.CheckBox, on bool) {
+	state := checkbox.Unchecked
+	if on {
+		state = checkbox.Checked
+	}
+	if box.State() != state {
+		box.SetState(state)
+		bar.Refresh()
+	}
+}
+
+// ReplaceVisible returns true if the replacement controls are showing.
+func (bar *FindBar) ReplaceVisible() bool {
+	return bar.replaceRow.Parent() != nil
+}
+
+// SetReplaceVisible sets whether the replacement controls are showing.
+func (bar *FindBar) SetReplaceVisible(visible bool) {
+	if visible != bar.ReplaceVisible() {
+		if visible {
+			bar.AddChild(bar.replaceRow)
+		} else {
+			bar.replaceRow.RemoveFromParent()
+		}
+		bar.relayout()
+	}
+}
+
+// Activate gives the keyboard focus to the field holding the text being searched for, selecting
+// its content.
+func (bar *FindBar) Activate() {
+	if wnd := bar.Window(); wnd != nil {
+		wnd.SetFocus(bar.findField)
+		bar.findField.SelectAll()
+	}
+}
+
+// Close removes the bar from its parent, clearing the highlights from the target and returning
+// the keyboard focus to it.
+func (bar *FindBar) Close() {
+	parent := bar.Parent()
+	if parent == nil {
+		return
+	}
+	wnd := bar.Window()
+	bar.RemoveFromParent()
+	relayout(parent)
+	if bar.target != nil {
+		bar.target.SetSearchHighlights(nil)
+		if wnd != nil {
+			wnd.SetFocus(bar.target)
+		}
+	}
+}
+
+func (bar *FindBar) relayout() {
+	var w ui.Widget = bar
+	if parent := bar.Parent(); parent != nil {
+		w = parent
+	}
+	relayout(w)
+}
+
+// relayout marks the widget and its ancestors as needing layout.
+func relayout(w ui.Widget) {
+	for target := w; target != nil; target = target.Parent() {
+		target.SetNeedLayout(true)
+	}
+	w.Repaint()
+}
+
+func (bar *FindBar) findKeyDown(evt event.Event) {
+	e := evt.(*event.KeyDown)
+	switch e.Code() {
+	case keys.VirtualKeyReturn, keys.VirtualKeyNumPadEnter:
+		if e.Modifiers().ShiftDown() {
+			bar.FindPrevious()
+		} else {
+			bar.FindNext()
+		}
+		evt.Finish()
+	case keys.VirtualKeyEscape:
+		bar.Close()
+		evt.Finish()
+	}
+}
+
+func (bar *FindBar) replaceKeyDown(evt event.Event) {
+	switch evt.(*event.KeyDown).Code() {
+	case keys.VirtualKeyReturn, keys.VirtualKeyNumPadEnter:
+		bar.Replace()
+		evt.Finish()
+	case keys.VirtualKeyEscape:
+		bar.Close()
+		evt.Finish()
+	}
+}
+
+// patternModified searches afresh when the pattern or its options change, selecting the first
+// match at or after the start of the target's selection.
+func (bar *FindBar) patternModified(evt event.Event) {
+	if bar.target == nil {
+		return
+	}
+	if matches := bar.refresh(); len(matches) != 0 {
+		bar.selectMatch(matches, matches[nextIndex(matches, bar.target.SearchSelection().Start)].TextRange)
+	}
+}
+
+// Refresh searches the target again, updating the highlighted matches and the status. Nothing is
+// done while the bar isn't part of a window, since there is nothing to show.
+func (bar *FindBar) Refresh() {
+	bar.refresh()
+}
+
+func (bar *FindBar) refresh() []match {
+	if bar.Parent() == nil {
+		return nil
+	}
+	if bar.target == nil {
+		bar.setStatus("")
+		return nil
+	}
+	matches, _, err := bar.search()
+	var ranges []widget.TextRange
+	if len(matches) != 0 {
+		ranges = make([]widget.TextRange, len(matches))
+		for i, m := range matches {
+			ranges[i] = m.TextRange
+		}
+	}
+	bar.target.SetSearchHighlights(ranges)
+	switch {
+	case err != nil:
+		bar.setStatus(i18n.Text("Invalid pattern"))
+	case bar.FindText() == "":
+		bar.setStatus("")
+	case len(matches) == 0:
+		bar.setStatus(i18n.Text("No matches"))
+	default:
+		bar.updateStatus(matches)
+	}
+	return matches
+}
+
+// updateStatus reports the position of the selected match, or the number of matches if the
+// selection isn't one of them.
+func (bar *FindBar) updateStatus(matches []match) {
+	if i := indexOfRange(matches, bar.target.SearchSelection()); i != -1 {
+		bar.setStatus(fmt.Sprintf(i18n.Text("%d of %d"), i+1, len(matches)))
+	} else if len(matches) == 1 {
+		bar.setStatus(i18n.Text("1 match"))
+	} else {
+		bar.setStatus(fmt.Sprintf(i18n.Text("%d matches"), len(matches)))
+	}
+}
+
+func (bar *FindBar) setStatus(text string) {
+	if bar.status.Text() != text {
+		bar.status.SetText(text)
+		relayout(bar.status)
+	}
+}
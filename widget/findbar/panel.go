@@ -0,0 +1,109 @@
+package findbar
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/draw/align"
+	"github.com/richardwilkes/ui/layout/flex"
+	"github.com/richardwilkes/ui/widget"
+)
+
+// Panel holds content containing a searchable widget, showing a FindBar above the content on
+// request. It implements editmenu.Findable, so the Edit menu's find items work whenever the
+// keyboard focus is within it.
+type Panel struct {
+	widget.Block
+	bar *FindBar
+}
+
+// NewPanel creates a new panel holding 'content', which is usually 'target' or a ScrollArea
+// containing it. The content's layout data is replaced so that it fills the panel.
+func NewPanel(target widget.Searchable, content ui.Widget) *Panel {
+	panel := &Panel{bar: New(target)}
+	panel.InitTypeAndID(panel)
+	panel.Describer = func() string { return fmt.Sprintf("FindBar Panel #%d", panel.ID()) }
+	lay := flex.NewLayout(panel)
+	lay.VSpacing = 0
+	flexData := flex.NewData()
+	flexData.HGrab = true
+	flexData.HAlign = align.Fill
+	panel.bar.SetLayoutData(flexData)
+	flexData = flex.NewData()
+	flexData.HGrab = true
+	flexData.HAlign = align.Fill
+	flexData.VGrab = true
+	flexData.VAlign = align.Fill
+	content.SetLayoutData(flexData)
+	panel.AddChild(content)
+	return panel
+}
+
+// FindBar returns the panel's find bar.
+func (panel *Panel) FindBar() *FindBar {
+	return panel.bar
+}
+
+// FindBarVisible returns true if the find bar is showing.
+func (panel *Panel) FindBarVisible() bool {
+	return panel.bar.Parent() != nil
+}
+
+// ShowFindBar shows the find bar, with its replacement controls if 'replace' is true, and gives
+// it the keyboard focus. If the target has a selection within a single line, it becomes the text
+// being searched for.
+func (panel *Panel) ShowFindBar(replace bool) {
+	if target := panel.bar.Target(); target != nil {
+		if sel := target.SearchSelection(); sel.Start != sel.End {
+			if text := []rune(target.SearchText()); sel.End <= len(text) {
+				if selected := string(text[sel.Start:sel.End]); !strings.Contains(selected, "\n") {
+					panel.bar.SetRegex(false)
+					panel.bar.SetFindText(selected)
+				}
+			}
+		}
+	}
+	panel.bar.SetReplaceVisible(replace)
+	if !panel.FindBarVisible() {
+		panel.AddChildAtIndex(panel.bar, 0)
+		relayout(panel)
+		panel.bar.Refresh()
+	}
+	panel.bar.Activate()
+}
+
+// HideFindBar hides the find bar, returning the keyboard focus to the target.
+func (panel *Panel) HideFindBar() {
+	panel.bar.Close()
+}
+
+// CanFind implements editmenu.Findable.
+func (panel *Panel) CanFind() bool {
+	return panel.bar.Target() != nil
+}
+
+// Find implements editmenu.Findable.
+func (panel *Panel) Find() {
+	panel.ShowFindBar(panel.FindBarVisible() && panel.bar.ReplaceVisible())
+}
+
+// CanFindNext implements editmenu.Findable.
+func (panel *Panel) CanFindNext() bool {
+	return panel.bar.CanFindNext()
+}
+
+// FindNext implements editmenu.Findable.
+func (panel *Panel) FindNext() {
+	panel.bar.FindNext()
+}
+
+// CanReplace implements editmenu.Findable.
+func (panel *Panel) CanReplace() bool {
+	return panel.bar.Target() != nil && panel.bar.Target().Enabled()
+}
+
+// Replace implements editmenu.Findable.
+func (panel *Panel) Replace() {
+	panel.ShowFindBar(true)
+}
@@ -0,0 +1,183 @@
+package findbar
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/ui/widget"
+)
+
+// match is a span of the target's text that matches the pattern.
+type match struct {
+	widget.TextRange
+	submatches []int // The byte offsets of the match and its submatches, as returned by the regexp package.
+}
+
+// pattern returns the regular expression for the text being searched for and the current
+// options, or nil if there is nothing to search for.
+func (bar *FindBar) pattern() (*regexp.Regexp, error) {
+	expr := bar.FindText()
+	if expr == "" {
+		return nil, nil
+	}
+	flags := "(?m"
+	if !bar.CaseSensitive() {
+		flags += "i"
+	}
+	if !bar.Regex() {
+		expr = regexp.QuoteMeta(expr)
+	}
+	re, err := regexp.Compile(flags + ")" + expr)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return re, nil
+}
+
+// search returns the matches within the target's text, along with the text that was searched.
+func (bar *FindBar) search() (matches []match, text string, err error) {
+	var re *regexp.Regexp
+	if re, err = bar.pattern(); re == nil || bar.target == nil {
+		return nil, "", err
+	}
+	text = bar.target.SearchText()
+	wholeWord := bar.WholeWord()
+	pos := 0
+	offset := 0
+	for _, loc := range re.FindAllStringSubmatchIndex(text, -1) {
+		if loc[0] == loc[1] || (wholeWord && !isWholeWord(text, loc[0], loc[1])) {
+			continue
+		}
+		offset += utf8.RuneCountInString(text[pos:loc[0]])
+		start := offset
+		offset += utf8.RuneCountInString(text[loc[0]:loc[1]])
+		pos = loc[1]
+		matches = append(matches, match{TextRange: widget.TextRange{Start: start, End: offset}, submatches: loc})
+	}
+	return matches, text, nil
+}
+
+// isWholeWord returns true if the span of the text between the byte offsets is not adjoined by
+// any letters, digits or underscores.
+func isWholeWord(text string, start, end int) bool {
+	if r, _ := utf8.DecodeLastRuneInString(text[:start]); start > 0 && isWordRune(r) {
+		return false
+	}
+	if r, _ := utf8.DecodeRuneInString(text[end:]); end < len(text) && isWordRune(r) {
+		return false
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// replacement returns the text to replace the match with. When searching with a regular
+// expression, references to submatches are expanded.
+func (bar *FindBar) replacement(re *regexp.Regexp, text string, m match) string {
+	if !bar.Regex() {
+		return bar.ReplaceText()
+	}
+	return string(re.ExpandString(nil, bar.ReplaceText(), text, m.submatches))
+}
+
+// indexOfRange returns the index of the match covering exactly the range, or -1.
+func indexOfRange(matches []match, r widget.TextRange) int {
+	i := sort.Search(len(matches), func(i int) bool { return matches[i].Start >= r.Start })
+	if i < len(matches) && matches[i].TextRange == r {
+		return i
+	}
+	return -1
+}
+
+// nextIndex returns the index of the first match starting at or after 'offset', wrapping around
+// to the first match if there is none.
+func nextIndex(matches []match, offset int) int {
+	i := sort.Search(len(matches), func(i int) bool { return matches[i].Start >= offset })
+	if i == len(matches) {
+		i = 0
+	}
+	return i
+}
+
+// previousIndex returns the index of the last match starting before 'offset', wrapping around to
+// the last match if there is none.
+func previousIndex(matches []match, offset int) int {
+	i := sort.Search(len(matches), func(i int) bool { return matches[i].Start >= offset }) - 1
+	if i < 0 {
+		i = len(matches) - 1
+	}
+	return i
+}
+
+func (bar *FindBar) selectMatch(matches []match, r widget.TextRange) {
+	bar.target.SetSearchSelection(r)
+	bar.updateStatus(matches)
+}
+
+// CanFindNext returns true if there is text to search for and a target to search.
+func (bar *FindBar) CanFindNext() bool {
+	return bar.target != nil && bar.FindText() != ""
+}
+
+// FindNext selects the first match after the target's selection, wrapping around to the start if
+// necessary.
+func (bar *FindBar) FindNext() {
+	if matches, _, err := bar.search(); err == nil && len(matches) != 0 {
+		bar.selectMatch(matches, matches[nextIndex(matches, bar.target.SearchSelection().End)].TextRange)
+	}
+}
+
+// FindPrevious selects the last match before the target's selection, wrapping around to the end
+// if necessary.
+func (bar *FindBar) FindPrevious() {
+	if matches, _, err := bar.search(); err == nil && len(matches) != 0 {
+		bar.selectMatch(matches, matches[previousIndex(matches, bar.target.SearchSelection().Start)].TextRange)
+	}
+}
+
+// Replace replaces the target's selection if it is a match and selects the next match. If the
+// selection isn't a match, the next match is selected instead.
+func (bar *FindBar) Replace() {
+	matches, text, err := bar.search()
+	if err != nil || len(matches) == 0 {
+		return
+	}
+	i := indexOfRange(matches, bar.target.SearchSelection())
+	if i == -1 {
+		bar.FindNext()
+		return
+	}
+	re, _ := bar.pattern()
+	if bar.target.ReplaceRanges([]widget.TextRange{matches[i].TextRange}, []string{bar.replacement(re, text, matches[i])}) {
+		bar.FindNext()
+	}
+}
+
+// ReplaceAll replaces every match as a single edit of the target, which a single Undo reverts.
+func (bar *FindBar) ReplaceAll() {
+	matches, text, err := bar.search()
+	if err != nil || len(matches) == 0 {
+		return
+	}
+	re, _ := bar.pattern()
+	ranges := make([]widget.TextRange, len(matches))
+	replacements := make([]string, len(matches))
+	for i, m := range matches {
+		ranges[i] = m.TextRange
+		replacements[i] = bar.replacement(re, text, m)
+	}
+	if bar.target.ReplaceRanges(ranges, replacements) {
+		if len(matches) == 1 {
+			bar.setStatus(i18n.Text("Replaced 1 match"))
+		} else {
+			bar.setStatus(fmt.Sprintf(i18n.Text("Replaced %d matches"), len(matches)))
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package imagebutton
 
 import (
+	"github.com/richardwilkes/ui/theme"
 	"github.com/richardwilkes/ui/widget/button"
 )
 
@@ -9,6 +10,10 @@ var (
 	StdImageButton = NewTheme()
 )
 
+func init() {
+	theme.RegisterInitializer(func() { StdImageButton.Init() })
+}
+
 // Theme contains the theme elements for ImageButtons.
 type Theme struct {
 	button.BaseTheme
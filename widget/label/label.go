@@ -4,18 +4,27 @@ import (
 	"fmt"
 
 	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/clipboard"
+	"github.com/richardwilkes/ui/clipboard/datatypes"
 	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/cursor"
 	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/event/button"
 	"github.com/richardwilkes/ui/font"
 	"github.com/richardwilkes/ui/widget"
 )
 
-// Label represents a non-interactive piece of text.
+// Label represents a piece of text. By default, labels are non-interactive, but they may be made
+// selectable, allowing the user to select their text with the mouse.
 type Label struct {
 	widget.Block
-	text       string
-	font       *font.Font
-	foreground color.Color
+	text            string
+	font            *font.Font
+	foreground      color.Color
+	selectable      bool
+	selectionStart  int
+	selectionEnd    int
+	selectionAnchor int
 }
 
 // New creates a label with the specified text.
@@ -25,11 +34,15 @@ func New(text string) *Label {
 
 // NewWithFont creates a label with the specified text and font.
 func NewWithFont(text string, font *font.Font) *Label {
-	label := &Label{text: text, foreground: color.Black, font: font}
+	label := &Label{text: text, font: font}
 	label.InitTypeAndID(label)
 	label.Describer = func() string { return fmt.Sprintf("Label #%d (%s)", label.ID(), label.text) }
 	label.SetSizer(label)
-	label.EventHandlers().Add(event.PaintType, label.paint)
+	handlers := label.EventHandlers()
+	handlers.Add(event.PaintType, label.paint)
+	handlers.Add(event.MouseDownType, label.mouseDown)
+	handlers.Add(event.MouseDraggedType, label.mouseDragged)
+	handlers.Add(event.UpdateCursorType, label.setCursor)
 	return label
 }
 
@@ -47,12 +60,158 @@ func (label *Label) Sizes(hint geom.Size) (min, pref, max geom.Size) {
 func (label *Label) paint(evt event.Event) {
 	bounds := label.LocalInsetBounds()
 	gc := evt.(*event.Paint).GC()
-	gc.SetColor(label.foreground)
+	if label.foreground == 0 {
+		gc.SetColor(color.Text)
+	} else {
+		gc.SetColor(label.foreground)
+	}
 	size := label.font.Measure(label.text)
-	gc.DrawString(bounds.X, bounds.Y+(bounds.Height-size.Height)/2, label.text, label.font)
+	top := bounds.Y + (bounds.Height-size.Height)/2
+	if label.selectionStart < label.selectionEnd {
+		left := bounds.X
+		if label.selectionStart > 0 {
+			pre := string(label.runes()[:label.selectionStart])
+			gc.DrawString(left, top, pre, label.font)
+			left += label.font.Measure(pre).Width
+		}
+		mid := string(label.runes()[label.selectionStart:label.selectionEnd])
+		width := label.font.Measure(mid).Width
+		gc.SetColor(color.SelectedTextBackground)
+		gc.FillRect(geom.Rect{Point: geom.Point{X: left, Y: top}, Size: geom.Size{Width: width, Height: size.Height}})
+		gc.SetColor(color.SelectedText)
+		gc.DrawString(left, top, mid, label.font)
+		if label.selectionEnd < len(label.runes()) {
+			if label.foreground == 0 {
+				gc.SetColor(color.Text)
+			} else {
+				gc.SetColor(label.foreground)
+			}
+			gc.DrawString(left+width, top, string(label.runes()[label.selectionEnd:]), label.font)
+		}
+	} else {
+		gc.DrawString(bounds.X, top, label.text, label.font)
+	}
+}
+
+func (label *Label) runes() []rune {
+	return []rune(label.text)
+}
+
+// Text returns the label's text.
+func (label *Label) Text() string {
+	return label.text
+}
+
+// SetText sets the label's text. Any selection is cleared.
+func (label *Label) SetText(text string) {
+	if label.text != text {
+		label.text = text
+		label.SetSelection(0, 0)
+		label.Repaint()
+	}
+}
+
+// Selectable returns true if the user can select the label's text.
+func (label *Label) Selectable() bool {
+	return label.selectable
+}
+
+// SetSelectable sets whether the user can select the label's text. On platforms that support it,
+// selected text is published as the primary selection.
+func (label *Label) SetSelectable(selectable bool) {
+	if label.selectable != selectable {
+		label.selectable = selectable
+		label.SetSelection(0, 0)
+	}
+}
+
+// SelectedText returns the currently selected text.
+func (label *Label) SelectedText() string {
+	return string(label.runes()[label.selectionStart:label.selectionEnd])
+}
+
+// SetSelection sets the start and end range of the selection. Values beyond either end will be
+// constrained to the appropriate end. Likewise, an end value less than the start value will be
+// treated as if the start and end values were the same.
+func (label *Label) SetSelection(start, end int) {
+	label.setSelection(start, end, start)
+}
+
+func (label *Label) setSelection(start, end, anchor int) {
+	length := len(label.runes())
+	if start < 0 {
+		start = 0
+	} else if start > length {
+		start = length
+	}
+	if end < start {
+		end = start
+	} else if end > length {
+		end = length
+	}
+	label.selectionAnchor = anchor
+	if label.selectionStart != start || label.selectionEnd != end {
+		label.selectionStart = start
+		label.selectionEnd = end
+		label.Repaint()
+		if start < end {
+			clipboard.Primary.SetData(datatypes.Data{MimeType: datatypes.PlainText, Bytes: []byte(label.SelectedText())})
+		}
+	}
+}
+
+// CanCopy returns true if the label has a selection that can be copied.
+func (label *Label) CanCopy() bool {
+	return label.selectionStart < label.selectionEnd
+}
+
+// Copy the selected text to the clipboard.
+func (label *Label) Copy() {
+	if label.CanCopy() {
+		clipboard.SetData(datatypes.Data{MimeType: datatypes.PlainText, Bytes: []byte(label.SelectedText())})
+	}
+}
+
+func (label *Label) indexAt(where geom.Point) int {
+	return label.font.IndexForPosition(label.FromWindow(where).X-label.LocalInsetBounds().X, label.text)
+}
+
+func (label *Label) mouseDown(evt event.Event) {
+	if label.selectable {
+		if e, ok := evt.(*event.MouseDown); ok && e.Button() == button.Left {
+			if e.Clicks() > 1 {
+				label.SetSelection(0, len(label.runes()))
+			} else {
+				pos := label.indexAt(e.Where())
+				label.setSelection(pos, pos, pos)
+			}
+			evt.Finish()
+		}
+	}
+}
+
+func (label *Label) mouseDragged(evt event.Event) {
+	if label.selectable {
+		pos := label.indexAt(evt.(*event.MouseDragged).Where())
+		anchor := label.selectionAnchor
+		if pos < anchor {
+			label.setSelection(pos, anchor, anchor)
+		} else {
+			label.setSelection(anchor, pos, anchor)
+		}
+		evt.Finish()
+	}
+}
+
+func (label *Label) setCursor(evt event.Event) {
+	if label.selectable {
+		label.Window().SetCursor(cursor.Text)
+		evt.Finish()
+	}
 }
 
-// SetForeground sets the color used when drawing the text.
+// SetForeground sets the color used when drawing the text. Setting it to 0
+// causes the text color of the active theme to be used, which is the default.
 func (label *Label) SetForeground(color color.Color) {
 	if label.foreground != color {
 		label.foreground = color
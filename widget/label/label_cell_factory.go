@@ -24,16 +24,7 @@ func (f *CellFactory) CellHeight() float64 {
 
 // CreateCell implements the widget.CellFactory interface.
 func (f *CellFactory) CreateCell(owner ui.Widget, element interface{}, index int, selected, focused bool) ui.Widget {
-	var text string
-	switch v := element.(type) {
-	case string:
-		text = v
-	case fmt.Stringer:
-		text = v.String()
-	default:
-		text = reflect.TypeOf(element).String()
-	}
-	label := NewWithFont(text, font.Views)
+	label := NewWithFont(cellText(element), font.Views)
 	if selected {
 		label.SetBackground(color.SelectedTextBackground)
 		label.SetForeground(color.SelectedText)
@@ -41,3 +32,31 @@ func (f *CellFactory) CreateCell(owner ui.Widget, element interface{}, index int
 	label.SetBorder(border.NewEmpty(geom.NewHorizontalInsets(4)))
 	return label
 }
+
+// UpdateCell implements the widget.CellUpdater interface.
+func (f *CellFactory) UpdateCell(cell ui.Widget, owner ui.Widget, element interface{}, index int, selected, focused bool) bool {
+	label, ok := cell.(*Label)
+	if !ok {
+		return false
+	}
+	label.SetText(cellText(element))
+	if selected {
+		label.SetBackground(color.SelectedTextBackground)
+		label.SetForeground(color.SelectedText)
+	} else {
+		label.SetBackground(0)
+		label.SetForeground(0)
+	}
+	return true
+}
+
+func cellText(element interface{}) string {
+	switch v := element.(type) {
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return reflect.TypeOf(element).String()
+	}
+}
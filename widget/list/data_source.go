@@ -0,0 +1,65 @@
+package list
+
+// DataSource provides the rows displayed by a List. Rows are only fetched as they are needed, so
+// a data source may hold far more rows than could reasonably be placed in a slice.
+type DataSource interface {
+	// RowCount returns the number of rows.
+	RowCount() int
+	// Row returns the row at the specified index.
+	Row(index int) interface{}
+}
+
+// sliceDataSource is the data source a List uses until another one is set.
+type sliceDataSource struct {
+	rows []interface{}
+}
+
+func (ds *sliceDataSource) RowCount() int {
+	return len(ds.rows)
+}
+
+func (ds *sliceDataSource) Row(index int) interface{} {
+	return ds.rows[index]
+}
+
+// RowMover may be implemented by data sources whose rows can be reordered by the user.
+type RowMover interface {
+	// CanMoveRows returns true if the rows may currently be reordered.
+	CanMoveRows() bool
+	// MoveRows moves the rows at 'indexes', which are in ascending order, so that they are placed
+	// before the row currently at index 'to'. 'to' may be equal to the number of rows to place them
+	// at the end.
+	MoveRows(indexes []int, to int)
+}
+
+func (ds *sliceDataSource) CanMoveRows() bool {
+	return true
+}
+
+func (ds *sliceDataSource) MoveRows(indexes []int, to int) {
+	moving := make([]interface{}, 0, len(indexes))
+	rows := make([]interface{}, 0, len(ds.rows))
+	next := 0
+	for i, row := range ds.rows {
+		if next < len(indexes) && indexes[next] == i {
+			moving = append(moving, row)
+			next++
+		} else {
+			rows = append(rows, row)
+		}
+	}
+	to = movedRowsStart(indexes, to)
+	ds.rows = append(rows[:to], append(moving, rows[to:]...)...)
+}
+
+// movedRowsStart returns the index the first of the rows at 'indexes' will have after they are
+// moved before the row at 'to'.
+func movedRowsStart(indexes []int, to int) int {
+	for _, index := range indexes {
+		if index >= to {
+			break
+		}
+		to--
+	}
+	return to
+}
@@ -1,37 +1,73 @@
 package list
 
 import (
+	"bytes"
 	"fmt"
 	"math"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/richardwilkes/toolbox/xmath"
 	"github.com/richardwilkes/toolbox/xmath/geom"
 	"github.com/richardwilkes/ui"
 	"github.com/richardwilkes/ui/border"
+	"github.com/richardwilkes/ui/clipboard"
+	"github.com/richardwilkes/ui/clipboard/datatypes"
 	"github.com/richardwilkes/ui/color"
 	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/event/button"
 	"github.com/richardwilkes/ui/keys"
 	"github.com/richardwilkes/ui/layout"
+	"github.com/richardwilkes/ui/menu"
+	"github.com/richardwilkes/ui/menu/editmenu"
 	"github.com/richardwilkes/ui/widget"
+	"github.com/richardwilkes/ui/widget/scrollbar"
+)
+
+var (
+	// SizeSampleRows holds the number of rows, starting from the first, that are measured to
+	// determine the preferred width of a List. Rows beyond these only contribute to the width once
+	// they have been painted.
+	SizeSampleRows = 100
+	// TypeAheadTimeout holds the amount of time that may pass between key strokes before the text
+	// typed to find a row is discarded.
+	TypeAheadTimeout = time.Second
+	// DragThreshold holds the distance the mouse must move while pressed on a row before a drag to
+	// reorder the rows begins.
+	DragThreshold = 4.0
 )
 
 // List provides a control that allows the user to select from a list of items, represented by cells.
 type List struct {
 	widget.Block
 	factory        widget.CellFactory
-	rows           []interface{}
+	data           DataSource
+	builtin        sliceDataSource
+	heights        rowHeights
+	spare          []ui.Widget
+	widest         float64
 	Selection      xmath.BitSet
 	savedSelection *xmath.BitSet
 	anchor         int
 	pressed        bool
+	reorderable    bool
+	dragPending    bool
+	dragging       bool
+	dragStart      geom.Point
+	dropIndex      int
+	typed          string
+	typedAt        time.Time
 }
 
 // New creates a new List control.
 func New(factory widget.CellFactory) *List {
 	list := &List{factory: factory, anchor: -1}
+	list.data = &list.builtin
+	list.heights.reset(0, 0)
 	list.InitTypeAndID(list)
 	list.Describer = func() string { return fmt.Sprintf("List #%d", list.ID()) }
-	list.SetBackground(color.White)
+	list.SetBackground(color.TextBackground)
 	list.SetBorder(border.NewEmpty(geom.NewUniformInsets(2)))
 	list.SetFocusable(true)
 	list.SetGrabFocusWhenClickedOn(true)
@@ -42,20 +78,25 @@ func New(factory widget.CellFactory) *List {
 	handlers.Add(event.MouseDraggedType, list.mouseDragged)
 	handlers.Add(event.MouseUpType, list.mouseUp)
 	handlers.Add(event.KeyDownType, list.keyDown)
+	handlers.Add(event.ContextMenuType, list.contextMenu)
+	handlers.Add(event.ThemeChangedType, func(evt event.Event) { list.SetBackground(color.TextBackground) })
 	return list
 }
 
 // Sizes implements Sizer
 func (list *List) Sizes(hint geom.Size) (min, pref, max geom.Size) {
 	max = layout.DefaultMaxSize(max)
-	height := math.Ceil(list.factory.CellHeight())
+	height := list.cellHeight()
 	if height < 1 {
 		height = layout.NoHint
+		list.syncHeights()
 	}
 	size := geom.Size{Width: hint.Width, Height: height}
-	for i, row := range list.rows {
-		cell := list.factory.CreateCell(list, row, i, false, false)
+	count := list.data.RowCount()
+	for i := 0; i < count && i < SizeSampleRows; i++ {
+		cell := list.acquireCell(i, false, false)
 		_, cpref, cmax := ui.Sizes(cell, size)
+		list.releaseCell(cell)
 		cpref.GrowToInteger()
 		cmax.GrowToInteger()
 		if pref.Width < cpref.Width {
@@ -65,65 +106,231 @@ func (list *List) Sizes(hint geom.Size) (min, pref, max geom.Size) {
 			max.Width = cmax.Width
 		}
 		if height < 1 {
-			pref.Height += cpref.Height
-			max.Height += cmax.Height
+			list.heights.set(i, cpref.Height)
 		}
 	}
+	if pref.Width < list.widest {
+		pref.Width = list.widest
+	}
+	if max.Width < pref.Width {
+		max.Width = pref.Width
+	}
 	if height >= 1 {
-		count := float64(len(list.rows))
-		if count < 1 {
-			count = 1
-		}
-		pref.Height = count * height
-		max.Height = count * height
-		if max.Height < layout.DefaultMax {
-			max.Height = layout.DefaultMax
-		}
+		pref.Height = math.Max(float64(count), 1) * height
+	} else {
+		pref.Height = list.heights.total()
 	}
+	max.Height = math.Max(pref.Height, layout.DefaultMax)
 	pref.GrowToInteger()
 	max.GrowToInteger()
 	return pref, pref, max
 }
 
-// Append values to the list of items.
+// DataSource returns the source of the rows being displayed.
+func (list *List) DataSource() DataSource {
+	return list.data
+}
+
+// SetDataSource sets the source of the rows being displayed. Passing nil reverts to the list's
+// built-in data source, which is the one modified by Append(), Insert() and Remove(). The selection
+// is cleared.
+func (list *List) SetDataSource(ds DataSource) {
+	if ds == nil {
+		ds = &list.builtin
+	}
+	list.data = ds
+	list.Selection.Reset()
+	list.anchor = -1
+	list.DataChanged()
+}
+
+// RowsInserted should be called after 'count' rows have been inserted into the data source at
+// 'index'.
+func (list *List) RowsInserted(index, count int) {
+	if list.variableHeight() && list.heights.count()+count == list.data.RowCount() {
+		if list.heights.estimate == 0 {
+			// Nothing was available to estimate from before
+			list.heights.setEstimate(list.estimateHeight())
+		}
+		list.heights.insert(index, count)
+		list.sizeChanged()
+	} else {
+		list.DataChanged()
+	}
+}
+
+// RowsRemoved should be called after 'count' rows have been removed from the data source,
+// starting at 'index'.
+func (list *List) RowsRemoved(index, count int) {
+	if list.variableHeight() && list.heights.count()-count == list.data.RowCount() {
+		list.heights.remove(index, count)
+		list.sizeChanged()
+	} else {
+		list.DataChanged()
+	}
+}
+
+// RowsChanged should be called after the content of 'count' rows in the data source, starting at
+// 'index', has changed.
+func (list *List) RowsChanged(index, count int) {
+	if list.variableHeight() && index+count <= list.heights.count() {
+		list.heights.invalidate(index, count)
+		list.Repaint()
+	} else {
+		list.DataChanged()
+	}
+}
+
+// DataChanged should be called after the data source has changed in a way that can't be described
+// by RowsInserted(), RowsRemoved() or RowsChanged(). Any cached row heights are discarded.
+func (list *List) DataChanged() {
+	list.heights.reset(0, 0)
+	list.widest = 0
+	list.sizeChanged()
+}
+
+// Append values to the list of items in the built-in data source.
 func (list *List) Append(values ...interface{}) {
-	list.rows = append(list.rows, values...)
-	list.Repaint()
+	index := len(list.builtin.rows)
+	list.builtin.rows = append(list.builtin.rows, values...)
+	list.builtinChanged(func() { list.RowsInserted(index, len(values)) })
 }
 
-// Insert values at the specified index.
+// Insert values at the specified index in the built-in data source.
 func (list *List) Insert(index int, values ...interface{}) {
-	list.rows = append(list.rows[:index], append(values, list.rows[index:]...)...)
-	list.Repaint()
+	list.builtin.rows = append(list.builtin.rows[:index], append(values, list.builtin.rows[index:]...)...)
+	list.builtinChanged(func() { list.RowsInserted(index, len(values)) })
 }
 
-// Remove the item at the specified index.
+// Remove the item at the specified index in the built-in data source.
 func (list *List) Remove(index int) {
-	copy(list.rows[index:], list.rows[index+1:])
-	size := len(list.rows) - 1
-	list.rows[size] = nil
-	list.rows = list.rows[:size]
+	rows := list.builtin.rows
+	copy(rows[index:], rows[index+1:])
+	size := len(rows) - 1
+	rows[size] = nil
+	list.builtin.rows = rows[:size]
+	list.builtinChanged(func() { list.RowsRemoved(index, 1) })
+}
+
+func (list *List) builtinChanged(notify func()) {
+	if list.data == &list.builtin {
+		notify()
+	}
+}
+
+func (list *List) cellHeight() float64 {
+	return math.Ceil(list.factory.CellHeight())
+}
+
+func (list *List) variableHeight() bool {
+	return list.cellHeight() < 1
+}
+
+// syncHeights prepares the cached row heights for use, estimating the height of each row from the
+// height of the first one if the number of rows no longer matches.
+func (list *List) syncHeights() {
+	count := list.data.RowCount()
+	if list.heights.count() != count {
+		list.heights.reset(count, list.estimateHeight())
+	}
+}
+
+// estimateHeight returns the preferred height of the first row, or 0 if there are no rows.
+func (list *List) estimateHeight() float64 {
+	if list.data.RowCount() == 0 {
+		return 0
+	}
+	cell := list.acquireCell(0, false, false)
+	_, pref, _ := ui.Sizes(cell, layout.NoHintSize)
+	list.releaseCell(cell)
+	pref.GrowToInteger()
+	return pref.Height
+}
+
+// sizeChanged marks the list and its ancestors as needing layout, since the list's preferred size
+// may no longer be the same.
+func (list *List) sizeChanged() {
+	var w ui.Widget = list
+	for w != nil {
+		w.SetNeedLayout(true)
+		w = w.Parent()
+	}
 	list.Repaint()
 }
 
-func (list *List) rowAt(y float64) (index int, top float64) {
-	count := len(list.rows)
-	top = list.LocalInsetBounds().Y
-	cellHeight := math.Ceil(list.factory.CellHeight())
-	if cellHeight < 1 {
-		for index < count {
-			cell := list.factory.CreateCell(list, list.rows[index], index, false, false)
-			_, pref, _ := ui.Sizes(cell, layout.NoHintSize)
-			pref.GrowToInteger()
-			if top+pref.Height >= y {
-				break
+func (list *List) acquireCell(index int, selected, focused bool) ui.Widget {
+	row := list.data.Row(index)
+	if count := len(list.spare); count > 0 {
+		if updater, ok := list.factory.(widget.CellUpdater); ok {
+			cell := list.spare[count-1]
+			list.spare[count-1] = nil
+			list.spare = list.spare[:count-1]
+			if updater.UpdateCell(cell, list, row, index, selected, focused) {
+				return cell
 			}
-			top += pref.Height
-			index++
 		}
-	} else {
+	}
+	return list.factory.CreateCell(list, row, index, selected, focused)
+}
+
+// releaseCell makes the cell available for reuse, if the factory supports it.
+func (list *List) releaseCell(cell ui.Widget) {
+	if _, ok := list.factory.(widget.CellUpdater); ok {
+		list.spare = append(list.spare, cell)
+	}
+}
+
+// rowTop returns the position of the top of the row at 'index'.
+func (list *List) rowTop(index int) float64 {
+	top := list.LocalInsetBounds().Y
+	if cellHeight := list.cellHeight(); cellHeight >= 1 {
+		return top + float64(index)*cellHeight
+	}
+	list.syncHeights()
+	return top + list.heights.top(index)
+}
+
+func (list *List) rowHeight(index int) float64 {
+	if cellHeight := list.cellHeight(); cellHeight >= 1 {
+		return cellHeight
+	}
+	list.syncHeights()
+	return list.heights.height(index)
+}
+
+// ScrollRowIntoView scrolls the nearest scrollable ancestor of the list, if any, so that the row at
+// 'index' is visible.
+func (list *List) ScrollRowIntoView(index int) {
+	if index < 0 || index >= list.data.RowCount() {
+		return
+	}
+	for parent := list.Parent(); parent != nil; parent = parent.Parent() {
+		if scroller, ok := parent.(scrollbar.Scrollable); ok {
+			top := list.rowTop(index)
+			bottom := top + list.rowHeight(index)
+			pos := scroller.ScrolledPosition(false)
+			visible := scroller.VisibleSize(false)
+			if top < pos {
+				scroller.SetScrolledPosition(false, top)
+			} else if bottom > pos+visible {
+				scroller.SetScrolledPosition(false, bottom-visible)
+			}
+			return
+		}
+	}
+}
+
+func (list *List) rowAt(y float64) (index int, top float64) {
+	count := list.data.RowCount()
+	top = list.LocalInsetBounds().Y
+	if cellHeight := list.cellHeight(); cellHeight >= 1 {
 		index = int(math.Floor((y - top) / cellHeight))
 		top += float64(index) * cellHeight
+	} else {
+		list.syncHeights()
+		if index = list.heights.indexAt(y - top); index >= 0 {
+			top += list.heights.top(index)
+		}
 	}
 	if index >= count {
 		index = -1
@@ -162,14 +369,45 @@ func (list *List) mouseDown(evt event.Event) {
 			if !list.Selection.Equal(list.savedSelection) {
 				list.Repaint()
 			}
+			if e.Button() == button.Left && !e.Modifiers().CommandDown() && !e.Modifiers().ShiftDown() && list.canReorder() {
+				list.dragPending = true
+				list.dragStart = list.FromWindow(e.Where())
+			}
+		}
+		if e.Button() == button.Right {
+			// The context menu will take over the mouse, so there won't be a mouse up in which to
+			// report the change.
+			if !list.Selection.Equal(list.savedSelection) {
+				event.Dispatch(event.NewSelection(list))
+			}
+			list.savedSelection = nil
+			return
 		}
 	}
 	list.pressed = true
 }
 
+func (list *List) contextMenu(evt event.Event) {
+	editmenu.AppendContextMenuItems(menu.ForContextMenu(evt.(*event.ContextMenu)))
+}
+
 func (list *List) mouseDragged(evt event.Event) {
 	if list.pressed {
 		if e, ok := evt.(*event.MouseDragged); ok {
+			if list.dragPending {
+				where := list.FromWindow(e.Where())
+				if !list.dragging && math.Hypot(where.X-list.dragStart.X, where.Y-list.dragStart.Y) >= DragThreshold {
+					list.dragging = true
+					list.dropIndex = -1
+				}
+				if list.dragging {
+					if index := list.dropIndexAt(where.Y); index != list.dropIndex {
+						list.dropIndex = index
+						list.Repaint()
+					}
+				}
+				return
+			}
 			list.Selection.Copy(list.savedSelection)
 			if index, _ := list.rowAt(list.FromWindow(e.Where()).Y); index >= 0 {
 				if list.anchor == -1 {
@@ -194,34 +432,114 @@ func (list *List) mouseDragged(evt event.Event) {
 func (list *List) mouseUp(evt event.Event) {
 	if list.pressed {
 		list.pressed = false
+		if list.dragging {
+			list.dragging = false
+			list.reorder(list.dropIndex)
+			list.Repaint()
+		}
 		if !list.Selection.Equal(list.savedSelection) {
 			event.Dispatch(event.NewSelection(list))
 		}
 	}
+	list.dragPending = false
 	list.savedSelection = nil
 }
 
+// Reorderable returns true if the user may drag the rows to reorder them.
+func (list *List) Reorderable() bool {
+	return list.reorderable
+}
+
+// SetReorderable sets whether the user may drag the rows to reorder them. Rows can only be
+// reordered when the data source implements the RowMover interface and permits it. A Reorder event
+// is dispatched before the rows are moved, giving its handlers a chance to abort the move.
+func (list *List) SetReorderable(reorderable bool) {
+	list.reorderable = reorderable
+}
+
+func (list *List) canReorder() bool {
+	if list.reorderable {
+		if mover, ok := list.data.(RowMover); ok {
+			return mover.CanMoveRows()
+		}
+	}
+	return false
+}
+
+// dropIndexAt returns the index of the row that dragged rows dropped at 'y' would be placed before.
+func (list *List) dropIndexAt(y float64) int {
+	index, top := list.rowAt(y)
+	if index < 0 {
+		if y < list.LocalInsetBounds().Y {
+			return 0
+		}
+		return list.data.RowCount()
+	}
+	if y >= top+list.rowHeight(index)/2 {
+		index++
+	}
+	return index
+}
+
+// reorder moves the selected rows before the row at 'to', unless a Reorder event handler aborts it.
+func (list *List) reorder(to int) {
+	indexes := make([]int, 0, list.Selection.Count())
+	for i := list.Selection.FirstSet(); i != -1 && i < list.data.RowCount(); i = list.Selection.NextSet(i + 1) {
+		indexes = append(indexes, i)
+	}
+	count := len(indexes)
+	if count == 0 || (indexes[count-1]-indexes[0] == count-1 && to >= indexes[0] && to <= indexes[count-1]+1) {
+		return
+	}
+	mover, ok := list.data.(RowMover)
+	if !ok {
+		return
+	}
+	e := event.NewReorder(list, indexes, to)
+	event.Dispatch(e)
+	if !e.Aborted() {
+		mover.MoveRows(indexes, to)
+		start := movedRowsStart(indexes, to)
+		list.Selection.Reset()
+		list.Selection.SetRange(start, start+count-1)
+		list.anchor = start
+		list.DataChanged()
+	}
+}
+
 func (list *List) paint(evt event.Event) {
 	if e, ok := evt.(*event.Paint); ok {
 		dirty := e.DirtyRect()
 		index, y := list.rowAt(dirty.Y)
 		if index >= 0 {
-			cellHeight := math.Ceil(list.factory.CellHeight())
-			count := len(list.rows)
+			cellHeight := list.cellHeight()
+			count := list.data.RowCount()
 			ymax := dirty.Y + dirty.Height
 			focused := list.Focused()
 			selCount := list.Selection.Count()
 			fullBounds := list.LocalBounds()
 			bounds := list.LocalInsetBounds()
+			resized := false
 			gc := e.GC()
 			for index < count && y < ymax {
 				selected := list.Selection.State(index)
-				cell := list.factory.CreateCell(list, list.rows[index], index, selected, focused && selected && selCount == 1)
+				cell := list.acquireCell(index, selected, focused && selected && selCount == 1)
 				cellBounds := geom.Rect{Point: geom.Point{X: bounds.X, Y: y}, Size: geom.Size{Width: bounds.Width, Height: cellHeight}}
-				if cellHeight < 1 {
+				if cellHeight < 1 && list.heights.isMeasured(index) {
+					cellBounds.Height = list.heights.height(index)
+				} else if cellHeight < 1 || index >= SizeSampleRows {
 					_, pref, _ := ui.Sizes(cell, layout.NoHintSize)
 					pref.GrowToInteger()
-					cellBounds.Height = pref.Height
+					if cellHeight < 1 {
+						cellBounds.Height = pref.Height
+						if list.heights.set(index, pref.Height) {
+							resized = true
+						}
+					}
+					if list.widest < pref.Width {
+						list.widest = pref.Width
+						resized = true
+					}
 				}
 				cell.SetBounds(cellBounds)
 				y += cellBounds.Height
@@ -238,8 +556,21 @@ func (list *List) paint(evt event.Event) {
 				cell.Paint(gc, dirty)
 				dirty.Point.Add(tl)
 				gc.Restore()
+				list.releaseCell(cell)
 				index++
 			}
+			if resized {
+				// Measuring the rows changed the list's size, which can't be acted upon while painting
+				if wnd := list.Window(); wnd != nil {
+					wnd.Invoke(list.sizeChanged)
+				}
+			}
+		}
+		if list.dragging {
+			bounds := list.LocalInsetBounds()
+			gc := e.GC()
+			gc.SetColor(color.KeyboardFocus)
+			gc.FillRect(geom.Rect{Point: geom.Point{X: bounds.X, Y: list.rowTop(list.dropIndex) - 1}, Size: geom.Size{Width: bounds.Width, Height: 2}})
 		}
 	}
 }
@@ -247,7 +578,10 @@ func (list *List) paint(evt event.Event) {
 func (list *List) keyDown(evt event.Event) {
 	if e, ok := evt.(*event.KeyDown); ok {
 		code := e.Code()
-		if keys.IsControlAction(code) {
+		if list.isTypeAhead(e) {
+			evt.Finish()
+			list.typeAhead(e.Rune())
+		} else if keys.IsControlAction(code) {
 			if list.Selection.Count() > 0 {
 				event.Dispatch(event.NewClick(list))
 			}
@@ -257,7 +591,7 @@ func (list *List) keyDown(evt event.Event) {
 				evt.Finish()
 				var first int
 				if list.Selection.Count() == 0 {
-					first = len(list.rows) - 1
+					first = list.data.RowCount() - 1
 				} else {
 					first = list.Selection.FirstSet() - 1
 					if first < 0 {
@@ -265,36 +599,104 @@ func (list *List) keyDown(evt event.Event) {
 					}
 				}
 				list.Select(e.Modifiers().ShiftDown(), first)
+				list.ScrollRowIntoView(first)
 				event.Dispatch(event.NewSelection(list))
 			case keys.VirtualKeyDown, keys.VirtualKeyNumPadDown:
 				evt.Finish()
 				last := list.Selection.LastSet() + 1
-				if last >= len(list.rows) {
-					last = len(list.rows) - 1
+				if last >= list.data.RowCount() {
+					last = list.data.RowCount() - 1
 				}
 				list.Select(e.Modifiers().ShiftDown(), last)
+				list.ScrollRowIntoView(last)
 				event.Dispatch(event.NewSelection(list))
 			case keys.VirtualKeyHome, keys.VirtualKeyNumPadHome:
 				evt.Finish()
 				list.Select(e.Modifiers().ShiftDown(), 0)
+				list.ScrollRowIntoView(0)
 				event.Dispatch(event.NewSelection(list))
 			case keys.VirtualKeyEnd, keys.VirtualKeyNumPadEnd:
 				evt.Finish()
-				list.Select(e.Modifiers().ShiftDown(), len(list.rows)-1)
+				last := list.data.RowCount() - 1
+				list.Select(e.Modifiers().ShiftDown(), last)
+				list.ScrollRowIntoView(last)
 				event.Dispatch(event.NewSelection(list))
 			}
 		}
 	}
 }
 
+// isTypeAhead returns true if the key should be used to find a row by its text. A space is only
+// used when it follows other text typed for that purpose, since it otherwise acts as a click.
+func (list *List) isTypeAhead(e *event.KeyDown) bool {
+	ch := e.Rune()
+	if !unicode.IsPrint(ch) || e.Modifiers().CommandDown() || e.Modifiers().ControlDown() {
+		return false
+	}
+	if ch == ' ' {
+		return list.typed != "" && time.Since(list.typedAt) < TypeAheadTimeout
+	}
+	return true
+}
+
+// typeAhead adds the rune to the text typed so far and selects the next row whose text starts with
+// it, ignoring case.
+func (list *List) typeAhead(ch rune) {
+	now := time.Now()
+	if now.Sub(list.typedAt) >= TypeAheadTimeout {
+		list.typed = ""
+	}
+	list.typedAt = now
+	list.typed += string(unicode.ToLower(ch))
+	count := list.data.RowCount()
+	if count == 0 {
+		return
+	}
+	start := list.Selection.FirstSet()
+	if start == -1 {
+		start = 0
+	} else if list.typed == string(unicode.ToLower(ch)) {
+		// Starting a new search, so move past the current row to allow cycling through the matches
+		start++
+	}
+	for i := 0; i < count; i++ {
+		index := (start + i) % count
+		if strings.HasPrefix(strings.ToLower(fmt.Sprint(list.data.Row(index))), list.typed) {
+			list.Select(false, index)
+			list.ScrollRowIntoView(index)
+			event.Dispatch(event.NewSelection(list))
+			return
+		}
+	}
+}
+
+// CanCopy returns true if there are selected rows that can be copied.
+func (list *List) CanCopy() bool {
+	return list.Selection.Count() > 0
+}
+
+// Copy the selected rows to the clipboard as text, one row per line.
+func (list *List) Copy() {
+	if list.CanCopy() {
+		var buffer bytes.Buffer
+		for i := list.Selection.FirstSet(); i != -1 && i < list.data.RowCount(); i = list.Selection.NextSet(i + 1) {
+			if buffer.Len() > 0 {
+				buffer.WriteString("\n")
+			}
+			buffer.WriteString(fmt.Sprint(list.data.Row(i)))
+		}
+		clipboard.SetData(datatypes.Data{MimeType: datatypes.PlainText, Bytes: buffer.Bytes()})
+	}
+}
+
 // CanSelectAll returns true if SelectAll() will change anything.
 func (list *List) CanSelectAll() bool {
-	return list.Selection.Count() < len(list.rows)
+	return list.Selection.Count() < list.data.RowCount()
 }
 
 // SelectAll selects all rows.
 func (list *List) SelectAll() {
-	list.SelectRange(0, len(list.rows)-1, false)
+	list.SelectRange(0, list.data.RowCount()-1, false)
 }
 
 // SelectRange selects items from 'start' to 'end', inclusive. If 'append' is true, then any
@@ -304,7 +706,7 @@ func (list *List) SelectRange(start, end int, append bool) {
 		list.Selection.Reset()
 		list.anchor = -1
 	}
-	max := len(list.rows) - 1
+	max := list.data.RowCount() - 1
 	start = xmath.MaxInt(xmath.MinInt(start, max), 0)
 	end = xmath.MaxInt(xmath.MinInt(end, max), 0)
 	list.Selection.SetRange(start, end)
@@ -321,7 +723,7 @@ func (list *List) Select(append bool, index ...int) {
 		list.Selection.Reset()
 		list.anchor = -1
 	}
-	max := len(list.rows)
+	max := list.data.RowCount()
 	for _, v := range index {
 		if v >= 0 && v < max {
 			list.Selection.Set(v)
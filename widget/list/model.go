@@ -0,0 +1,165 @@
+package list
+
+import (
+	"sort"
+)
+
+// Model sits between a List and the data source holding its rows, allowing the rows to be filtered
+// and sorted without modifying the data source. Indexes into the data source are referred to as
+// model indexes, while indexes of the rows shown by the List are referred to as view indexes.
+type Model struct {
+	owner   *List
+	source  DataSource
+	filter  func(row interface{}) bool
+	less    func(a, b interface{}) bool
+	view    []int // nil when every row is shown in its original order
+	reverse []int
+}
+
+// NewModel creates a new model for the rows in 'source' and makes it the data source of 'owner'.
+func NewModel(owner *List, source DataSource) *Model {
+	model := &Model{owner: owner, source: source}
+	owner.SetDataSource(model)
+	return model
+}
+
+// Source returns the data source the model draws its rows from.
+func (model *Model) Source() DataSource {
+	return model.source
+}
+
+// RowCount implements the DataSource interface.
+func (model *Model) RowCount() int {
+	if model.view == nil {
+		return model.source.RowCount()
+	}
+	return len(model.view)
+}
+
+// Row implements the DataSource interface.
+func (model *Model) Row(index int) interface{} {
+	return model.source.Row(model.ModelIndex(index))
+}
+
+// ModelIndex returns the model index of the row at the specified view index.
+func (model *Model) ModelIndex(viewIndex int) int {
+	if model.view == nil {
+		return viewIndex
+	}
+	return model.view[viewIndex]
+}
+
+// ViewIndex returns the view index of the row at the specified model index, or -1 if it has been
+// filtered out.
+func (model *Model) ViewIndex(modelIndex int) int {
+	if model.view == nil {
+		return modelIndex
+	}
+	if modelIndex < 0 || modelIndex >= len(model.reverse) {
+		return -1
+	}
+	return model.reverse[modelIndex]
+}
+
+// SetFilter sets the function used to decide which rows are shown. Pass nil to show all rows.
+func (model *Model) SetFilter(filter func(row interface{}) bool) {
+	model.filter = filter
+	model.Refresh()
+}
+
+// SetSort sets the function used to order the rows. Rows that compare as equal retain their
+// relative order within the data source. Pass nil to show the rows in their original order.
+func (model *Model) SetSort(less func(a, b interface{}) bool) {
+	model.less = less
+	model.Refresh()
+}
+
+// Refresh reapplies the filter and sort. Call it after the rows in the data source have changed.
+// The rows that were selected in the List remain selected, provided they are still shown.
+func (model *Model) Refresh() {
+	list := model.owner
+	selected := make([]int, 0, list.Selection.Count())
+	for i := list.Selection.FirstSet(); i != -1; i = list.Selection.NextSet(i + 1) {
+		if i < model.RowCount() {
+			selected = append(selected, model.ModelIndex(i))
+		}
+	}
+	anchor := -1
+	if list.anchor >= 0 && list.anchor < model.RowCount() {
+		anchor = model.ModelIndex(list.anchor)
+	}
+	model.rebuild()
+	list.Selection.Reset()
+	count := model.source.RowCount()
+	for _, one := range selected {
+		if one < count {
+			if index := model.ViewIndex(one); index != -1 {
+				list.Selection.Set(index)
+			}
+		}
+	}
+	list.anchor = -1
+	if anchor != -1 && anchor < count {
+		list.anchor = model.ViewIndex(anchor)
+	}
+	if list.anchor == -1 {
+		list.anchor = list.Selection.FirstSet()
+	}
+	list.DataChanged()
+}
+
+func (model *Model) rebuild() {
+	if model.filter == nil && model.less == nil {
+		model.view = nil
+		model.reverse = nil
+		return
+	}
+	count := model.source.RowCount()
+	model.view = make([]int, 0, count)
+	for i := 0; i < count; i++ {
+		if model.filter == nil || model.filter(model.source.Row(i)) {
+			model.view = append(model.view, i)
+		}
+	}
+	if model.less != nil {
+		sort.SliceStable(model.view, func(i, j int) bool {
+			return model.less(model.source.Row(model.view[i]), model.source.Row(model.view[j]))
+		})
+	}
+	model.reverse = make([]int, count)
+	for i := range model.reverse {
+		model.reverse[i] = -1
+	}
+	for i, one := range model.view {
+		model.reverse[one] = i
+	}
+}
+
+// CanMoveRows implements the RowMover interface. Rows may only be moved when they are not sorted
+// and the data source allows it.
+func (model *Model) CanMoveRows() bool {
+	if model.less != nil {
+		return false
+	}
+	mover, ok := model.source.(RowMover)
+	return ok && mover.CanMoveRows()
+}
+
+// MoveRows implements the RowMover interface.
+func (model *Model) MoveRows(indexes []int, to int) {
+	mover, ok := model.source.(RowMover)
+	if !ok {
+		return
+	}
+	modelIndexes := make([]int, len(indexes))
+	for i, index := range indexes {
+		modelIndexes[i] = model.ModelIndex(index)
+	}
+	if to < model.RowCount() {
+		to = model.ModelIndex(to)
+	} else {
+		to = model.source.RowCount()
+	}
+	mover.MoveRows(modelIndexes, to)
+	model.rebuild()
+}
@@ -0,0 +1,154 @@
+package list
+
+// rowHeights holds the height of each row along with a Fenwick tree of them, so that both the
+// position of a row and the row at a position can be found in O(log n). Rows that have not been
+// measured yet are given an estimated height.
+type rowHeights struct {
+	heights  []float64
+	measured []bool
+	tree     []float64 // 1-based
+	estimate float64
+}
+
+// reset the heights to hold 'count' unmeasured rows.
+func (rh *rowHeights) reset(count int, estimate float64) {
+	rh.estimate = estimate
+	rh.heights = make([]float64, count)
+	rh.measured = make([]bool, count)
+	for i := range rh.heights {
+		rh.heights[i] = estimate
+	}
+	rh.rebuild()
+}
+
+func (rh *rowHeights) rebuild() {
+	count := len(rh.heights)
+	rh.tree = make([]float64, count+1)
+	for i := 1; i <= count; i++ {
+		rh.tree[i] += rh.heights[i-1]
+		if parent := i + (i & -i); parent <= count {
+			rh.tree[parent] += rh.tree[i]
+		}
+	}
+}
+
+// setEstimate changes the estimated height, applying it to the unmeasured rows that still have the
+// previous estimate.
+func (rh *rowHeights) setEstimate(estimate float64) {
+	if estimate == rh.estimate {
+		return
+	}
+	for i, height := range rh.heights {
+		if !rh.measured[i] && height == rh.estimate {
+			rh.heights[i] = estimate
+		}
+	}
+	rh.estimate = estimate
+	rh.rebuild()
+}
+
+func (rh *rowHeights) count() int {
+	return len(rh.heights)
+}
+
+// insert 'count' unmeasured rows at 'index'.
+func (rh *rowHeights) insert(index, count int) {
+	if index == len(rh.heights) {
+		for i := 0; i < count; i++ {
+			rh.append()
+		}
+		return
+	}
+	heights := make([]float64, count)
+	for i := range heights {
+		heights[i] = rh.estimate
+	}
+	rh.heights = append(rh.heights[:index], append(heights, rh.heights[index:]...)...)
+	rh.measured = append(rh.measured[:index], append(make([]bool, count), rh.measured[index:]...)...)
+	rh.rebuild()
+}
+
+// append an unmeasured row in O(log n).
+func (rh *rowHeights) append() {
+	rh.heights = append(rh.heights, rh.estimate)
+	rh.measured = append(rh.measured, false)
+	if len(rh.tree) == 0 {
+		// The tree has never been built, so give it its unused root
+		rh.tree = make([]float64, 1, 2)
+	}
+	i := len(rh.heights)
+	// The new node covers the rows (i - lowbit(i), i]
+	rh.tree = append(rh.tree, rh.estimate+rh.top(i-1)-rh.top(i-(i&-i)))
+}
+
+// remove 'count' rows starting at 'index'.
+func (rh *rowHeights) remove(index, count int) {
+	rh.heights = append(rh.heights[:index], rh.heights[index+count:]...)
+	rh.measured = append(rh.measured[:index], rh.measured[index+count:]...)
+	rh.rebuild()
+}
+
+// invalidate marks 'count' rows starting at 'index' as needing to be measured again.
+func (rh *rowHeights) invalidate(index, count int) {
+	for i := index; i < index+count; i++ {
+		rh.measured[i] = false
+	}
+}
+
+func (rh *rowHeights) isMeasured(index int) bool {
+	return rh.measured[index]
+}
+
+func (rh *rowHeights) height(index int) float64 {
+	return rh.heights[index]
+}
+
+// set the measured height of the row at 'index'. Returns true if the height changed.
+func (rh *rowHeights) set(index int, height float64) bool {
+	rh.measured[index] = true
+	delta := height - rh.heights[index]
+	if delta == 0 {
+		return false
+	}
+	rh.heights[index] = height
+	for i := index + 1; i < len(rh.tree); i += i & -i {
+		rh.tree[i] += delta
+	}
+	return true
+}
+
+// top returns the sum of the heights of the rows before 'index'.
+func (rh *rowHeights) top(index int) float64 {
+	var sum float64
+	for i := index; i > 0; i -= i & -i {
+		sum += rh.tree[i]
+	}
+	return sum
+}
+
+func (rh *rowHeights) total() float64 {
+	return rh.top(len(rh.heights))
+}
+
+// indexAt returns the index of the row containing 'y', or -1 if there isn't one.
+func (rh *rowHeights) indexAt(y float64) int {
+	count := len(rh.heights)
+	if y < 0 || count == 0 {
+		return -1
+	}
+	step := 1
+	for step*2 <= count {
+		step *= 2
+	}
+	pos := 0
+	for ; step > 0; step /= 2 {
+		if next := pos + step; next <= count && rh.tree[next] <= y {
+			pos = next
+			y -= rh.tree[next]
+		}
+	}
+	if pos >= count {
+		return -1
+	}
+	return pos
+}
@@ -0,0 +1,105 @@
+package list
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkHeights verifies the tree against a naive summation of the heights.
+func checkHeights(t *testing.T, rh *rowHeights, step string) {
+	t.Helper()
+	if len(rh.measured) != len(rh.heights) {
+		t.Fatalf("%s: %d measured flags for %d rows", step, len(rh.measured), len(rh.heights))
+	}
+	var sum float64
+	for i, height := range rh.heights {
+		if top := rh.top(i); top != sum {
+			t.Fatalf("%s: top(%d) = %v, expected %v", step, i, top, sum)
+		}
+		if height > 0 {
+			if index := rh.indexAt(sum); index != i {
+				t.Fatalf("%s: indexAt(%v) = %d, expected %d", step, sum, index, i)
+			}
+			if index := rh.indexAt(sum + height/2); index != i {
+				t.Fatalf("%s: indexAt(%v) = %d, expected %d", step, sum+height/2, index, i)
+			}
+		}
+		sum += height
+	}
+	if total := rh.total(); total != sum {
+		t.Fatalf("%s: total() = %v, expected %v", step, total, sum)
+	}
+	if index := rh.indexAt(sum); index != -1 {
+		t.Fatalf("%s: indexAt(%v) = %d, expected -1", step, sum, index)
+	}
+}
+
+func TestRowHeightsAppendBeforeReset(t *testing.T) {
+	var rh rowHeights
+	for i := 0; i < 5; i++ {
+		rh.append()
+	}
+	checkHeights(t, &rh, "append")
+	rh.setEstimate(10)
+	checkHeights(t, &rh, "setEstimate")
+	if total := rh.total(); total != 50 {
+		t.Fatalf("total() = %v, expected 50", total)
+	}
+}
+
+func TestRowHeightsInsertIntoEmpty(t *testing.T) {
+	var rh rowHeights
+	rh.reset(0, 0)
+	rh.insert(0, 3)
+	checkHeights(t, &rh, "insert")
+	if total := rh.total(); total != 0 {
+		t.Fatalf("total() = %v, expected 0", total)
+	}
+}
+
+func TestRowHeightsSetEstimate(t *testing.T) {
+	var rh rowHeights
+	rh.reset(4, 0)
+	rh.set(1, 7)
+	rh.set(2, 0)
+	rh.setEstimate(5)
+	for i, expected := range []float64{5, 7, 0, 5} {
+		if height := rh.height(i); height != expected {
+			t.Fatalf("height(%d) = %v, expected %v", i, height, expected)
+		}
+	}
+	checkHeights(t, &rh, "setEstimate")
+}
+
+func TestRowHeightsRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var rh rowHeights
+	rh.reset(rnd.Intn(10), 20)
+	for step := 0; step < 2000; step++ {
+		count := rh.count()
+		var name string
+		switch op := rnd.Intn(10); {
+		case op < 3:
+			name = "append"
+			rh.append()
+		case op < 5:
+			name = "insert"
+			rh.insert(rnd.Intn(count+1), 1+rnd.Intn(4))
+		case op < 7 && count > 0:
+			name = "remove"
+			index := rnd.Intn(count)
+			rh.remove(index, 1+rnd.Intn(count-index))
+		case op < 9 && count > 0:
+			name = "set"
+			rh.set(rnd.Intn(count), float64(rnd.Intn(40)))
+		case count > 0:
+			name = "invalidate"
+			index := rnd.Intn(count)
+			rh.invalidate(index, 1+rnd.Intn(count-index))
+		default:
+			name = "reset"
+			rh.reset(rnd.Intn(10), float64(1+rnd.Intn(30)))
+		}
+		checkHeights(t, &rh, name)
+	}
+}
@@ -1,6 +1,7 @@
 package radiobutton
 
 import (
+	"github.com/richardwilkes/ui/theme"
 	"github.com/richardwilkes/ui/widget/button"
 )
 
@@ -9,6 +10,10 @@ var (
 	StdTheme = NewTheme()
 )
 
+func init() {
+	theme.RegisterInitializer(func() { StdTheme.Init() })
+}
+
 // Theme contains the theme elements for RadioButtons.
 type Theme struct {
 	button.BaseTextTheme
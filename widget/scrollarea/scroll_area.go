@@ -46,6 +46,7 @@ func New(content ui.Widget, behavior Behavior) *ScrollArea {
 	handlers.Add(event.KeyDownType, sa.keyDown)
 	handlers.Add(event.FocusGainedType, sa.focusGained)
 	handlers.Add(event.FocusLostType, sa.focusLost)
+	handlers.Add(event.ThemeChangedType, sa.themeChanged)
 	sa.view = widget.NewBlock()
 	sa.view.SetBackground(color.TextBackground)
 	handlers = sa.view.EventHandlers()
@@ -206,6 +207,14 @@ func (sa *ScrollArea) focusLost(evt event.Event) {
 	}
 }
 
+func (sa *ScrollArea) themeChanged(evt event.Event) {
+	sa.SetBorder(sa.Theme.Border)
+	if sa.view.Border() != nil {
+		sa.view.SetBorder(sa.Theme.FocusBorder)
+	}
+	sa.view.SetBackground(color.TextBackground)
+}
+
 func (sa *ScrollArea) keyDown(evt event.Event) {
 	if e, ok := evt.(*event.KeyDown); ok {
 		switch e.Code() {
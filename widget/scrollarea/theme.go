@@ -4,6 +4,7 @@ import (
 	"github.com/richardwilkes/toolbox/xmath/geom"
 	"github.com/richardwilkes/ui/border"
 	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/theme"
 )
 
 var (
@@ -11,6 +12,10 @@ var (
 	StdTheme = NewTheme()
 )
 
+func init() {
+	theme.RegisterInitializer(func() { StdTheme.Init() })
+}
+
 // Theme contains the theme elements for ScrollAreas.
 type Theme struct {
 	Border      border.Border // The border to use when not focused.
@@ -26,7 +31,7 @@ func NewTheme() *Theme {
 
 // Init initializes the theme with its default values.
 func (theme *Theme) Init() {
-	theme.Border = border.NewLine(color.Background.AdjustBrightness(-0.25), geom.NewUniformInsets(1))
+	theme.Border = border.NewLine(color.Divider, geom.NewUniformInsets(1))
 	lineBorder := border.NewLine(color.KeyboardFocus, geom.NewUniformInsets(2))
 	lineBorder.NoInset = true
 	theme.FocusBorder = lineBorder
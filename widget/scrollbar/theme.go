@@ -5,6 +5,7 @@ import (
 
 	"github.com/richardwilkes/ui/color"
 	"github.com/richardwilkes/ui/draw"
+	"github.com/richardwilkes/ui/theme"
 )
 
 var (
@@ -12,6 +13,10 @@ var (
 	StdTheme = NewTheme()
 )
 
+func init() {
+	theme.RegisterInitializer(func() { StdTheme.Init() })
+}
+
 // Theme contains the theme elements for ScrollBars.
 type Theme struct {
 	InitialRepeatDelay    time.Duration // The amount of time to wait before triggering the first repeating event.
@@ -38,11 +43,11 @@ func NewTheme() *Theme {
 func (theme *Theme) Init() {
 	theme.InitialRepeatDelay = time.Millisecond * 250
 	theme.RepeatDelay = time.Millisecond * 75
-	theme.Background = color.White
+	theme.Background = color.ControlBackground
 	theme.BackgroundWhenPressed = color.KeyboardFocus
-	theme.MarkWhenLight = color.Black
-	theme.MarkWhenDark = color.White
-	theme.MarkWhenDisabled = color.Gray
+	theme.MarkWhenLight = color.TextWhenLight
+	theme.MarkWhenDark = color.TextWhenDark
+	theme.MarkWhenDisabled = color.TextWhenDisabled
 	theme.GradientAdjustment = 0.15
 	theme.DisabledAdjustment = -0.05
 	theme.OutlineAdjustment = -0.5
@@ -0,0 +1,37 @@
+package widget
+
+import (
+	"github.com/richardwilkes/ui"
+)
+
+// TextRange identifies a span of text by rune offsets.
+type TextRange struct {
+	Start int // The offset of the first rune in the span.
+	End   int // The offset just past the last rune in the span.
+}
+
+// Searchable defines the methods required of text widgets that can be searched, and have their
+// text replaced, by a find bar.
+type Searchable interface {
+	ui.Widget
+
+	// SearchText returns the text to be searched. Offsets used by the other methods are rune
+	// offsets into this text.
+	SearchText() string
+
+	// SearchSelection returns the range of the current selection.
+	SearchSelection() TextRange
+
+	// SetSearchSelection selects the range and scrolls it into view.
+	SetSearchSelection(selection TextRange)
+
+	// SetSearchHighlights sets the ranges that should be highlighted as matches, replacing any set
+	// previously. The ranges are sorted and don't overlap. Passing nil removes the highlights.
+	SetSearchHighlights(ranges []TextRange)
+
+	// ReplaceRanges replaces the text within each range with the corresponding entry from
+	// 'replacements' as a single edit, dispatching one Modified event. A widget that keeps an edit
+	// history records it as one entry, so that a single Undo reverts every replacement. The
+	// ranges are sorted and don't overlap. Returns true if a modification was made.
+	ReplaceRanges(ranges []TextRange, replacements []string) bool
+}
@@ -80,6 +80,6 @@ func (sep *Separator) paint(evt event.Event) {
 		}
 	}
 	gc := evt.(*event.Paint).GC()
-	gc.SetColor(color.Background.AdjustBrightness(-0.25))
+	gc.SetColor(color.Divider)
 	gc.FillRect(bounds)
 }
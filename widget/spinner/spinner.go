@@ -0,0 +1,326 @@
+package spinner
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/draw"
+	"github.com/richardwilkes/ui/draw/align"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/keys"
+	"github.com/richardwilkes/ui/layout/flex"
+	"github.com/richardwilkes/ui/widget"
+	"github.com/richardwilkes/ui/widget/textfield"
+)
+
+const (
+	none part = iota
+	up
+	down
+)
+
+type part int
+
+// Spinner provides a numeric text field paired with buttons for incrementing and decrementing its
+// value. The value may also be stepped with the up and down arrow keys and the mouse wheel.
+type Spinner struct {
+	widget.Block
+	Theme     *Theme // The theme the spinner will use to draw itself.
+	field     *textfield.TextField
+	arrows    *widget.Block
+	formatter textfield.FloatFormatter
+	value     float64
+	min       float64
+	max       float64
+	step      float64
+	pressed   part
+	sequence  int
+}
+
+// New creates a new spinner with a range of 0 to 100, a step of 1 and no fractional digits.
+func New() *Spinner {
+	s := &Spinner{Theme: StdTheme, max: 100, step: 1}
+	s.InitTypeAndID(s)
+	s.Describer = func() string { return fmt.Sprintf("Spinner #%d", s.ID()) }
+	lay := flex.NewLayout(s)
+	lay.Columns = 2
+	lay.HSpacing = 0
+	s.field = textfield.New()
+	s.field.SetFormatter(&s.formatter)
+	flexData := flex.NewData()
+	flexData.HGrab = true
+	flexData.HAlign = align.Fill
+	s.field.SetLayoutData(flexData)
+	s.AddChild(s.field)
+	handlers := s.field.EventHandlers()
+	handlers.Add(event.CommitType, s.committed)
+	// The field consumes the arrow keys, so intercept them first
+	handlers.Prepend(event.KeyDownType, s.keyDown)
+	s.arrows = widget.NewBlock()
+	flexData = flex.NewData()
+	flexData.VAlign = align.Fill
+	flexData.SizeHint.Width = s.Theme.ArrowWidth
+	s.arrows.SetLayoutData(flexData)
+	handlers = s.arrows.EventHandlers()
+	handlers.Add(event.PaintType, s.paintArrows)
+	handlers.Add(event.MouseDownType, s.mouseDown)
+	handlers.Add(event.MouseUpType, s.mouseUp)
+	s.AddChild(s.arrows)
+	s.EventHandlers().Add(event.MouseWheelType, s.mouseWheel)
+	s.updateField()
+	return s
+}
+
+// Value returns the current value.
+func (s *Spinner) Value() float64 {
+	return s.value
+}
+
+// SetValue sets the value, constraining it to the spinner's range and rounding it to its
+// precision. NaN and infinite values are ignored. A Modified event is dispatched if the value
+// changes.
+func (s *Spinner) SetValue(value float64) {
+	value = s.normalize(value)
+	changed := value != s.value
+	s.value = value
+	s.updateField()
+	if changed {
+		s.arrows.Repaint()
+		event.Dispatch(event.NewModified(s))
+	}
+}
+
+// Min returns the minimum value.
+func (s *Spinner) Min() float64 {
+	return s.min
+}
+
+// Max returns the maximum value.
+func (s *Spinner) Max() float64 {
+	return s.max
+}
+
+// SetRange sets the minimum and maximum values. If 'max' is less than 'min', it is treated as if
+// it were the same as 'min'. The current value is constrained to the new range.
+func (s *Spinner) SetRange(min, max float64) {
+	if max < min {
+		max = min
+	}
+	s.min = min
+	s.max = max
+	s.SetValue(s.value)
+}
+
+// Step returns the amount the value changes by when incremented or decremented.
+func (s *Spinner) Step() float64 {
+	return s.step
+}
+
+// SetStep sets the amount the value changes by when incremented or decremented.
+func (s *Spinner) SetStep(step float64) {
+	s.step = math.Abs(step)
+}
+
+// Precision returns the number of fractional digits displayed.
+func (s *Spinner) Precision() int {
+	return s.formatter.Decimals
+}
+
+// SetPrecision sets the number of fractional digits displayed. Values are rounded to this
+// precision.
+func (s *Spinner) SetPrecision(precision int) {
+	if precision < 0 {
+		precision = 0
+	}
+	s.formatter.Decimals = precision
+	s.SetValue(s.value)
+}
+
+// Increment increases the value by the step.
+func (s *Spinner) Increment() {
+	s.stepBy(s.step)
+}
+
+// Decrement decreases the value by the step.
+func (s *Spinner) Decrement() {
+	s.stepBy(-s.step)
+}
+
+func (s *Spinner) stepBy(delta float64) {
+	// Pick up anything typed into the field before stepping from it
+	if s.field.Text() != s.formatter.Format(s.value) {
+		s.field.Commit()
+	}
+	s.SetValue(s.value + delta)
+}
+
+// normalize returns the value constrained to the range and rounded to the precision. The current
+// value is returned in place of one that isn't finite.
+func (s *Spinner) normalize(value float64) float64 {
+	if !isFinite(value) {
+		return s.value
+	}
+	scale := math.Pow(10, float64(s.formatter.Decimals))
+	if rounded := math.Round(value*scale) / scale; isFinite(rounded) {
+		// Values too large to scale already have no fractional digits
+		value = rounded
+	}
+	return math.Max(math.Min(value, s.max), s.min)
+}
+
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+func (s *Spinner) updateField() {
+	s.field.SetValue(s.value)
+}
+
+func (s *Spinner) committed(evt event.Event) {
+	if value, ok := s.field.Value().(float64); ok && isFinite(value) {
+		s.SetValue(value)
+	} else {
+		s.updateField()
+	}
+}
+
+func (s *Spinner) keyDown(evt event.Event) {
+	if e, ok := evt.(*event.KeyDown); ok {
+		switch e.Code() {
+		case keys.VirtualKeyUp, keys.VirtualKeyNumPadUp:
+			s.Increment()
+			evt.Finish()
+		case keys.VirtualKeyDown, keys.VirtualKeyNumPadDown:
+			s.Decrement()
+			evt.Finish()
+		}
+	}
+}
+
+func (s *Spinner) mouseWheel(evt event.Event) {
+	if s.Enabled() {
+		if delta := evt.(*event.MouseWheel).Delta().Y; delta > 0 {
+			s.Increment()
+		} else if delta < 0 {
+			s.Decrement()
+		}
+	}
+	evt.Finish()
+}
+
+func (s *Spinner) mouseDown(evt event.Event) {
+	s.sequence++
+	if !s.Enabled() {
+		return
+	}
+	where := s.arrows.FromWindow(evt.(*event.MouseDown).Where())
+	what := s.over(where)
+	if s.partEnabled(what) {
+		s.pressed = what
+		s.scheduleRepeat(what, s.Theme.InitialRepeatDelay)
+		s.arrows.Repaint()
+	}
+}
+
+func (s *Spinner) mouseUp(evt event.Event) {
+	s.pressed = none
+	s.arrows.Repaint()
+}
+
+func (s *Spinner) scheduleRepeat(which part, delay time.Duration) {
+	window := s.Window()
+	if window.Valid() {
+		current := s.sequence
+		switch which {
+		case up:
+			s.Increment()
+		case down:
+			s.Decrement()
+		default:
+			return
+		}
+		window.InvokeAfter(func() {
+			if current == s.sequence && s.pressed == which && s.partEnabled(which) {
+				s.scheduleRepeat(which, s.Theme.RepeatDelay)
+			}
+		}, delay)
+	}
+}
+
+func (s *Spinner) over(where geom.Point) part {
+	for i := up; i <= down; i++ {
+		rect := s.partRect(i)
+		if rect.ContainsPoint(where) {
+			return i
+		}
+	}
+	return none
+}
+
+func (s *Spinner) partRect(which part) geom.Rect {
+	result := s.arrows.LocalInsetBounds()
+	result.Height /= 2
+	if which == down {
+		result.Y += result.Height
+	}
+	return result
+}
+
+func (s *Spinner) partEnabled(which part) bool {
+	if s.Enabled() {
+		switch which {
+		case up:
+			return s.value < s.max
+		case down:
+			return s.value > s.min
+		default:
+		}
+	}
+	return false
+}
+
+func (s *Spinner) paintArrows(evt event.Event) {
+	gc := evt.(*event.Paint).GC()
+	s.drawArrow(gc, up)
+	s.drawArrow(gc, down)
+}
+
+func (s *Spinner) drawArrow(gc *draw.Graphics, which part) {
+	bounds := s.partRect(which)
+	if s.pressed == which {
+		gc.SetColor(s.Theme.BackgroundWhenPressed)
+		gc.FillRect(bounds)
+	}
+	size := math.Min(bounds.Width, bounds.Height*2) / 2
+	left := bounds.X + (bounds.Width-size)/2
+	right := left + size
+	top := bounds.Y + (bounds.Height-size/2)/2
+	bottom := top + size/2
+	if which == up {
+		top, bottom = bottom, top
+	}
+	gc.BeginPath()
+	gc.MoveTo(left, top)
+	gc.LineTo(right, top)
+	gc.LineTo(left+size/2, bottom)
+	gc.ClosePath()
+	gc.SetColor(s.markColor(which))
+	gc.FillPath()
+}
+
+func (s *Spinner) markColor(which part) color.Color {
+	if s.partEnabled(which) {
+		background := color.Background
+		if s.pressed == which {
+			background = s.Theme.BackgroundWhenPressed
+		}
+		if background.Luminance() > 0.65 {
+			return s.Theme.MarkWhenLight
+		}
+		return s.Theme.MarkWhenDark
+	}
+	return s.Theme.MarkWhenDisabled
+}
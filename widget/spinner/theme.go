@@ -0,0 +1,46 @@
+package spinner
+
+import (
+	"time"
+
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/theme"
+)
+
+var (
+	// StdTheme is the theme all new Spinners get by default.
+	StdTheme = NewTheme()
+)
+
+func init() {
+	theme.RegisterInitializer(func() { StdTheme.Init() })
+}
+
+// Theme contains the theme elements for Spinners.
+type Theme struct {
+	InitialRepeatDelay    time.Duration // The amount of time to wait before triggering the first repeating step.
+	RepeatDelay           time.Duration // The amount of time to wait before triggering a repeating step.
+	ArrowWidth            float64       // The width of the increment and decrement buttons.
+	BackgroundWhenPressed color.Color   // The background color of a button while it is pressed.
+	MarkWhenLight         color.Color   // The color to use for the arrows when the background is considered to be 'light'.
+	MarkWhenDark          color.Color   // The color to use for the arrows when the background is considered to be 'dark'.
+	MarkWhenDisabled      color.Color   // The color to use for the arrows when disabled.
+}
+
+// NewTheme creates a new Spinner theme.
+func NewTheme() *Theme {
+	theme := &Theme{}
+	theme.Init()
+	return theme
+}
+
+// Init initializes the theme with its default values.
+func (theme *Theme) Init() {
+	theme.InitialRepeatDelay = time.Millisecond * 250
+	theme.RepeatDelay = time.Millisecond * 75
+	theme.ArrowWidth = 16
+	theme.BackgroundWhenPressed = color.KeyboardFocus
+	theme.MarkWhenLight = color.TextWhenLight
+	theme.MarkWhenDark = color.TextWhenDark
+	theme.MarkWhenDisabled = color.TextWhenDisabled
+}
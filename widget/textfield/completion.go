@@ -0,0 +1,341 @@
+package textfield
+
+import (
+	"math"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/border"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/draw/align"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/font"
+	"github.com/richardwilkes/ui/keys"
+	"github.com/richardwilkes/ui/layout/flex"
+	"github.com/richardwilkes/ui/widget/label"
+	"github.com/richardwilkes/ui/widget/list"
+	"github.com/richardwilkes/ui/widget/scrollarea"
+	"github.com/richardwilkes/ui/window"
+)
+
+// CompletionProvider supplies completions for the text typed into a TextField.
+type CompletionProvider interface {
+	// Completions returns the suggested replacements for 'prefix', the text preceding the caret.
+	Completions(prefix string) []string
+}
+
+// CompletionProviderFunc adapts a function to the CompletionProvider interface.
+type CompletionProviderFunc func(prefix string) []string
+
+// Completions implements the CompletionProvider interface.
+func (f CompletionProviderFunc) Completions(prefix string) []string {
+	return f(prefix)
+}
+
+type completer struct {
+	provider    CompletionProvider
+	async       bool
+	request     int
+	prefix      string
+	suggestions []string
+	ghost       string
+	popup       *window.Window
+	list        *list.List
+}
+
+// CompletionProvider returns the provider of completions for the field, or nil.
+func (field *TextField) CompletionProvider() CompletionProvider {
+	if field.completion == nil {
+		return nil
+	}
+	return field.completion.provider
+}
+
+// SetCompletionProvider sets the provider of completions for the field. As the user types, the
+// completions for the text preceding the caret are listed in a popup below it, and the remainder of
+// the first one that extends the text is shown after the caret, where Tab will accept it. If
+// 'async' is true, the provider is called on a separate goroutine and its results are posted back
+// to the UI thread with Window.Invoke. Results for text that has since changed are discarded.
+// Passing nil removes the provider.
+func (field *TextField) SetCompletionProvider(provider CompletionProvider, async bool) {
+	field.closeCompletions(false)
+	if provider == nil {
+		field.completion = nil
+	} else {
+		field.completion = &completer{provider: provider, async: async}
+	}
+}
+
+// requestCompletions asks the provider for the completions of the text preceding the caret. If
+// 'inline' is true, the first suitable completion is also shown after the caret.
+func (field *TextField) requestCompletions(inline bool) {
+	c := field.completion
+	if c == nil || field.secure {
+		return
+	}
+	c.request++
+	c.ghost = ""
+	if field.HasSelectionRange() || field.selectionStart == 0 {
+		field.closeCompletions(false)
+		return
+	}
+	prefix := string(field.runes[:field.selectionStart])
+	request := c.request
+	deliver := func(suggestions []string) {
+		if field.completion == c && request == c.request && field.completionsApply(prefix) {
+			field.showCompletions(prefix, suggestions, inline)
+		}
+	}
+	if c.async {
+		wnd := field.Window()
+		if wnd == nil {
+			return
+		}
+		provider := c.provider
+		go func() {
+			suggestions := provider.Completions(prefix)
+			wnd.Invoke(func() { deliver(suggestions) })
+		}()
+	} else {
+		deliver(c.provider.Completions(prefix))
+	}
+}
+
+func (field *TextField) showCompletions(prefix string, suggestions []string, inline bool) {
+	c := field.completion
+	c.prefix = prefix
+	c.suggestions = suggestions
+	if inline && field.selectionEnd == len(field.runes) {
+		length := len([]rune(prefix))
+		for _, one := range suggestions {
+			if candidate := []rune(one); len(candidate) > length && string(candidate[:length]) == prefix {
+				c.ghost = string(candidate[length:])
+				break
+			}
+		}
+	}
+	field.Repaint()
+	if len(suggestions) == 0 {
+		field.closeCompletions(true)
+		return
+	}
+	if c.popup == nil {
+		field.openCompletions()
+		if c.popup == nil {
+			return
+		}
+	}
+	c.list.SetDataSource(suggestionSource(suggestions))
+	rows := len(suggestions)
+	if rows > field.Theme.VisibleCompletions {
+		rows = field.Theme.VisibleCompletions
+	}
+	frame := c.popup.ContentFrame()
+	frame.Point = field.completionsLocation()
+	frame.Height = float64(rows)*completionHeight() + 2
+	c.popup.SetContentFrame(frame)
+	content := c.popup.Content()
+	content.SetNeedLayout(true)
+	content.ValidateLayout()
+	content.Repaint()
+}
+
+func (field *TextField) openCompletions() {
+	owner := field.Window()
+	if owner == nil {
+		return
+	}
+	c := field.completion
+	c.list = list.New(&label.CellFactory{Height: completionHeight()})
+	handlers := c.list.EventHandlers()
+	handlers.Add(event.MouseUpType, func(evt event.Event) { field.acceptSelectedCompletion() })
+	// The popup takes the keyboard focus, so pass the keys back to the field
+	handlers.Prepend(event.KeyDownType, func(evt event.Event) {
+		if e, ok := evt.(*event.KeyDown); ok {
+			evt.Finish()
+			event.Dispatch(event.NewKeyDown(field, e.Code(), e.Rune(), e.Modifiers(), e.Repeat()))
+		}
+	})
+	scroller := scrollarea.New(c.list, scrollarea.Fill)
+	scroller.SetBorder(nil)
+	c.popup = window.NewPopupWindow(owner, field.completionsLocation(), geom.Size{Width: 200, Height: 100})
+	content := c.popup.Content()
+	content.SetBackground(color.TextBackground)
+	content.SetBorder(border.NewLine(color.MenuBorder, geom.NewUniformInsets(1)))
+	flex.NewLayout(content)
+	flexData := flex.NewData()
+	flexData.HGrab = true
+	flexData.VGrab = true
+	flexData.HAlign = align.Fill
+	flexData.VAlign = align.Fill
+	scroller.SetLayoutData(flexData)
+	content.AddChild(scroller)
+	popup := c.popup
+	popup.EventHandlers().Add(event.FocusLostType, func(evt event.Event) {
+		if field.completion == c && c.popup == popup {
+			field.closeCompletions(false)
+		}
+	})
+	popup.ToFront()
+}
+
+// completionsLocation returns the location, in display coordinates, of the top-left corner of the
+// completions popup, which is placed just below the caret.
+func (field *TextField) completionsLocation() geom.Point {
+	frame := field.Window().ContentFrame()
+	bounds := field.LocalBounds()
+	pt := field.ToWindow(geom.Point{X: field.FromSelectionIndex(field.selectionStart).X, Y: bounds.Y + bounds.Height})
+	return geom.Point{X: frame.X + pt.X, Y: frame.Y + pt.Y}
+}
+
+func completionHeight() float64 {
+	return math.Ceil(font.Views.Height()) + 2
+}
+
+// closeCompletions closes the completions popup. If 'refocus' is true, the field's window is
+// brought back to the front, since the popup took the keyboard focus from it.
+func (field *TextField) closeCompletions(refocus bool) {
+	if c := field.completion; c != nil && c.popup != nil {
+		popup := c.popup
+		c.popup = nil
+		c.list = nil
+		popup.Close()
+		if owner := field.Window(); refocus && owner != nil {
+			owner.ToFront()
+		}
+	}
+}
+
+// ghostText returns the inline completion to show after the caret, if it still applies.
+func (field *TextField) ghostText() string {
+	if c := field.completion; c != nil && c.ghost != "" && !field.HasSelectionRange() && field.selectionEnd == len(field.runes) && string(field.runes) == c.prefix {
+		return c.ghost
+	}
+	return ""
+}
+
+// handleCompletionKey performs the completion-related actions for the key. Returns true if the key
+// was used.
+func (field *TextField) handleCompletionKey(e *event.KeyDown) bool {
+	c := field.completion
+	if c == nil {
+		return false
+	}
+	code := e.Code()
+	if c.popup != nil {
+		switch code {
+		case keys.VirtualKeyDown, keys.VirtualKeyNumPadDown:
+			field.moveCompletionSelection(1)
+			return true
+		case keys.VirtualKeyUp, keys.VirtualKeyNumPadUp:
+			field.moveCompletionSelection(-1)
+			return true
+		case keys.VirtualKeyPageDown, keys.VirtualKeyNumPadPageDown:
+			field.moveCompletionSelection(field.Theme.VisibleCompletions)
+			return true
+		case keys.VirtualKeyPageUp, keys.VirtualKeyNumPadPageUp:
+			field.moveCompletionSelection(-field.Theme.VisibleCompletions)
+			return true
+		case keys.VirtualKeyEscape:
+			c.ghost = ""
+			field.closeCompletions(true)
+			field.Repaint()
+			return true
+		case keys.VirtualKeyReturn, keys.VirtualKeyNumPadEnter:
+			return field.acceptSelectedCompletion()
+		}
+	}
+	if code == keys.VirtualKeyTab && e.Modifiers()&keys.NonStickyModifiers == 0 {
+		if field.acceptSelectedCompletion() {
+			return true
+		}
+		if ghost := field.ghostText(); ghost != "" {
+			field.acceptCompletion(c.prefix + ghost)
+			return true
+		}
+	}
+	return false
+}
+
+func (field *TextField) moveCompletionSelection(delta int) {
+	c := field.completion
+	count := len(c.suggestions)
+	if count == 0 {
+		return
+	}
+	index := c.list.Selection.FirstSet()
+	if index == -1 {
+		if delta > 0 {
+			index = 0
+		} else {
+			index = count - 1
+		}
+	} else {
+		index += delta
+	}
+	if index < 0 {
+		index = 0
+	} else if index >= count {
+		index = count - 1
+	}
+	c.list.Select(false, index)
+	c.list.ScrollRowIntoView(index)
+}
+
+// acceptSelectedCompletion accepts the completion selected in the popup. Returns false if there
+// isn't one.
+func (field *TextField) acceptSelectedCompletion() bool {
+	c := field.completion
+	if c == nil || c.popup == nil {
+		return false
+	}
+	index := c.list.Selection.FirstSet()
+	if index < 0 || index >= len(c.suggestions) {
+		return false
+	}
+	field.acceptCompletion(c.suggestions[index])
+	return true
+}
+
+// acceptCompletion replaces the prefix the completions were offered for with 'text'. Nothing is done
+// if the text preceding the caret is no longer that prefix.
+func (field *TextField) acceptCompletion(text string) {
+	prefix := field.completion.prefix
+	field.cancelCompletions()
+	if !field.completionsApply(prefix) {
+		return
+	}
+	replacement := []rune(sanitize(text))
+	field.replaceRunes(0, field.selectionStart, replacement)
+	field.SetSelectionTo(len(replacement))
+	field.notifyOfModification()
+}
+
+// completionsApply returns true if completions offered for 'prefix' still apply, i.e. the caret has
+// no range and 'prefix' is the text preceding it.
+func (field *TextField) completionsApply(prefix string) bool {
+	return !field.HasSelectionRange() && string(field.runes[:field.selectionStart]) == prefix
+}
+
+// cancelCompletions discards the completions, including any still being gathered, since the caret
+// moved or the text was changed other than by typing.
+func (field *TextField) cancelCompletions() {
+	if c := field.completion; c != nil {
+		c.request++
+		if c.ghost != "" {
+			c.ghost = ""
+			field.Repaint()
+		}
+		field.closeCompletions(true)
+	}
+}
+
+// suggestionSource presents the completions in the popup.
+type suggestionSource []string
+
+func (s suggestionSource) RowCount() int {
+	return len(s)
+}
+
+func (s suggestionSource) Row(index int) interface{} {
+	return s[index]
+}
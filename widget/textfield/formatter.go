@@ -0,0 +1,332 @@
+package textfield
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+var (
+	// DefaultSeparators holds the separators used by numeric formatters that don't specify their
+	// own. It is initialized from the user's locale.
+	DefaultSeparators = SeparatorsForLocale(i18n.Locale())
+)
+
+// Formatter converts between the text displayed in a TextField and the value it represents.
+type Formatter interface {
+	// Parse returns the value represented by 'text', or an error describing why it can't be
+	// parsed.
+	Parse(text string) (interface{}, error)
+	// Format returns the text to display for 'value'.
+	Format(value interface{}) string
+}
+
+// Separators holds the characters used to separate the parts of a number.
+type Separators struct {
+	Decimal string // Placed between the whole and fractional parts.
+	Group   string // Placed between each group of three digits in the whole part. May be empty.
+}
+
+// SeparatorsForLocale returns the separators conventionally used with the locale, such as "de_DE"
+// or "en-US".
+func SeparatorsForLocale(locale string) Separators {
+	lang := strings.ToLower(locale)
+	if i := strings.IndexAny(lang, "_-.@"); i != -1 {
+		lang = lang[:i]
+	}
+	switch lang {
+	case "de", "es", "it", "nl", "pt", "id", "tr", "da", "el":
+		return Separators{Decimal: ",", Group: "."}
+	case "fr", "ru", "pl", "cs", "sk", "sv", "fi", "nb", "no", "uk", "hu", "bg":
+		return Separators{Decimal: ",", Group: "\u00a0"}
+	default:
+		return Separators{Decimal: ".", Group: ","}
+	}
+}
+
+func (sep Separators) orDefault() Separators {
+	if sep.Decimal == "" {
+		return DefaultSeparators
+	}
+	return sep
+}
+
+// normalize returns 'text' without spaces or group separators, and with a '.' as the decimal
+// separator.
+func (sep Separators) normalize(text string) string {
+	text = strings.TrimSpace(text)
+	if sep.Group != "" {
+		text = strings.Replace(text, sep.Group, "", -1)
+		if sep.Group == "\u00a0" {
+			// Also accept ordinary spaces, which are far easier to type
+			text = strings.Replace(text, " ", "", -1)
+		}
+	}
+	return strings.Replace(text, sep.Decimal, ".", 1)
+}
+
+// parse returns the number represented by 'text', ignoring group separators. Only finite numbers
+// are accepted.
+func (sep Separators) parse(text string) (float64, error) {
+	value, err := strconv.ParseFloat(sep.normalize(text), 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, errs.New(i18n.Text("Not a valid number"))
+	}
+	return value, nil
+}
+
+// format returns the text for 'value' with 'decimals' fractional digits.
+func (sep Separators) format(value float64, decimals int, grouping bool) string {
+	text := strconv.FormatFloat(math.Abs(value), 'f', decimals, 64)
+	whole := text
+	fraction := ""
+	if i := strings.IndexByte(text, '.'); i != -1 {
+		whole = text[:i]
+		fraction = sep.Decimal + text[i+1:]
+	}
+	if grouping {
+		whole = sep.group(whole)
+	}
+	if value < 0 && strings.Trim(whole+fraction, "0"+sep.Decimal+sep.Group) != "" {
+		whole = "-" + whole
+	}
+	return whole + fraction
+}
+
+// formatInteger returns the text for an integer, given as returned by integerText().
+func (sep Separators) formatInteger(text string, grouping bool) string {
+	if !grouping {
+		return text
+	}
+	if strings.HasPrefix(text, "-") {
+		return "-" + sep.group(text[1:])
+	}
+	return sep.group(text)
+}
+
+// group returns the digits with a group separator between each group of three.
+func (sep Separators) group(digits string) string {
+	if sep.Group == "" || len(digits) <= 3 {
+		return digits
+	}
+	var buffer strings.Builder
+	first := len(digits) % 3
+	if first == 0 {
+		first = 3
+	}
+	buffer.WriteString(digits[:first])
+	for i := first; i < len(digits); i += 3 {
+		buffer.WriteString(sep.Group)
+		buffer.WriteString(digits[i : i+3])
+	}
+	return buffer.String()
+}
+
+// IntFormatter converts between text and int64 values.
+type IntFormatter struct {
+	Separators Separators // If Decimal is empty, DefaultSeparators is used.
+	Grouping   bool       // If true, digits are grouped in threes when formatted.
+}
+
+// Parse implements the Formatter interface. A fractional part is accepted only if it is zero.
+func (f *IntFormatter) Parse(text string) (interface{}, error) {
+	sep := f.Separators.orDefault()
+	text = sep.normalize(text)
+	if i := strings.IndexByte(text, '.'); i != -1 {
+		if strings.Trim(text[i+1:], "0") != "" {
+			if _, err := sep.parse(text); err != nil {
+				return nil, err
+			}
+			return nil, errs.New(i18n.Text("Must be a whole number"))
+		}
+		text = text[:i]
+	}
+	value, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+			return nil, errs.New(i18n.Text("Number is out of range"))
+		}
+		return nil, errs.New(i18n.Text("Not a valid number"))
+	}
+	return value, nil
+}
+
+// Format implements the Formatter interface.
+func (f *IntFormatter) Format(value interface{}) string {
+	if text, ok := integerText(value); ok {
+		return f.Separators.orDefault().formatInteger(text, f.Grouping)
+	}
+	v, ok := toFloat(value)
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	return f.Separators.orDefault().format(math.Trunc(v), 0, f.Grouping)
+}
+
+// FloatFormatter converts between text and float64 values.
+type FloatFormatter struct {
+	Separators Separators // If Decimal is empty, DefaultSeparators is used.
+	Decimals   int        // The number of fractional digits to format. Values are not rounded when parsed.
+	Grouping   bool       // If true, digits are grouped in threes when formatted.
+}
+
+// Parse implements the Formatter interface.
+func (f *FloatFormatter) Parse(text string) (interface{}, error) {
+	value, err := f.Separators.orDefault().parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Format implements the Formatter interface.
+func (f *FloatFormatter) Format(value interface{}) string {
+	v, ok := toFloat(value)
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	return f.Separators.orDefault().format(v, f.Decimals, f.Grouping)
+}
+
+// CurrencyFormatter converts between text and float64 values representing amounts of money.
+type CurrencyFormatter struct {
+	Separators  Separators // If Decimal is empty, DefaultSeparators is used.
+	Symbol      string     // The currency symbol, such as "$" or "€".
+	SymbolAfter bool       // If true, the symbol is placed after the amount rather than before it.
+	Decimals    int        // The number of fractional digits to format.
+}
+
+// NewCurrencyFormatter creates a new currency formatter with two fractional digits.
+func NewCurrencyFormatter(symbol string, symbolAfter bool) *CurrencyFormatter {
+	return &CurrencyFormatter{Symbol: symbol, SymbolAfter: symbolAfter, Decimals: 2}
+}
+
+// Parse implements the Formatter interface. The currency symbol is optional.
+func (f *CurrencyFormatter) Parse(text string) (interface{}, error) {
+	text = strings.TrimSpace(text)
+	negative := false
+	if strings.HasPrefix(text, "-") {
+		negative = true
+		text = strings.TrimSpace(text[1:])
+	}
+	if f.Symbol != "" {
+		text = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(text, f.Symbol), f.Symbol))
+	}
+	value, err := f.Separators.orDefault().parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if negative {
+		value = -value
+	}
+	return value, nil
+}
+
+// Format implements the Formatter interface.
+func (f *CurrencyFormatter) Format(value interface{}) string {
+	v, ok := toFloat(value)
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	amount := f.Separators.orDefault().format(math.Abs(v), f.Decimals, true)
+	if f.SymbolAfter {
+		amount = amount + " " + f.Symbol
+	} else {
+		amount = f.Symbol + amount
+	}
+	if v < 0 {
+		amount = "-" + amount
+	}
+	return amount
+}
+
+// DateFormatter converts between text and time.Time values.
+type DateFormatter struct {
+	Layout   string         // The layout, as used by time.Parse(), such as "2006-01-02".
+	Location *time.Location // The location used when parsing. If nil, time.Local is used.
+}
+
+// Parse implements the Formatter interface.
+func (f *DateFormatter) Parse(text string) (interface{}, error) {
+	loc := f.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	value, err := time.ParseInLocation(f.Layout, strings.TrimSpace(text), loc)
+	if err != nil {
+		return nil, errs.Newf(i18n.Text("Must be a date in the form %s"), f.Layout)
+	}
+	return value, nil
+}
+
+// Format implements the Formatter interface.
+func (f *DateFormatter) Format(value interface{}) string {
+	if t, ok := value.(time.Time); ok {
+		return t.Format(f.Layout)
+	}
+	return fmt.Sprint(value)
+}
+
+// integerText returns the decimal digits of an integer, preceded by '-' if it is negative.
+func integerText(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case int:
+		return strconv.FormatInt(int64(v), 10), true
+	case int8:
+		return strconv.FormatInt(int64(v), 10), true
+	case int16:
+		return strconv.FormatInt(int64(v), 10), true
+	case int32:
+		return strconv.FormatInt(int64(v), 10), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), true
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10), true
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10), true
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	default:
+		return "", false
+	}
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
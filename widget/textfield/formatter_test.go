@@ -0,0 +1,221 @@
+package textfield
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+var (
+	english = Separators{Decimal: ".", Group: ","}
+	german  = Separators{Decimal: ",", Group: "."}
+	french  = Separators{Decimal: ",", Group: "\u00a0"}
+)
+
+func TestSeparatorsForLocale(t *testing.T) {
+	for _, one := range []struct {
+		locale   string
+		expected Separators
+	}{
+		{"en_US", english},
+		{"en-GB", english},
+		{"de_DE.UTF-8", german},
+		{"fr", french},
+		{"", english},
+	} {
+		if sep := SeparatorsForLocale(one.locale); sep != one.expected {
+			t.Errorf("SeparatorsForLocale(%q) = %+v; expected %+v", one.locale, sep, one.expected)
+		}
+	}
+}
+
+func TestIntFormatterParse(t *testing.T) {
+	for _, one := range []struct {
+		sep      Separators
+		text     string
+		expected int64
+		ok       bool
+	}{
+		{english, "0", 0, true},
+		{english, " 1,234 ", 1234, true},
+		{english, "-1,234", -1234, true},
+		{english, "+12", 12, true},
+		{english, "12.000", 12, true},
+		{english, "12.", 12, true},
+		{german, "1.234,0", 1234, true},
+		{french, "1 234", 1234, true},
+		{french, "1\u00a0234", 1234, true},
+		{english, "9223372036854775807", math.MaxInt64, true},
+		{english, "-9223372036854775808", math.MinInt64, true},
+		{english, "9007199254740993", 9007199254740993, true},
+		{english, "9223372036854775808", 0, false},
+		{english, "-9223372036854775809", 0, false},
+		{english, "12.5", 0, false},
+		{english, "1e3", 0, false},
+		{english, "NaN", 0, false},
+		{english, "Inf", 0, false},
+		{english, "abc", 0, false},
+		{english, "", 0, false},
+	} {
+		f := &IntFormatter{Separators: one.sep}
+		value, err := f.Parse(one.text)
+		if !one.ok {
+			if err == nil {
+				t.Errorf("Parse(%q) = %v; expected an error", one.text, value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", one.text, err)
+		} else if value != one.expected {
+			t.Errorf("Parse(%q) = %v; expected %d", one.text, value, one.expected)
+		}
+	}
+}
+
+func TestIntFormatterFormat(t *testing.T) {
+	for _, one := range []struct {
+		sep      Separators
+		grouping bool
+		value    interface{}
+		expected string
+	}{
+		{english, false, 1234, "1234"},
+		{english, true, 1234, "1,234"},
+		{english, true, -1234567, "-1,234,567"},
+		{english, true, 123, "123"},
+		{german, true, int64(9007199254740993), "9.007.199.254.740.993"},
+		{english, false, int64(math.MinInt64), "-9223372036854775808"},
+		{english, false, uint64(math.MaxUint64), "18446744073709551615"},
+		{english, true, 12.7, "12"},
+		{english, false, "text", "text"},
+	} {
+		f := &IntFormatter{Separators: one.sep, Grouping: one.grouping}
+		if text := f.Format(one.value); text != one.expected {
+			t.Errorf("Format(%v) = %q; expected %q", one.value, text, one.expected)
+		}
+	}
+}
+
+func TestFloatFormatter(t *testing.T) {
+	for _, one := range []struct {
+		sep      Separators
+		text     string
+		expected float64
+		ok       bool
+	}{
+		{english, "1,234.5", 1234.5, true},
+		{german, "1.234,5", 1234.5, true},
+		{english, "-0.25", -0.25, true},
+		{english, "1e3", 1000, true},
+		{english, "NaN", 0, false},
+		{english, "nan", 0, false},
+		{english, "Inf", 0, false},
+		{english, "-Infinity", 0, false},
+		{english, "1e400", 0, false},
+		{english, "1..2", 0, false},
+		{english, "", 0, false},
+	} {
+		f := &FloatFormatter{Separators: one.sep}
+		value, err := f.Parse(one.text)
+		if !one.ok {
+			if err == nil {
+				t.Errorf("Parse(%q) = %v; expected an error", one.text, value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", one.text, err)
+		} else if value != one.expected {
+			t.Errorf("Parse(%q) = %v; expected %v", one.text, value, one.expected)
+		}
+	}
+	for _, one := range []struct {
+		sep      Separators
+		decimals int
+		grouping bool
+		value    interface{}
+		expected string
+	}{
+		{english, 2, true, 1234.5, "1,234.50"},
+		{german, 1, true, 1234.56, "1.234,6"},
+		{french, 0, true, 1234567.0, "1\u00a0234\u00a0567"},
+		{english, 2, false, -0.001, "0.00"},
+		{english, 1, false, -2.25, "-2.2"},
+		{english, 0, false, 7, "7"},
+	} {
+		f := &FloatFormatter{Separators: one.sep, Decimals: one.decimals, Grouping: one.grouping}
+		if text := f.Format(one.value); text != one.expected {
+			t.Errorf("Format(%v) = %q; expected %q", one.value, text, one.expected)
+		}
+	}
+}
+
+func TestCurrencyFormatter(t *testing.T) {
+	dollars := NewCurrencyFormatter("$", false)
+	dollars.Separators = english
+	euros := NewCurrencyFormatter("€", true)
+	euros.Separators = german
+	for _, one := range []struct {
+		f        *CurrencyFormatter
+		text     string
+		expected float64
+		ok       bool
+	}{
+		{dollars, "$1,234.50", 1234.5, true},
+		{dollars, "1234.5", 1234.5, true},
+		{dollars, "-$12", -12, true},
+		{dollars, "- $ 12", -12, true},
+		{euros, "1.234,50 €", 1234.5, true},
+		{euros, "-12 €", -12, true},
+		{dollars, "$NaN", 0, false},
+		{dollars, "$", 0, false},
+	} {
+		value, err := one.f.Parse(one.text)
+		if !one.ok {
+			if err == nil {
+				t.Errorf("Parse(%q) = %v; expected an error", one.text, value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", one.text, err)
+		} else if value != one.expected {
+			t.Errorf("Parse(%q) = %v; expected %v", one.text, value, one.expected)
+		}
+	}
+	for _, one := range []struct {
+		f        *CurrencyFormatter
+		value    interface{}
+		expected string
+	}{
+		{dollars, 1234.5, "$1,234.50"},
+		{dollars, -12, "-$12.00"},
+		{euros, 1234.5, "1.234,50 €"},
+		{euros, -0.5, "-0,50 €"},
+	} {
+		if text := one.f.Format(one.value); text != one.expected {
+			t.Errorf("Format(%v) = %q; expected %q", one.value, text, one.expected)
+		}
+	}
+}
+
+func TestDateFormatter(t *testing.T) {
+	f := &DateFormatter{Layout: "2006-01-02", Location: time.UTC}
+	value, err := f.Parse(" 2024-02-29 ")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	expected := time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)
+	if !value.(time.Time).Equal(expected) {
+		t.Errorf("Parse = %v; expected %v", value, expected)
+	}
+	if text := f.Format(expected); text != "2024-02-29" {
+		t.Errorf("Format = %q; expected %q", text, "2024-02-29")
+	}
+	for _, text := range []string{"2023-02-29", "02/29/2024", ""} {
+		if value, err = f.Parse(text); err == nil {
+			t.Errorf("Parse(%q) = %v; expected an error", text, value)
+		}
+	}
+}
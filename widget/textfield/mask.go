@@ -0,0 +1,77 @@
+package textfield
+
+import (
+	"unicode"
+)
+
+// Placeholders that may be used within an input mask. Any other character in a mask is a literal
+// that is inserted automatically.
+const (
+	MaskDigit        = '#' // Accepts a digit.
+	MaskLetter       = 'A' // Accepts a letter.
+	MaskAlphanumeric = '*' // Accepts a letter or digit.
+)
+
+func isMaskPlaceholder(ch rune) bool {
+	return ch == MaskDigit || ch == MaskLetter || ch == MaskAlphanumeric
+}
+
+func maskAccepts(placeholder, ch rune) bool {
+	switch placeholder {
+	case MaskDigit:
+		return unicode.IsDigit(ch)
+	case MaskLetter:
+		return unicode.IsLetter(ch)
+	case MaskAlphanumeric:
+		return unicode.IsLetter(ch) || unicode.IsDigit(ch)
+	default:
+		return false
+	}
+}
+
+// applyMask fits 'text' to 'mask', dropping any characters the mask doesn't accept and inserting
+// its literals. 'caret' is a rune index within 'text'; the equivalent index within the result is
+// returned along with it.
+func applyMask(mask, text []rune, caret int) (result []rune, newCaret int) {
+	result = make([]rune, 0, len(mask))
+	i := 0
+	next := func() {
+		// Skip the literals previously inserted, along with anything else the mask won't accept
+		for i < len(text) && i < len(mask) && !isMaskPlaceholder(mask[i]) && mask[i] == text[i] {
+			i++
+		}
+	}
+	next()
+	for _, m := range mask {
+		if i >= len(text) {
+			break
+		}
+		if !isMaskPlaceholder(m) {
+			result = append(result, m)
+			continue
+		}
+		for i < len(text) && !maskAccepts(m, text[i]) {
+			i++
+			next()
+		}
+		if i < len(text) {
+			result = append(result, text[i])
+			i++
+			if i <= caret {
+				newCaret = len(result)
+			}
+			next()
+		}
+	}
+	return result, newCaret
+}
+
+// maskComplete returns true if every placeholder in 'mask' has been filled in 'text'.
+func maskComplete(mask, text []rune) bool {
+	for i := len(mask) - 1; i >= 0; i-- {
+		if isMaskPlaceholder(mask[i]) {
+			return len(text) > i
+		}
+	}
+	return true
+}
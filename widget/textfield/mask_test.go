@@ -0,0 +1,36 @@
+package textfield
+
+import "testing"
+
+func TestApplyMask(t *testing.T) {
+	for i, one := range []struct {
+		mask     string
+		text     string
+		caret    int
+		result   string
+		newCaret int
+		complete bool
+	}{
+		{"(###) ###-####", "", 0, "", 0, false},
+		{"(###) ###-####", "5551234567", 10, "(555) 123-4567", 14, true},
+		{"(###) ###-####", "5551234567", 3, "(555) 123-4567", 4, true},
+		{"(###) ###-####", "(555) 123", 9, "(555) 123", 9, false},
+		{"(###) ###-####", "(55", 3, "(55", 3, false},
+		{"(###) ###-####", "a5b5c5", 6, "(555", 4, false},
+		{"(###) ###-####", "(555) 123-45678", 15, "(555) 123-4567", 14, true},
+		{"####-##-##", "2024-01-0", 9, "2024-01-0", 9, false},
+		{"####-##-##", "20240102", 8, "2024-01-02", 10, true},
+		{"AA-##", "ab12", 4, "ab-12", 5, true},
+		{"AA-##", "1ab2", 4, "ab-2", 4, false},
+		{"**-**", "a1b2", 4, "a1-b2", 5, true},
+		{"**-**", "a-1-b2", 6, "a1-b2", 5, true},
+	} {
+		result, newCaret := applyMask([]rune(one.mask), []rune(one.text), one.caret)
+		if string(result) != one.result || newCaret != one.newCaret {
+			t.Errorf("%d: applyMask(%q, %q, %d) = %q, %d; expected %q, %d", i, one.mask, one.text, one.caret, string(result), newCaret, one.result, one.newCaret)
+		}
+		if complete := maskComplete([]rune(one.mask), result); complete != one.complete {
+			t.Errorf("%d: maskComplete(%q, %q) = %v; expected %v", i, one.mask, string(result), complete, one.complete)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package textfield
+
+import (
+	"github.com/richardwilkes/toolbox/xmath"
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/draw"
+	"github.com/richardwilkes/ui/widget"
+)
+
+// SearchText implements widget.Searchable. A secure field has no searchable text.
+func (field *TextField) SearchText() string {
+	if field.secure {
+		return ""
+	}
+	return string(field.runes)
+}
+
+// SearchSelection implements widget.Searchable.
+func (field *TextField) SearchSelection() widget.TextRange {
+	return widget.TextRange{Start: field.selectionStart, End: field.selectionEnd}
+}
+
+// SetSearchSelection implements widget.Searchable.
+func (field *TextField) SetSearchSelection(selection widget.TextRange) {
+	field.SetSelection(selection.Start, selection.End)
+}
+
+// SetSearchHighlights implements widget.Searchable.
+func (field *TextField) SetSearchHighlights(ranges []widget.TextRange) {
+	if len(ranges) != 0 || len(field.highlights) != 0 {
+		field.highlights = ranges
+		field.Repaint()
+	}
+}
+
+// ReplaceRanges implements widget.Searchable. The cursor is left after the last replacement.
+func (field *TextField) ReplaceRanges(ranges []widget.TextRange, replacements []string) bool {
+	if field.secure || len(ranges) == 0 || len(ranges) != len(replacements) {
+		return false
+	}
+	length := len(field.runes)
+	runes := make([]rune, 0, length)
+	last := 0
+	for i, one := range ranges {
+		start := xmath.MaxInt(xmath.MinInt(one.Start, length), last)
+		end := xmath.MaxInt(xmath.MinInt(one.End, length), start)
+		runes = append(runes, field.runes[last:start]...)
+		runes = append(runes, []rune(sanitize(replacements[i]))...)
+		last = end
+	}
+	pos := len(runes)
+	field.runes = append(runes, field.runes[last:]...)
+	field.highlights = nil
+	field.setSelection(pos, pos, pos)
+	field.notifyOfModification()
+	return true
+}
+
+func (field *TextField) paintSearchHighlights(gc *draw.Graphics, textTop float64) {
+	if len(field.highlights) == 0 || len(field.preedit) != 0 {
+		return
+	}
+	length := len(field.runes)
+	gc.SetColor(field.Theme.SearchHighlightColor)
+	for _, one := range field.highlights {
+		if one.Start >= length {
+			break
+		}
+		left := field.FromSelectionIndex(one.Start).X
+		right := field.FromSelectionIndex(one.End).X
+		gc.FillRect(geom.Rect{Point: geom.Point{X: left, Y: textTop}, Size: geom.Size{Width: right - left, Height: field.Theme.Font.Height()}})
+	}
+}
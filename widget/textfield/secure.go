@@ -0,0 +1,216 @@
+package textfield
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui/clipboard"
+	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/draw"
+	"github.com/richardwilkes/ui/event"
+)
+
+// bullet is drawn in place of each character of a secure field's content.
+const bullet = "•"
+
+// Secure returns true if the field is in secure mode.
+func (field *TextField) Secure() bool {
+	return field.secure
+}
+
+// SetSecure sets whether the field is in secure mode, as is appropriate for passwords. In secure
+// mode, the content and any text being composed by an input method are drawn as bullets unless
+// they have been revealed, the content can't be copied or cut, it isn't offered as the primary
+// selection, completions are not provided and no edit history is kept. Any selection previously
+// offered as the primary selection is withdrawn. The buffer holding the content is zeroed whenever
+// the content is replaced or cleared, whenever it is outgrown, and when the field's window is
+// closed. There is no accessibility support yet, so the content isn't exposed to assistive
+// technologies in any mode; when such support is added, a secure field must report its content as
+// concealed.
+func (field *TextField) SetSecure(secure bool) {
+	if field.secure != secure {
+		field.secure = secure
+		field.revealed = false
+		if secure {
+			field.closeCompletions(false)
+			if field.completion != nil {
+				field.completion.ghost = ""
+			}
+			wipe(field.runes[len(field.runes):cap(field.runes)])
+			field.withdrawPrimary()
+			wipe(field.historyBase[:cap(field.historyBase)])
+			field.watchForClose()
+		}
+		field.resetHistory()
+		field.autoScroll()
+		field.Repaint()
+	}
+}
+
+// Revealed returns true if a secure field is currently showing its content.
+func (field *TextField) Revealed() bool {
+	return field.revealed
+}
+
+// SetRevealed sets whether a secure field shows its content rather than bullets. Has no effect on
+// a field that isn't secure.
+func (field *TextField) SetRevealed(revealed bool) {
+	if field.secure && field.revealed != revealed {
+		field.revealed = revealed
+		field.autoScroll()
+		field.Repaint()
+	}
+}
+
+// RevealToggle returns true if a secure field shows a button for revealing its content.
+func (field *TextField) RevealToggle() bool {
+	return field.revealToggle
+}
+
+// SetRevealToggle sets whether a secure field shows a button at its trailing edge that the user
+// can click to reveal or conceal its content.
+func (field *TextField) SetRevealToggle(show bool) {
+	if field.revealToggle != show {
+		field.revealToggle = show
+		field.autoScroll()
+		field.Repaint()
+	}
+}
+
+// Clear removes the content of the field, zeroing the buffer that held it.
+func (field *TextField) Clear() {
+	if len(field.runes) != 0 {
+		wipe(field.runes[:cap(field.runes)])
+		field.runes = field.runes[:0]
+		field.SetSelectionToStart()
+		field.notifyOfModification()
+	}
+}
+
+// Dispose zeroes the buffer holding the content of a secure field and empties it, without
+// notifying anyone of the modification. This is done automatically when the field's window is
+// closed.
+func (field *TextField) Dispose() {
+	if field.secure {
+		wipe(field.runes[:cap(field.runes)])
+		wipe(field.preedit)
+		field.runes = nil
+		field.preedit = nil
+		field.selectionStart = 0
+		field.selectionEnd = 0
+		field.selectionAnchor = 0
+		field.scrollOffset = 0
+	}
+}
+
+// watchForClose arranges for the field to be disposed when its window closes.
+func (field *TextField) watchForClose() {
+	if wnd := field.Window(); field.secure && wnd != nil && wnd != field.watched {
+		field.watched = wnd
+		wnd.EventHandlers().Add(event.ClosedType, func(evt event.Event) {
+			if field.watched == wnd {
+				field.Dispose()
+			}
+		})
+	}
+}
+
+// withdrawPrimary clears the primary selection if it still holds the text the field offered.
+func (field *TextField) withdrawPrimary() {
+	if field.offeredPrimary && clipboard.Primary.ChangeCount() == field.primaryChange {
+		clipboard.Primary.Clear()
+	}
+	field.offeredPrimary = false
+}
+
+// discard zeroes a temporary copy of some of the content of a secure field once it is no longer
+// needed.
+func (field *TextField) discard(runes []rune) {
+	if field.secure {
+		wipe(runes)
+	}
+}
+
+// wipe zeroes the runes.
+func wipe(runes []rune) {
+	for i := range runes {
+		runes[i] = 0
+	}
+}
+
+// concealed returns true if the field's content should be drawn as bullets.
+func (field *TextField) concealed() bool {
+	return field.secure && !field.revealed
+}
+
+// shown returns the text to draw for 'runes', which are part of the field's content. Since each
+// character is replaced by a single bullet when the content is concealed, rune indexes into the
+// result match those into the content.
+func (field *TextField) shown(runes []rune) string {
+	if field.concealed() {
+		return strings.Repeat(bullet, len(runes))
+	}
+	return string(runes)
+}
+
+// textBounds returns the area available to the text, which excludes the reveal toggle, if present.
+func (field *TextField) textBounds() geom.Rect {
+	bounds := field.LocalInsetBounds()
+	if field.hasRevealToggle() {
+		bounds.Width -= field.Theme.RevealToggleWidth
+		if bounds.Width < 0 {
+			bounds.Width = 0
+		}
+	}
+	return bounds
+}
+
+func (field *TextField) hasRevealToggle() bool {
+	return field.secure && field.revealToggle
+}
+
+// revealToggleBounds returns the area occupied by the reveal toggle.
+func (field *TextField) revealToggleBounds() geom.Rect {
+	bounds := field.LocalInsetBounds()
+	width := field.Theme.RevealToggleWidth
+	if width > bounds.Width {
+		width = bounds.Width
+	}
+	bounds.X += bounds.Width - width
+	bounds.Width = width
+	return bounds
+}
+
+func (field *TextField) overRevealToggle(where geom.Point) bool {
+	if !field.hasRevealToggle() {
+		return false
+	}
+	bounds := field.revealToggleBounds()
+	return bounds.ContainsPoint(field.FromWindow(where))
+}
+
+// paintRevealToggle draws an eye, which is crossed out while the content is concealed.
+func (field *TextField) paintRevealToggle(gc *draw.Graphics) {
+	bounds := field.revealToggleBounds()
+	size := bounds.Width - 6
+	if size > bounds.Height {
+		size = bounds.Height
+	}
+	if size <= 0 {
+		return
+	}
+	cx := bounds.X + bounds.Width/2
+	cy := bounds.Y + bounds.Height/2
+	if field.Enabled() {
+		gc.SetColor(color.Text)
+	} else {
+		gc.SetColor(color.TextWhenDisabled)
+	}
+	gc.SetStrokeWidth(1)
+	gc.StrokeEllipse(geom.Rect{Point: geom.Point{X: cx - size/2, Y: cy - size/4}, Size: geom.Size{Width: size, Height: size / 2}})
+	pupil := size / 4
+	gc.FillEllipse(geom.Rect{Point: geom.Point{X: cx - pupil/2, Y: cy - pupil/2}, Size: geom.Size{Width: pupil, Height: pupil}})
+	if field.concealed() {
+		gc.StrokeLine(cx-size/2, cy+size/2, cx+size/2, cy-size/2)
+	}
+}
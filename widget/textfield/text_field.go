@@ -6,17 +6,23 @@ import (
 	"strings"
 	"time"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/richardwilkes/toolbox/xmath"
 	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui"
 	"github.com/richardwilkes/ui/clipboard"
 	"github.com/richardwilkes/ui/clipboard/datatypes"
 	"github.com/richardwilkes/ui/color"
 	"github.com/richardwilkes/ui/cursor"
+	"github.com/richardwilkes/ui/draw"
 	"github.com/richardwilkes/ui/event"
 	"github.com/richardwilkes/ui/event/button"
 	"github.com/richardwilkes/ui/keys"
 	"github.com/richardwilkes/ui/layout"
+	"github.com/richardwilkes/ui/menu"
+	"github.com/richardwilkes/ui/menu/editmenu"
+	"github.com/richardwilkes/ui/undo"
 	"github.com/richardwilkes/ui/widget"
 	"github.com/richardwilkes/ui/window"
 )
@@ -30,17 +36,38 @@ type TextField struct {
 	selectionStart  int
 	selectionEnd    int
 	selectionAnchor int
+	preedit         []rune
+	preeditCaret    int
 	forceShowUntil  time.Time
 	scrollOffset    float64
 	showCursor      bool
 	pending         bool
 	extendByWord    bool
 	invalid         bool
+	completion      *completer
+	validators      []Validator
+	formatter       Formatter
+	mask            []rune
+	value           interface{}
+	message         string
+	edited          bool
+	secure          bool
+	revealed        bool
+	revealToggle    bool
+	offeredPrimary  bool
+	primaryChange   int
+	history         *undo.Stack
+	historyBase     []rune // The content as of the most recent edit in the history.
+	typing          bool
+	undoing         bool
+	keepCompletion  bool // True while typing, after which the completions are requested afresh.
+	watched         ui.Window
+	highlights      []widget.TextRange
 }
 
 // New creates a new, empty, text field.
 func New() *TextField {
-	field := &TextField{Theme: StdTheme}
+	field := &TextField{Theme: StdTheme, history: undo.NewStack()}
 	field.InitTypeAndID(field)
 	field.Describer = func() string { return fmt.Sprintf("TextField #%d", field.ID()) }
 	field.SetBackground(color.TextBackground)
@@ -53,9 +80,13 @@ func New() *TextField {
 	handlers.Add(event.FocusGainedType, field.focusGained)
 	handlers.Add(event.FocusLostType, field.focusLost)
 	handlers.Add(event.MouseDownType, field.mouseDown)
+	handlers.Add(event.ContextMenuType, field.contextMenu)
 	handlers.Add(event.MouseDraggedType, field.mouseDragged)
 	handlers.Add(event.KeyDownType, field.keyDown)
+	handlers.Add(event.PreeditType, field.preeditChanged)
 	handlers.Add(event.UpdateCursorType, field.setCursor)
+	handlers.Add(event.ThemeChangedType, field.themeChanged)
+	handlers.Add(event.ToolTipType, field.validationToolTip)
 	return field
 }
 
@@ -73,11 +104,14 @@ func (field *TextField) Sizes(hint geom.Size) (min, pref, max geom.Size) {
 	}
 	var text string
 	if len(field.runes) != 0 {
-		text = string(field.runes)
+		text = field.shown(field.runes)
 	} else {
 		text = "M"
 	}
 	size := field.Theme.Font.Measure(text)
+	if field.hasRevealToggle() {
+		size.Width += field.Theme.RevealToggleWidth
+	}
 	size.GrowToInteger()
 	size.ConstrainForHint(hint)
 	if border := field.Border(); border != nil {
@@ -88,7 +122,7 @@ func (field *TextField) Sizes(hint geom.Size) (min, pref, max geom.Size) {
 
 func (field *TextField) paint(evt event.Event) {
 	if e, ok := evt.(*event.Paint); ok {
-		bounds := field.LocalInsetBounds()
+		bounds := field.textBounds()
 		gc := e.GC()
 		gc.Save()
 		defer gc.Restore()
@@ -99,19 +133,25 @@ func (field *TextField) paint(evt event.Event) {
 			gc.SetColor(field.Theme.DisabledBackgroundColor)
 			gc.FillRect(e.DirtyRect())
 		}
+		if field.hasRevealToggle() {
+			field.paintRevealToggle(gc)
+		}
 		gc.Rect(bounds)
 		gc.Clip()
 		textTop := bounds.Y + (bounds.Height-field.Theme.Font.Height())/2
-		if field.HasSelectionRange() {
+		field.paintSearchHighlights(gc, textTop)
+		if len(field.preedit) != 0 {
+			field.paintPreedit(gc, bounds, textTop)
+		} else if field.HasSelectionRange() {
 			left := bounds.X + field.scrollOffset
 			if field.selectionStart > 0 {
 				gc.SetColor(color.Text)
-				pre := string(field.runes[:field.selectionStart])
+				pre := field.shown(field.runes[:field.selectionStart])
 				gc.DrawString(left, textTop, pre, field.Theme.Font)
 				left += field.Theme.Font.Measure(pre).Width
 			}
-			mid := string(field.runes[field.selectionStart:field.selectionEnd])
-			right := bounds.X + field.Theme.Font.Measure(string(field.runes[:field.selectionEnd])).Width + field.scrollOffset
+			mid := field.shown(field.runes[field.selectionStart:field.selectionEnd])
+			right := bounds.X + field.Theme.Font.Measure(field.shown(field.runes[:field.selectionEnd])).Width + field.scrollOffset
 			selRect := geom.Rect{Point: geom.Point{X: left, Y: textTop}, Size: geom.Size{Width: right - left, Height: field.Theme.Font.Height()}}
 			if field.Focused() {
 				gc.SetColor(color.SelectedTextBackground)
@@ -126,26 +166,31 @@ func (field *TextField) paint(evt event.Event) {
 			gc.DrawString(left, textTop, mid, field.Theme.Font)
 			if field.selectionStart < len(field.runes) {
 				gc.SetColor(color.Text)
-				gc.DrawString(right, textTop, string(field.runes[field.selectionEnd:]), field.Theme.Font)
+				gc.DrawString(right, textTop, field.shown(field.runes[field.selectionEnd:]), field.Theme.Font)
 			}
 		} else if len(field.runes) == 0 {
 			if field.watermark != "" {
-				gc.SetColor(color.Gray)
+				gc.SetColor(color.TextWhenDisabled)
 				gc.DrawString(bounds.X, textTop, field.watermark, field.Theme.Font)
 			}
 		} else {
 			gc.SetColor(color.Text)
-			gc.DrawString(bounds.X+field.scrollOffset, textTop, string(field.runes), field.Theme.Font)
+			text := field.shown(field.runes)
+			gc.DrawString(bounds.X+field.scrollOffset, textTop, text, field.Theme.Font)
+			if ghost := field.ghostText(); ghost != "" {
+				gc.SetColor(color.TextWhenDisabled)
+				gc.DrawString(bounds.X+field.scrollOffset+field.Theme.Font.Measure(text).Width, textTop, ghost, field.Theme.Font)
+			}
 		}
-		if !field.HasSelectionRange() && field.Focused() {
+		if (!field.HasSelectionRange() || len(field.preedit) != 0) && field.Focused() {
 			if field.showCursor {
 				var cursorColor color.Color
 				if field.Background().Luminance() > 0.6 {
-					cursorColor = color.Black
+					cursorColor = color.TextWhenLight
 				} else {
-					cursorColor = color.White
+					cursorColor = color.TextWhenDark
 				}
-				x := bounds.X + field.Theme.Font.Measure(string(field.runes[:field.selectionEnd])).Width + field.scrollOffset
+				x := field.caretX()
 				gc.SetColor(cursorColor)
 				gc.StrokeLine(x, textTop, x, textTop+field.Theme.Font.Height()-1)
 			}
@@ -154,9 +199,59 @@ func (field *TextField) paint(evt event.Event) {
 	}
 }
 
+// paintPreedit draws the text being composed by an input method in place of the current
+// selection, underlined to distinguish it from the committed text.
+func (field *TextField) paintPreedit(gc *draw.Graphics, bounds geom.Rect, textTop float64) {
+	left := bounds.X + field.scrollOffset
+	gc.SetColor(color.Text)
+	if field.selectionStart > 0 {
+		pre := field.shown(field.runes[:field.selectionStart])
+		gc.DrawString(left, textTop, pre, field.Theme.Font)
+		left += field.Theme.Font.Measure(pre).Width
+	}
+	composing := field.shown(field.preedit)
+	gc.DrawString(left, textTop, composing, field.Theme.Font)
+	width := field.Theme.Font.Measure(composing).Width
+	y := textTop + field.Theme.Font.Ascent() + 1.5
+	gc.SetStrokeWidth(1)
+	gc.StrokeLine(left, y, left+width, y)
+	if field.selectionEnd < len(field.runes) {
+		gc.DrawString(left+width, textTop, field.shown(field.runes[field.selectionEnd:]), field.Theme.Font)
+	}
+}
+
+// caretX returns the horizontal position of the caret in local coordinates.
+func (field *TextField) caretX() float64 {
+	text := field.shown(field.runes[:field.selectionEnd])
+	if len(field.preedit) != 0 {
+		text = field.shown(field.runes[:field.selectionStart]) + field.shown(field.preedit[:field.preeditCaret])
+	}
+	return field.textBounds().X + field.Theme.Font.Measure(text).Width + field.scrollOffset
+}
+
+// CaretRect implements ui.CaretLocator.
+func (field *TextField) CaretRect() geom.Rect {
+	bounds := field.LocalInsetBounds()
+	height := field.Theme.Font.Height()
+	return geom.Rect{Point: geom.Point{X: field.caretX(), Y: bounds.Y + (bounds.Height-height)/2}, Size: geom.Size{Width: 1, Height: height}}
+}
+
+func (field *TextField) preeditChanged(evt event.Event) {
+	if e, ok := evt.(*event.Preedit); ok {
+		field.discard(field.preedit)
+		field.preedit = []rune(e.Text())
+		field.preeditCaret = xmath.MinInt(xmath.MaxInt(e.Caret(), 0), len(field.preedit))
+		field.showCursor = true
+		field.Repaint()
+		evt.Finish()
+	}
+}
+
 func (field *TextField) scheduleBlink() {
 	window := field.Window()
-	if window.Valid() && !field.pending && field.Focused() {
+	if field.Theme.BlinkRate <= 0 {
+		field.showCursor = true
+	} else if window.Valid() && !field.pending && field.Focused() {
 		field.pending = true
 		window.InvokeAfter(field.blink, field.Theme.BlinkRate)
 	}
@@ -174,20 +269,37 @@ func (field *TextField) blink() {
 }
 
 func (field *TextField) focusGained(evt event.Event) {
+	field.watchForClose()
 	field.SetBorder(field.Theme.FocusBorder)
 	field.showCursor = true
 	field.Repaint()
 }
 
 func (field *TextField) focusLost(evt event.Event) {
+	field.discard(field.preedit)
+	field.preedit = nil
+	field.closeCompletions(false)
+	field.commitIfEdited()
 	field.SetBorder(field.Theme.Border)
 	field.Repaint()
 }
 
+func (field *TextField) themeChanged(evt event.Event) {
+	field.SetBackground(color.TextBackground)
+	if field.Focused() {
+		field.SetBorder(field.Theme.FocusBorder)
+	} else {
+		field.SetBorder(field.Theme.Border)
+	}
+}
+
 func (field *TextField) mouseDown(evt event.Event) {
+	field.cancelCompletions()
 	field.Window().SetFocus(field)
 	if e, ok := evt.(*event.MouseDown); ok {
-		if e.Button() == button.Left {
+		if e.Button() == button.Left && field.overRevealToggle(e.Where()) {
+			field.SetRevealed(!field.revealed)
+		} else if e.Button() == button.Left {
 			field.extendByWord = false
 			switch e.Clicks() {
 			case 2:
@@ -214,12 +326,16 @@ func (field *TextField) mouseDown(evt event.Event) {
 				}
 				field.setSelection(start, end, field.selectionAnchor)
 			}
-		} else if e.Button() == button.Right {
-			fmt.Println("right click")
+		} else if e.Button() == button.Middle {
+			field.pastePrimaryAt(field.ToSelectionIndex(field.FromWindow(e.Where()).X))
 		}
 	}
 }
 
+func (field *TextField) contextMenu(evt event.Event) {
+	editmenu.AppendContextMenuItems(menu.ForContextMenu(evt.(*event.ContextMenu)))
+}
+
 func (field *TextField) mouseDragged(evt event.Event) {
 	oldAnchor := field.selectionAnchor
 	pos := field.ToSelectionIndex(field.FromWindow(evt.(*event.MouseDragged).Where()).X)
@@ -265,18 +381,31 @@ func (field *TextField) mouseDragged(evt event.Event) {
 func (field *TextField) keyDown(evt event.Event) {
 	window.HideCursorUntilMouseMoves()
 	if e, ok := evt.(*event.KeyDown); ok {
+		if field.handleCompletionKey(e) {
+			evt.Finish()
+			return
+		}
 		code := e.Code()
 		switch code {
+		case keys.VirtualKeyReturn, keys.VirtualKeyNumPadEnter:
+			// Not finished, so that the key may still trigger a default button
+			field.commitIfEdited()
 		case keys.VirtualKeyBackspace:
+			field.keepCompletion = true
 			field.Delete()
+			field.keepCompletion = false
+			field.requestCompletions(false)
 			evt.Finish()
 		case keys.VirtualKeyDelete, keys.VirtualKeyNumPadDelete:
+			field.keepCompletion = true
 			if field.HasSelectionRange() {
 				field.Delete()
 			} else if field.selectionStart < len(field.runes) {
-				field.runes = append(field.runes[:field.selectionStart], field.runes[field.selectionStart+1:]...)
+				field.replaceRunes(field.selectionStart, field.selectionStart+1, nil)
 				field.notifyOfModification()
 			}
+			field.keepCompletion = false
+			field.requestCompletions(false)
 			evt.Finish()
 			field.Repaint()
 		case keys.VirtualKeyLeft, keys.VirtualKeyNumPadLeft:
@@ -304,12 +433,16 @@ func (field *TextField) keyDown(evt event.Event) {
 		default:
 			r := e.Rune()
 			if !unicode.IsControl(r) {
-				if field.HasSelectionRange() {
-					field.runes = append(field.runes[:field.selectionStart], field.runes[field.selectionEnd:]...)
-				}
-				field.runes = append(field.runes[:field.selectionStart], append([]rune{r}, field.runes[field.selectionStart:]...)...)
+				typed := []rune{r}
+				field.keepCompletion = true
+				field.typing = true
+				field.replaceRunes(field.selectionStart, field.selectionEnd, typed)
+				field.discard(typed)
 				field.SetSelectionTo(field.selectionStart + 1)
 				field.notifyOfModification()
+				field.typing = false
+				field.keepCompletion = false
+				field.requestCompletions(true)
 				evt.Finish()
 			}
 		}
@@ -409,28 +542,80 @@ func (field *TextField) Text() string {
 	return string(field.runes)
 }
 
-// SetText sets the content of the field. Returns true if a modification was made.
+// SetText sets the content of the field, discarding its edit history. Returns true if a
+// modification was made.
 func (field *TextField) SetText(text string) bool {
 	text = sanitize(text)
 	if string(field.runes) != text {
+		if field.secure {
+			wipe(field.runes[:cap(field.runes)])
+		}
 		field.runes = ([]rune)(text)
 		field.SetSelectionToEnd()
 		field.notifyOfModification()
+		field.resetHistory()
 		return true
 	}
 	return false
 }
 
+// replaceRunes replaces the content between 'start' and 'end' with 'runes'. When the buffer must
+// grow, a new one is allocated and, if the field is secure, the old one is zeroed, so that no
+// copies of the content are left behind.
+func (field *TextField) replaceRunes(start, end int, runes []rune) {
+	length := len(field.runes)
+	newLength := length - (end - start) + len(runes)
+	if newLength > cap(field.runes) {
+		buffer := make([]rune, newLength, newLength*2)
+		copy(buffer, field.runes[:start])
+		copy(buffer[start:], runes)
+		copy(buffer[start+len(runes):], field.runes[end:])
+		if field.secure {
+			wipe(field.runes[:cap(field.runes)])
+		}
+		field.runes = buffer
+		return
+	}
+	field.runes = field.runes[:newLength]
+	copy(field.runes[start+len(runes):], field.runes[end:length])
+	copy(field.runes[start:], runes)
+}
+
 func (field *TextField) notifyOfModification() {
+	if len(field.mask) != 0 {
+		var caret int
+		previous := field.runes
+		field.runes, caret = applyMask(field.mask, field.runes, field.selectionStart)
+		field.discard(previous[:cap(previous)])
+		field.setSelection(caret, caret, caret)
+	}
+	if field.secure {
+		// Editing shifts characters down within the buffer, leaving copies beyond its end
+		wipe(field.runes[len(field.runes):cap(field.runes)])
+	}
+	field.recordEdit()
+	if !field.keepCompletion {
+		field.cancelCompletions()
+	}
+	field.edited = true
 	field.Repaint()
 	event.Dispatch(event.NewModified(field))
-	ve := event.NewValidate(field)
-	event.Dispatch(ve)
-	invalid := !ve.Valid()
-	if invalid != field.invalid {
-		field.invalid = invalid
-		field.Repaint()
+	field.revalidate()
+}
+
+// runesFromData returns the runes of the UTF-8 text, without any line endings. The text is decoded
+// directly, rather than through strings that could not be zeroed. The data itself belongs to the
+// clipboard and is left alone.
+func runesFromData(data []byte) []rune {
+	runes := make([]rune, 0, utf8.RuneCount(data))
+	for i := 0; i < len(data); {
+		r, size := utf8.DecodeRune(data[i:])
+		if r != '\n' && r != '\r' {
+			runes = append(runes, r)
+		}
+		i += size
 	}
+	return runes
 }
 
 func sanitize(text string) string {
@@ -515,16 +700,27 @@ func (field *TextField) setSelection(start, end, anchor int) {
 		field.selectionStart = start
 		field.selectionEnd = end
 		field.selectionAnchor = anchor
+		if !field.keepCompletion {
+			field.cancelCompletions()
+		}
+		if !field.typing {
+			field.history.Seal()
+		}
 		field.forceShowUntil = time.Now().Add(field.Theme.BlinkRate)
 		field.showCursor = true
 		field.Repaint()
 		field.ScrollIntoView()
 		field.autoScroll()
+		if start != end && !field.secure {
+			clipboard.Primary.SetData(datatypes.Data{MimeType: datatypes.PlainText, Bytes: []byte(field.SelectedText())})
+			field.offeredPrimary = true
+			field.primaryChange = clipboard.Primary.ChangeCount()
+		}
 	}
 }
 
 func (field *TextField) autoScroll() {
-	bounds := field.LocalInsetBounds()
+	bounds := field.textBounds()
 	if bounds.Width > 0 {
 		original := field.scrollOffset
 		if field.selectionStart == field.selectionAnchor {
@@ -570,13 +766,13 @@ func (field *TextField) autoScroll() {
 
 // ToSelectionIndex returns the rune index for the specified x-coordinate.
 func (field *TextField) ToSelectionIndex(x float64) int {
-	bounds := field.LocalInsetBounds()
-	return field.Theme.Font.IndexForPosition(x-(bounds.X+field.scrollOffset), string(field.runes))
+	bounds := field.textBounds()
+	return field.Theme.Font.IndexForPosition(x-(bounds.X+field.scrollOffset), field.shown(field.runes))
 }
 
 // FromSelectionIndex returns a location in local coordinates for the specified rune index.
 func (field *TextField) FromSelectionIndex(index int) geom.Point {
-	bounds := field.LocalInsetBounds()
+	bounds := field.textBounds()
 	x := bounds.X + field.scrollOffset
 	top := bounds.Y + bounds.Height/2
 	if index > 0 {
@@ -584,13 +780,17 @@ func (field *TextField) FromSelectionIndex(index int) geom.Point {
 		if index > length {
 			index = length
 		}
-		x += field.Theme.Font.PositionForIndex(index, string(field.runes))
+		x += field.Theme.Font.PositionForIndex(index, field.shown(field.runes))
 	}
 	return geom.Point{X: x, Y: top}
 }
 
 func (field *TextField) findWordAt(pos int) (start, end int) {
 	length := len(field.runes)
+	if field.secure {
+		// Word boundaries would reveal where the spaces are
+		return 0, length
+	}
 	if pos < 0 {
 		pos = 0
 	} else if pos >= length {
@@ -609,14 +809,14 @@ func (field *TextField) findWordAt(pos int) (start, end int) {
 	return start, end
 }
 
-// CanCut returns true if the field has a selection that can be cut.
+// CanCut returns true if the field has a selection that can be cut. Secure fields never do.
 func (field *TextField) CanCut() bool {
-	return field.HasSelectionRange()
+	return field.HasSelectionRange() && !field.secure
 }
 
 // Cut the selected text to the clipboard.
 func (field *TextField) Cut() {
-	if field.HasSelectionRange() {
+	if field.CanCut() {
 		clipboard.SetData(datatypes.Data{MimeType: datatypes.PlainText, Bytes: []byte(field.SelectedText())})
 		field.Delete()
 	}
@@ -631,10 +831,10 @@ func (field *TextField) CanDelete() bool {
 func (field *TextField) Delete() {
 	if field.CanDelete() {
 		if field.HasSelectionRange() {
-			field.runes = append(field.runes[:field.selectionStart], field.runes[field.selectionEnd:]...)
+			field.replaceRunes(field.selectionStart, field.selectionEnd, nil)
 			field.SetSelectionTo(field.selectionStart)
 		} else {
-			field.runes = append(field.runes[:field.selectionStart-1], field.runes[field.selectionStart:]...)
+			field.replaceRunes(field.selectionStart-1, field.selectionStart, nil)
 			field.SetSelectionTo(field.selectionStart - 1)
 		}
 		field.notifyOfModification()
@@ -642,14 +842,14 @@ func (field *TextField) Delete() {
 	}
 }
 
-// CanCopy returns true if the field has a selection that can be copied.
+// CanCopy returns true if the field has a selection that can be copied. Secure fields never do.
 func (field *TextField) CanCopy() bool {
-	return field.HasSelectionRange()
+	return field.HasSelectionRange() && !field.secure
 }
 
 // Copy the selected text to the clipboard.
 func (field *TextField) Copy() {
-	if field.HasSelectionRange() {
+	if field.CanCopy() {
 		clipboard.SetData(datatypes.Data{MimeType: datatypes.PlainText, Bytes: []byte(field.SelectedText())})
 	}
 }
@@ -662,12 +862,9 @@ func (field *TextField) CanPaste() bool {
 // Paste any text on the clipboard into the field.
 func (field *TextField) Paste() {
 	if clipboard.HasType(datatypes.PlainText) {
-		text := sanitize(string(clipboard.Data(datatypes.PlainText)))
-		runes := ([]rune)(text)
-		if field.HasSelectionRange() {
-			field.runes = append(field.runes[:field.selectionStart], field.runes[field.selectionEnd:]...)
-		}
-		field.runes = append(field.runes[:field.selectionStart], append(runes, field.runes[field.selectionStart:]...)...)
+		runes := runesFromData(clipboard.Data(datatypes.PlainText))
+		field.replaceRunes(field.selectionStart, field.selectionEnd, runes)
+		field.discard(runes)
 		field.SetSelectionTo(field.selectionStart + len(runes))
 		field.notifyOfModification()
 	} else if field.HasSelectionRange() {
@@ -675,6 +872,29 @@ func (field *TextField) Paste() {
 	}
 }
 
+// pastePrimaryAt inserts the contents of the primary selection at the specified rune index. The
+// primary selection is retrieved asynchronously, as it is typically owned by another application.
+func (field *TextField) pastePrimaryAt(pos int) {
+	if !field.Enabled() {
+		return
+	}
+	clipboard.Primary.DataAsync(datatypes.PlainText, func(data []byte, err error) {
+		if err != nil || len(data) == 0 {
+			return
+		}
+		runes := runesFromData(data)
+		if pos < 0 {
+			pos = 0
+		} else if pos > len(field.runes) {
+			pos = len(field.runes)
+		}
+		field.replaceRunes(pos, pos, runes)
+		field.discard(runes)
+		field.SetSelectionTo(pos + len(runes))
+		field.notifyOfModification()
+	})
+}
+
 // CanSelectAll returns true if the field's selection can be expanded.
 func (field *TextField) CanSelectAll() bool {
 	return field.selectionStart != 0 || field.selectionEnd != len(field.runes)
@@ -687,7 +907,9 @@ func (field *TextField) SelectAll() {
 
 func (field *TextField) setCursor(evt event.Event) {
 	var c *cursor.Cursor
-	if field.Enabled() {
+	if field.overRevealToggle(evt.(*event.UpdateCursor).Where()) {
+		c = cursor.Arrow
+	} else if field.Enabled() {
 		c = cursor.Text
 	} else {
 		c = cursor.Arrow
@@ -7,6 +7,8 @@ import (
 	"github.com/richardwilkes/ui/border"
 	"github.com/richardwilkes/ui/color"
 	"github.com/richardwilkes/ui/font"
+	"github.com/richardwilkes/ui/theme"
+	"github.com/richardwilkes/ui/window"
 )
 
 var (
@@ -14,15 +16,22 @@ var (
 	StdTheme = NewTheme()
 )
 
+func init() {
+	theme.RegisterInitializer(func() { StdTheme.Init() })
+}
+
 // Theme contains the theme elements for TextFields.
 type Theme struct {
 	Font                    *font.Font    // The font to use.
 	Border                  border.Border // The border to use when not focused.
 	FocusBorder             border.Border // The border to use when focused.
-	BlinkRate               time.Duration // The rate at which the cursor blinks.
+	BlinkRate               time.Duration // The rate at which the cursor blinks. Zero or less disables blinking.
 	MinimumTextWidth        float64       // The minimum space to permit for text.
 	DisabledBackgroundColor color.Color   // The color to use for the background when disabled.
 	InvalidBackgroundColor  color.Color   // The color to use for the background when marked invalid.
+	VisibleCompletions      int           // The maximum number of completions visible in the popup at one time.
+	RevealToggleWidth       float64       // The width of the button that reveals the content of a secure field.
+	SearchHighlightColor    color.Color   // The background color of text matching a search.
 }
 
 // NewTheme creates a new TextField theme.
@@ -35,10 +44,13 @@ func NewTheme() *Theme {
 // Init initializes the theme with its default values.
 func (theme *Theme) Init() {
 	theme.Font = font.User
-	theme.Border = border.NewCompound(border.NewLine(color.Background.AdjustBrightness(-0.25), geom.NewUniformInsets(1)), border.NewEmpty(geom.Insets{Top: 1, Left: 4, Bottom: 1, Right: 4}))
+	theme.Border = border.NewCompound(border.NewLine(color.Divider, geom.NewUniformInsets(1)), border.NewEmpty(geom.Insets{Top: 1, Left: 4, Bottom: 1, Right: 4}))
 	theme.FocusBorder = border.NewCompound(border.NewLine(color.KeyboardFocus, geom.NewUniformInsets(2)), border.NewEmpty(geom.Insets{Top: 0, Left: 3, Bottom: 0, Right: 3}))
-	theme.BlinkRate = time.Millisecond * 560
+	theme.BlinkRate = window.CursorBlinkRate
 	theme.MinimumTextWidth = 10
 	theme.DisabledBackgroundColor = color.Background
-	theme.InvalidBackgroundColor = color.RGB(255, 232, 232)
+	theme.InvalidBackgroundColor = color.InvalidTextBackground
+	theme.VisibleCompletions = 8
+	theme.RevealToggleWidth = 20
+	theme.SearchHighlightColor = color.Yellow.SetAlphaIntensity(0.5)
 }
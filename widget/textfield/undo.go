@@ -0,0 +1,116 @@
+package textfield
+
+import (
+	"github.com/richardwilkes/ui/undo"
+)
+
+// textEdit records a change to the content of a field: the runes 'removed' starting at 'start'
+// were replaced by 'inserted'.
+type textEdit struct {
+	field    *TextField
+	start    int
+	removed  []rune
+	inserted []rune
+	typing   bool
+}
+
+// Undo implements undo.Edit. The restored text is left selected.
+func (edit *textEdit) Undo() {
+	edit.field.applyEdit(edit.start, edit.inserted, edit.removed)
+}
+
+// Redo implements undo.Edit.
+func (edit *textEdit) Redo() {
+	edit.field.applyEdit(edit.start, edit.removed, edit.inserted)
+}
+
+// Merge implements undo.Merger, so that characters typed one after another are undone together.
+func (edit *textEdit) Merge(next undo.Edit) bool {
+	other, ok := next.(*textEdit)
+	if !ok || !edit.typing || !other.typing || len(other.removed) != 0 || other.start != edit.start+len(edit.inserted) {
+		return false
+	}
+	edit.inserted = append(edit.inserted, other.inserted...)
+	return true
+}
+
+// CanUndo returns true if there is an edit to undo. Secure fields keep no history.
+func (field *TextField) CanUndo() bool {
+	return !field.secure && field.history.CanUndo()
+}
+
+// Undo reverts the most recent edit to the field's content.
+func (field *TextField) Undo() {
+	if field.CanUndo() {
+		field.history.Undo()
+	}
+}
+
+// CanRedo returns true if there is an undone edit to make again.
+func (field *TextField) CanRedo() bool {
+	return !field.secure && field.history.CanRedo()
+}
+
+// Redo makes the most recently undone edit to the field's content again.
+func (field *TextField) Redo() {
+	if field.CanRedo() {
+		field.history.Redo()
+	}
+}
+
+// applyEdit replaces 'old' at 'start' with 'text' on behalf of an undo or redo, selecting 'text'.
+func (field *TextField) applyEdit(start int, old, text []rune) {
+	field.replaceRunes(start, start+len(old), text)
+	field.setSelection(start, start+len(text), start)
+	field.undoing = true
+	field.notifyOfModification()
+	field.undoing = false
+}
+
+// recordEdit adds the difference between the content as it was last recorded and as it is now to
+// the history, as a single edit.
+func (field *TextField) recordEdit() {
+	if field.secure {
+		return
+	}
+	edit, changed := diffRunes(field.historyBase, field.runes)
+	if !changed {
+		return
+	}
+	if !field.undoing {
+		edit.field = field
+		edit.typing = field.typing
+		field.history.Add(edit)
+	}
+	field.historyBase = append(field.historyBase[:0], field.runes...)
+}
+
+// diffRunes returns the edit that turns 'base' into 'runes', which spans everything between their
+// common prefix and common suffix. Returns false if they are the same.
+func diffRunes(base, runes []rune) (*textEdit, bool) {
+	prefix := 0
+	for prefix < len(base) && prefix < len(runes) && base[prefix] == runes[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(base)-prefix && suffix < len(runes)-prefix && base[len(base)-1-suffix] == runes[len(runes)-1-suffix] {
+		suffix++
+	}
+	if prefix == len(base) && prefix == len(runes) {
+		return nil, false
+	}
+	return &textEdit{
+		start:    prefix,
+		removed:  append([]rune(nil), base[prefix:len(base)-suffix]...),
+		inserted: append([]rune(nil), runes[prefix:len(runes)-suffix]...),
+	}, true
+}
+
+// resetHistory discards the edit history, starting afresh from the current content.
+func (field *TextField) resetHistory() {
+	field.history.Clear()
+	field.historyBase = field.historyBase[:0]
+	if !field.secure {
+		field.historyBase = append(field.historyBase, field.runes...)
+	}
+}
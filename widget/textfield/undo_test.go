@@ -0,0 +1,78 @@
+package textfield
+
+import "testing"
+
+func TestDiffRunes(t *testing.T) {
+	for i, one := range []struct {
+		base     string
+		text     string
+		changed  bool
+		start    int
+		removed  string
+		inserted string
+	}{
+		{"", "", false, 0, "", ""},
+		{"abc", "abc", false, 0, "", ""},
+		{"", "xyz", true, 0, "", "xyz"},
+		{"xyz", "", true, 0, "xyz", ""},
+		{"ac", "abc", true, 1, "", "b"},
+		{"abc", "ac", true, 1, "b", ""},
+		{"abc", "abcd", true, 3, "", "d"},
+		{"abc", "zabc", true, 0, "", "z"},
+		{"aaa", "aaaa", true, 3, "", "a"},
+		{"abab", "ab", true, 2, "ab", ""},
+		{"abc", "axc", true, 1, "b", "x"},
+		{"one two one", "1 two 1", true, 0, "one two one", "1 two 1"},
+		{"a-b-a-c", "x-b-x-c", true, 0, "a-b-a", "x-b-x"},
+		{"héllo wörld", "héllo world", true, 7, "ö", "o"},
+	} {
+		edit, changed := diffRunes([]rune(one.base), []rune(one.text))
+		if changed != one.changed {
+			t.Errorf("%d: changed = %v, expected %v", i, changed, one.changed)
+			continue
+		}
+		if !changed {
+			continue
+		}
+		if edit.start != one.start || string(edit.removed) != one.removed || string(edit.inserted) != one.inserted {
+			t.Errorf("%d: got (%d, %q, %q), expected (%d, %q, %q)", i, edit.start, string(edit.removed), string(edit.inserted), one.start, one.removed, one.inserted)
+		}
+		// Applying the edit to the base must give the text, and reverting it must give the base
+		base := []rune(one.base)
+		text := append(append(append([]rune(nil), base[:edit.start]...), edit.inserted...), base[edit.start+len(edit.removed):]...)
+		if string(text) != one.text {
+			t.Errorf("%d: applying the edit gave %q, expected %q", i, string(text), one.text)
+		}
+		reverted := append(append(append([]rune(nil), text[:edit.start]...), edit.removed...), text[edit.start+len(edit.inserted):]...)
+		if string(reverted) != one.base {
+			t.Errorf("%d: reverting the edit gave %q, expected %q", i, string(reverted), one.base)
+		}
+	}
+}
+
+func TestTextEditMerge(t *testing.T) {
+	for i, one := range []struct {
+		first    textEdit
+		next     textEdit
+		merged   bool
+		inserted string
+	}{
+		{textEdit{start: 0, inserted: []rune("a"), typing: true}, textEdit{start: 1, inserted: []rune("b"), typing: true}, true, "ab"},
+		{textEdit{start: 2, inserted: []rune("ab"), typing: true}, textEdit{start: 4, inserted: []rune("c"), typing: true}, true, "abc"},
+		{textEdit{start: 2, removed: []rune("xyz"), inserted: []rune("a"), typing: true}, textEdit{start: 3, inserted: []rune("b"), typing: true}, true, "ab"},
+		{textEdit{start: 0, inserted: []rune("a")}, textEdit{start: 1, inserted: []rune("b"), typing: true}, false, "a"},
+		{textEdit{start: 0, inserted: []rune("a"), typing: true}, textEdit{start: 1, inserted: []rune("b")}, false, "a"},
+		{textEdit{start: 0, inserted: []rune("a"), typing: true}, textEdit{start: 0, inserted: []rune("b"), typing: true}, false, "a"},
+		{textEdit{start: 0, inserted: []rune("a"), typing: true}, textEdit{start: 2, inserted: []rune("b"), typing: true}, false, "a"},
+		{textEdit{start: 0, inserted: []rune("a"), typing: true}, textEdit{start: 1, removed: []rune("x"), inserted: []rune("b"), typing: true}, false, "a"},
+	} {
+		first := one.first
+		removed := string(first.removed)
+		if merged := first.Merge(&one.next); merged != one.merged {
+			t.Errorf("%d: Merge() = %v, expected %v", i, merged, one.merged)
+		}
+		if first.start != one.first.start || string(first.removed) != removed || string(first.inserted) != one.inserted {
+			t.Errorf("%d: got (%d, %q, %q), expected (%d, %q, %q)", i, first.start, string(first.removed), string(first.inserted), one.first.start, removed, one.inserted)
+		}
+	}
+}
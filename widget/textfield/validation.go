@@ -0,0 +1,166 @@
+package textfield
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/widget/tooltip"
+)
+
+// AddValidator adds a validator that the field's content must satisfy. Validators are consulted in
+// the order they were added, and the message from the first one that fails is shown as the
+// field's tooltip.
+func (field *TextField) AddValidator(validator Validator) {
+	field.validators = append(field.validators, validator)
+	field.revalidate()
+}
+
+// Formatter returns the formatter used to convert between the field's text and its value, or nil.
+func (field *TextField) Formatter() Formatter {
+	return field.formatter
+}
+
+// SetFormatter sets the formatter used to convert between the field's text and its value. Text
+// that the formatter can't parse marks the field invalid.
+func (field *TextField) SetFormatter(formatter Formatter) {
+	field.formatter = formatter
+	field.revalidate()
+}
+
+// Mask returns the input mask, or an empty string if there isn't one.
+func (field *TextField) Mask() string {
+	return string(field.mask)
+}
+
+// SetMask sets the input mask, such as "(###) ###-####" or "####-##-##". Within a mask, MaskDigit,
+// MaskLetter and MaskAlphanumeric are placeholders for the characters the user types. All other
+// characters are literals that are inserted automatically. Typed characters the mask doesn't accept
+// are dropped, and the field is marked invalid until every placeholder has been filled.
+func (field *TextField) SetMask(mask string) {
+	field.mask = []rune(mask)
+	if len(field.mask) != 0 {
+		field.runes, _ = applyMask(field.mask, field.runes, len(field.runes))
+		field.SetSelectionToEnd()
+		field.resetHistory()
+		field.Repaint()
+	}
+	field.revalidate()
+}
+
+// Invalid returns true if the field's content has been marked invalid.
+func (field *TextField) Invalid() bool {
+	return field.invalid
+}
+
+// ValidationMessage returns the message describing why the field's content is invalid, if one is
+// available.
+func (field *TextField) ValidationMessage() string {
+	return field.message
+}
+
+// Value returns the value most recently committed or set.
+func (field *TextField) Value() interface{} {
+	return field.value
+}
+
+// SetValue sets the field's value, displaying it with the formatter, if there is one.
+func (field *TextField) SetValue(value interface{}) {
+	var text string
+	if field.formatter != nil {
+		text = field.formatter.Format(value)
+	} else if value != nil {
+		text = fmt.Sprint(value)
+	}
+	field.SetText(text)
+	field.value = value
+	field.edited = false
+}
+
+// Commit makes the field's content its value, provided it is valid, and dispatches a Commit event.
+// When there is a formatter, the text is reformatted from the value. This happens automatically
+// when the user presses Enter or moves the focus elsewhere after editing the field. Returns true if
+// the content was committed.
+func (field *TextField) Commit() bool {
+	if field.invalid {
+		return false
+	}
+	value, message := field.parse()
+	if message != "" {
+		return false
+	}
+	if field.formatter != nil && value != nil {
+		field.SetText(field.formatter.Format(value))
+	}
+	field.value = value
+	field.edited = false
+	event.Dispatch(event.NewCommit(field))
+	return true
+}
+
+func (field *TextField) commitIfEdited() {
+	if field.edited {
+		field.Commit()
+	}
+}
+
+// revalidate updates the field's invalid state.
+func (field *TextField) revalidate() {
+	message := field.validate()
+	ve := event.NewValidate(field)
+	event.Dispatch(ve)
+	invalid := message != "" || !ve.Valid()
+	if invalid != field.invalid || message != field.message {
+		field.invalid = invalid
+		field.message = message
+		field.Repaint()
+	}
+}
+
+// validate runs the field's content through its mask, formatter and validators, returning a
+// message describing the first problem found, or an empty string if there are none.
+func (field *TextField) validate() string {
+	text := string(field.runes)
+	if len(field.mask) != 0 && len(field.runes) != 0 && !maskComplete(field.mask, field.runes) {
+		return i18n.Text("Incomplete entry")
+	}
+	value, message := field.parse()
+	if message != "" {
+		return message
+	}
+	for _, validator := range field.validators {
+		if message = validator.Validate(text, value); message != "" {
+			return message
+		}
+	}
+	return ""
+}
+
+// parse returns the value represented by the field's text. Empty text has no value when there is
+// a formatter.
+func (field *TextField) parse() (value interface{}, message string) {
+	text := string(field.runes)
+	if field.formatter == nil {
+		return text, ""
+	}
+	if strings.TrimSpace(text) == "" {
+		return nil, ""
+	}
+	value, err := field.formatter.Parse(text)
+	if err != nil {
+		if detailed, ok := err.(*errs.Error); ok {
+			return nil, detailed.Message()
+		}
+		return nil, err.Error()
+	}
+	return value, ""
+}
+
+func (field *TextField) validationToolTip(evt event.Event) {
+	if field.invalid && field.message != "" {
+		evt.(*tooltip.Event).SetToolTip(tooltip.NewText(field.message))
+		evt.Finish()
+	}
+}
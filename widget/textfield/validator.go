@@ -0,0 +1,77 @@
+package textfield
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// Validator checks the content of a TextField.
+type Validator interface {
+	// Validate returns an empty string if the content is acceptable, or a message describing the
+	// problem if it isn't. 'value' is the result of parsing 'text' with the field's Formatter, or
+	// 'text' itself if the field has no Formatter.
+	Validate(text string, value interface{}) string
+}
+
+// ValidatorFunc adapts a function to the Validator interface.
+type ValidatorFunc func(text string, value interface{}) string
+
+// Validate implements the Validator interface.
+func (f ValidatorFunc) Validate(text string, value interface{}) string {
+	return f(text, value)
+}
+
+// RegexValidator returns a Validator that requires the text to match 'pattern'. 'message' is
+// reported when it doesn't.
+func RegexValidator(pattern *regexp.Regexp, message string) Validator {
+	return ValidatorFunc(func(text string, value interface{}) string {
+		if pattern.MatchString(text) {
+			return ""
+		}
+		return message
+	})
+}
+
+// RangeValidator returns a Validator that requires the value to be a finite number between 'min'
+// and 'max', inclusive. Empty text is accepted; use RequiredValidator to reject it. If 'message' is
+// empty, a message stating the range is reported instead.
+func RangeValidator(min, max float64, message string) Validator {
+	if message == "" {
+		message = fmt.Sprintf(i18n.Text("Must be a number from %v to %v"), min, max)
+	}
+	return ValidatorFunc(func(text string, value interface{}) string {
+		if strings.TrimSpace(text) == "" {
+			return ""
+		}
+		v, ok := toFloat(value)
+		if !ok {
+			var err error
+			if v, err = strconv.ParseFloat(strings.TrimSpace(text), 64); err != nil {
+				return message
+			}
+		}
+		if math.IsNaN(v) || math.IsInf(v, 0) || v < min || v > max {
+			return message
+		}
+		return ""
+	})
+}
+
+// RequiredValidator returns a Validator that requires the text to contain something other than
+// spaces. If 'message' is empty, a generic message is reported instead.
+func RequiredValidator(message string) Validator {
+	if message == "" {
+		message = i18n.Text("A value is required")
+	}
+	return ValidatorFunc(func(text string, value interface{}) string {
+		if strings.TrimSpace(text) == "" {
+			return message
+		}
+		return ""
+	})
+}
@@ -0,0 +1,78 @@
+package textfield
+
+import (
+	"math"
+	"regexp"
+	"testing"
+)
+
+func TestRangeValidator(t *testing.T) {
+	validator := RangeValidator(-10, 10, "bad")
+	for _, one := range []struct {
+		text  string
+		value interface{}
+		ok    bool
+	}{
+		{"", nil, true},
+		{"  ", nil, true},
+		{"5", int64(5), true},
+		{"-10", -10.0, true},
+		{"10", 10, true},
+		{"11", int64(11), false},
+		{"-10.5", -10.5, false},
+		{"5", "5", true},
+		{"50", "50", false},
+		{"x", "x", false},
+		{"NaN", math.NaN(), false},
+		{"NaN", "NaN", false},
+		{"Inf", math.Inf(1), false},
+		{"-Inf", "-Inf", false},
+	} {
+		message := validator.Validate(one.text, one.value)
+		if ok := message == ""; ok != one.ok {
+			t.Errorf("Validate(%q, %v) = %q; expected acceptance to be %v", one.text, one.value, message, one.ok)
+		}
+	}
+	infinite := RangeValidator(math.Inf(-1), math.Inf(1), "bad")
+	for _, value := range []float64{math.Inf(1), math.Inf(-1), math.NaN()} {
+		if message := infinite.Validate("x", value); message == "" {
+			t.Errorf("Validate(%v) was accepted by an unbounded range", value)
+		}
+	}
+	if message := RangeValidator(1, 2, "").Validate("3", 3); message == "" {
+		t.Error("Validate(3) was accepted by the range 1 to 2")
+	}
+}
+
+func TestRegexValidator(t *testing.T) {
+	validator := RegexValidator(regexp.MustCompile(`^[a-z]+$`), "bad")
+	for _, one := range []struct {
+		text string
+		ok   bool
+	}{
+		{"abc", true},
+		{"", false},
+		{"abc1", false},
+	} {
+		if message := validator.Validate(one.text, one.text); (message == "") != one.ok {
+			t.Errorf("Validate(%q) = %q; expected acceptance to be %v", one.text, message, one.ok)
+		}
+	}
+}
+
+func TestRequiredValidator(t *testing.T) {
+	validator := RequiredValidator("")
+	for _, one := range []struct {
+		text string
+		ok   bool
+	}{
+		{"a", true},
+		{" a ", true},
+		{"", false},
+		{" \t", false},
+	} {
+		if message := validator.Validate(one.text, one.text); (message == "") != one.ok {
+			t.Errorf("Validate(%q) = %q; expected acceptance to be %v", one.text, message, one.ok)
+		}
+	}
+}
@@ -11,8 +11,15 @@ import (
 
 // SetText sets a text tooltip on the target.
 func SetText(target ui.Widget, text string) {
+	target.EventHandlers().Add(event.ToolTipType, func(evt event.Event) {
+		evt.(*Event).SetToolTip(NewText(text))
+	})
+}
+
+// NewText creates a widget that displays the text in the standard tooltip style.
+func NewText(text string) ui.Widget {
 	tip := label.New(text)
-	tip.SetBackground(color.LightYellow)
-	tip.SetBorder(border.NewCompound(border.NewLine(color.DarkGray, geom.NewUniformInsets(1)), border.NewEmpty(geom.Insets{Top: 2, Left: 4, Bottom: 2, Right: 4})))
-	target.EventHandlers().Add(event.ToolTipType, func(evt event.Event) { evt.(*Event).SetToolTip(tip) })
+	tip.SetBackground(color.ToolTipBackground)
+	tip.SetBorder(border.NewCompound(border.NewLine(color.ToolTipBorder, geom.NewUniformInsets(1)), border.NewEmpty(geom.Insets{Top: 2, Left: 4, Bottom: 2, Right: 4})))
+	return tip
 }
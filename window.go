@@ -39,6 +39,10 @@ type Window interface {
 	// ContentLocalFrame returns the local boundaries of the root widget of
 	// this window.
 	ContentLocalFrame() geom.Rect
+	// Scale returns the number of device pixels per logical unit for this
+	// window. All coordinates used by windows and widgets are in logical
+	// units.
+	Scale() float64
 	// Pack sets the window's content size to match the preferred size of the
 	// root widget.
 	Pack()
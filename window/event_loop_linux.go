@@ -34,6 +34,23 @@ var (
 func RunEventLoop() {
 	for x11.Running() {
 		event := x11.NextEvent()
+		if x11.FilterEvent(event) {
+			continue
+		}
+		if x11.ProcessXSettingsEvent(event) {
+			continue
+		}
+		if x11.ProcessClipboardEvent(event) {
+			continue
+		}
+		if selection := x11.ProcessSelectionOwnerEvent(event); selection != nil {
+			clipboardChanged(selection.IsPrimary())
+			continue
+		}
+		if x11.ProcessMonitorEvent(event) {
+			displaysChanged()
+			continue
+		}
 		switch event.Type() {
 		case x11.KeyPressType:
 			processKeyDownEvent(event.ToKeyEvent())
@@ -69,10 +86,28 @@ func RunEventLoop() {
 	}
 }
 
+func displaysChanged() {
+	event.SendDisplaysChanged()
+}
+
+func clipboardChanged(primary bool) {
+	event.SendClipboardChanged(primary)
+}
+
 func processKeyDownEvent(evt *x11.KeyEvent) {
 	if window, ok := windowMap[platformWindow(uintptr(evt.Window()))]; ok {
-		code, ch := evt.CodeAndChar()
-		window.processKeyDown(code, ch, evt.Modifiers(), false)
+		code, chars := evt.CodeAndChars()
+		switch len(chars) {
+		case 0:
+			window.processKeyDown(code, 0, evt.Modifiers(), false)
+		case 1:
+			window.processKeyDown(code, chars[0], evt.Modifiers(), false)
+		default:
+			// Text committed by the input method
+			for _, ch := range chars {
+				window.processKeyDown(code, ch, 0, false)
+			}
+		}
 	}
 }
 
@@ -90,7 +125,7 @@ func processButtonPressEvent(evt *x11.ButtonEvent) {
 		focusOut(keyWindow)
 	}
 	if window, ok := windowMap[platformWindow(uintptr(evt.Window()))]; ok {
-		where := evt.Where()
+		where := window.toLogical(evt.Where())
 		if evt.IsScrollWheel() {
 			dir := evt.ScrollWheelDirection()
 			window.processMouseWheel(where.X, where.Y, dir.X, dir.Y, evt.Modifiers())
@@ -114,7 +149,7 @@ func processButtonPressEvent(evt *x11.ButtonEvent) {
 func processButtonReleaseEvent(evt *x11.ButtonEvent) {
 	if window, ok := windowMap[platformWindow(uintptr(evt.Window()))]; ok {
 		if !evt.IsScrollWheel() {
-			where := evt.Where()
+			where := window.toLogical(evt.Where())
 			lastMouseDownButton = -1
 			window.processMouseUp(where.X, where.Y, evt.Button(), evt.Modifiers())
 		}
@@ -123,7 +158,7 @@ func processButtonReleaseEvent(evt *x11.ButtonEvent) {
 
 func processMouseEnteredEvent(evt *x11.CrossingEvent) {
 	if window, ok := windowMap[platformWindow(uintptr(evt.Window()))]; ok {
-		where := evt.Where()
+		where := window.toLogical(evt.Where())
 		window.processMouseEntered(where.X, where.Y, evt.Modifiers())
 	}
 }
@@ -133,19 +168,22 @@ func processMotionEvent(evt *x11.MotionEvent) {
 	if lastMouseDownButton != -1 {
 		if window, ok := windowMap[lastMouseDownWindow]; ok {
 			where := evt.Where()
-			if target != lastMouseDownWindow {
-				if other, ok := windowMap[target]; ok {
+			if other, ok := windowMap[target]; ok {
+				where = other.toLogical(where)
+				if target != lastMouseDownWindow {
 					// Translate the coordinates to the window that had the mouse down
 					bounds := other.ContentFrame()
 					bounds.Point.Subtract(window.ContentFrame().Point)
 					where.Add(bounds.Point)
 				}
+			} else {
+				where = window.toLogical(where)
 			}
 			window.processMouseDragged(where.X, where.Y, lastMouseDownButton, evt.Modifiers())
 		}
 	} else {
 		if window, ok := windowMap[target]; ok {
-			where := evt.Where()
+			where := window.toLogical(evt.Where())
 			window.processMouseMoved(where.X, where.Y, evt.Modifiers())
 		}
 	}
@@ -153,7 +191,7 @@ func processMotionEvent(evt *x11.MotionEvent) {
 
 func processMouseExitedEvent(evt *x11.CrossingEvent) {
 	if window, ok := windowMap[platformWindow(uintptr(evt.Window()))]; ok {
-		where := evt.Where()
+		where := window.toLogical(evt.Where())
 		window.processMouseExited(where.X, where.Y, evt.Modifiers())
 	}
 }
@@ -162,7 +200,9 @@ func processFocusInEvent(evt *x11.FocusChangeEvent) {
 	event.SendAppWillActivate()
 	event.SendAppDidActivate()
 	if window, ok := windowMap[platformWindow(uintptr(evt.Window()))]; ok {
+		window.toXWindow().SetInputFocus(true)
 		event.Dispatch(event.NewFocusGained(window))
+		window.updateInputSpot()
 	}
 }
 
@@ -172,6 +212,7 @@ func processFocusOutEvent(evt *x11.FocusChangeEvent) {
 
 func focusOut(wnd platformWindow) {
 	if window, ok := windowMap[wnd]; ok {
+		window.toXWindow().SetInputFocus(false)
 		event.Dispatch(event.NewFocusLost(window))
 	}
 	event.SendAppWillDeactivate()
@@ -190,7 +231,7 @@ func processExposeEvent(evt *x11.ExposeEvent) {
 				break
 			}
 		}
-		win.draw(bounds)
+		win.draw(fromDevicePixels(bounds, win.scale))
 	}
 }
 
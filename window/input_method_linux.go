@@ -0,0 +1,35 @@
+package window
+
+import (
+	"github.com/richardwilkes/toolbox/xmath/geom"
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/internal/x11"
+)
+
+func init() {
+	x11.PreeditChanged = preeditChanged
+}
+
+func preeditChanged(wnd x11.Window, text string, caret int) {
+	if window, ok := windowMap[platformWindow(uintptr(wnd))]; ok {
+		if focus := window.Focus(); focus != nil {
+			event.Dispatch(event.NewPreedit(focus, text, caret))
+		}
+		window.updateInputSpot()
+	}
+}
+
+func platformHandlesDiacritics() bool {
+	return x11.InputMethodActive()
+}
+
+// updateInputSpot tells the input method where the text caret of the
+// focused widget is, if it has one.
+func (window *Window) updateInputSpot() {
+	if locator, ok := window.Focus().(ui.CaretLocator); ok {
+		r := locator.CaretRect()
+		pt := window.Focus().ToWindow(geom.Point{X: r.X, Y: r.Y + r.Height})
+		window.toXWindow().SetInputSpot(geom.Point{X: pt.X * window.scale, Y: pt.Y * window.scale})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/richardwilkes/toolbox/xmath/geom"
 	"github.com/richardwilkes/ui"
 	"github.com/richardwilkes/ui/color"
+	"github.com/richardwilkes/ui/event"
 	"github.com/richardwilkes/ui/layout"
 	"github.com/richardwilkes/ui/menu"
 	"github.com/richardwilkes/ui/widget"
@@ -25,6 +26,7 @@ func newRootView(window ui.Window) *RootView {
 	view.SetWindow(window)
 	view.Describer = func() string { return fmt.Sprintf("RootView #%d", view.ID()) }
 	view.SetLayout(&RootLayout{view: view})
+	view.EventHandlers().Add(event.ThemeChangedType, func(evt event.Event) { view.SetBackground(color.Background) })
 	view.content = widget.NewBlock()
 	view.AddChild(view.content)
 	return view
@@ -0,0 +1,96 @@
+package window
+
+import (
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/ui/display"
+	"github.com/richardwilkes/ui/draw"
+	"github.com/richardwilkes/ui/font"
+	"github.com/richardwilkes/ui/internal/x11"
+	"github.com/richardwilkes/ui/theme"
+)
+
+var (
+	desktopThemeName       string
+	defaultCursorBlinkRate = CursorBlinkRate
+	desktopStoppedBlinking bool
+)
+
+func init() {
+	x11.XSettingsChanged = applyDesktopSettings
+}
+
+// applyDesktopSettings updates our settings to match those the desktop
+// publishes via XSETTINGS.
+func applyDesktopSettings() {
+	if ms, ok := x11.XSettingInt("Net/DoubleClickTime"); ok && ms > 0 {
+		DoubleClickTime = time.Duration(ms) * time.Millisecond
+	}
+	if distance, ok := x11.XSettingInt("Net/DoubleClickDistance"); ok && distance > 0 {
+		DoubleClickDistance = float64(distance)
+	}
+	if blink, ok := x11.XSettingInt("Net/CursorBlink"); ok && blink == 0 {
+		CursorBlinkRate = 0
+		desktopStoppedBlinking = true
+	} else if ms, ok := x11.XSettingInt("Net/CursorBlinkTime"); ok && ms > 0 {
+		// The desktop specifies the time for a full on-off cycle
+		CursorBlinkRate = time.Duration(ms) * time.Millisecond / 2
+		desktopStoppedBlinking = false
+	} else if desktopStoppedBlinking {
+		// Blinking was turned back on without saying how fast
+		CursorBlinkRate = defaultCursorBlinkRate
+		desktopStoppedBlinking = false
+	}
+	scale := primaryScaleFactor()
+	for _, wnd := range windowMap {
+		wnd.setScale(scale)
+	}
+	draw.PreferredImageScale = display.MaxScaleFactor()
+	if name, ok := x11.XSettingString("Gtk/FontName"); ok && name != "" {
+		font.SetDesktopFont(name, desktopDPI())
+	}
+	if name, ok := x11.XSettingString("Net/ThemeName"); ok && name != desktopThemeName {
+		desktopThemeName = name
+		theme.Activate(themeForDesktopTheme(name))
+	} else {
+		theme.Refresh()
+	}
+}
+
+// desktopDPI returns the resolution the desktop uses for its fonts, in dots
+// per logical inch. The desktop includes its scale factor in the resolution
+// it publishes, but our drawing surfaces apply that scale on their own.
+func desktopDPI() float64 {
+	if value, ok := x11.XSettingInt("Xft/DPI"); ok && value > 0 {
+		return float64(value) / 1024 / primaryScaleFactor()
+	}
+	return 96
+}
+
+// primaryScaleFactor returns the scale factor of the primary display, or 1
+// if there are no displays, such as while the monitors are disconnected.
+func primaryScaleFactor() float64 {
+	if primary := display.Primary(); primary != nil {
+		return primary.ScaleFactor
+	}
+	return 1
+}
+
+// themeForDesktopTheme returns the registered theme with the same name as
+// the desktop's theme, if there is one. Otherwise, the closest built-in
+// theme is returned.
+func themeForDesktopTheme(name string) *theme.Theme {
+	if t := theme.Lookup(name); t != nil {
+		return t
+	}
+	name = strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(name))
+	switch {
+	case strings.Contains(name, "highcontrast"):
+		return theme.Lookup(theme.HighContrastName)
+	case strings.Contains(name, "dark"):
+		return theme.Lookup(theme.DarkName)
+	default:
+		return theme.Lookup(theme.LightName)
+	}
+}
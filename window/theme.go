@@ -0,0 +1,28 @@
+package window
+
+import (
+	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/event"
+	"github.com/richardwilkes/ui/theme"
+)
+
+func init() {
+	theme.AddChangeListener(themeChanged)
+}
+
+// themeChanged lets every widget in every window refresh its theme-derived
+// values, then forces a relayout and repaint of each window.
+func themeChanged() {
+	for _, wnd := range windowMap {
+		notifyThemeChanged(wnd.root)
+		wnd.Repaint()
+	}
+}
+
+func notifyThemeChanged(target ui.Widget) {
+	event.Dispatch(event.NewThemeChanged(target))
+	target.SetNeedLayout(true)
+	for _, child := range target.Children() {
+		notifyThemeChanged(child)
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/richardwilkes/toolbox/xmath/geom"
 	"github.com/richardwilkes/ui"
+	"github.com/richardwilkes/ui/display"
 	"github.com/richardwilkes/ui/layout"
 )
 
@@ -26,30 +27,30 @@ func (ts *tooltipSequencer) show() {
 		tip := ts.window.lastToolTip
 		_, pref, _ := ui.Sizes(tip, layout.NoHintSize)
 		bounds := geom.Rect{Point: geom.Point{X: ts.avoid.X, Y: ts.avoid.Y + ts.avoid.Height + 1}, Size: pref}
-		if bounds.X < 0 {
-			bounds.X = 0
+		area := ts.visibleArea()
+		if bounds.X < area.X {
+			bounds.X = area.X
 		}
-		if bounds.Y < 0 {
-			bounds.Y = 0
+		if bounds.Y < area.Y {
+			bounds.Y = area.Y
 		}
-		viewSize := ts.window.root.Size()
-		if viewSize.Width < bounds.Width {
-			_, pref, _ := ui.Sizes(tip, geom.Size{Width: viewSize.Width, Height: layout.NoHint})
-			if viewSize.Width < pref.Width {
-				bounds.X = 0
-				bounds.Width = viewSize.Width
+		if area.Width < bounds.Width {
+			_, pref, _ := ui.Sizes(tip, geom.Size{Width: area.Width, Height: layout.NoHint})
+			if area.Width < pref.Width {
+				bounds.X = area.X
+				bounds.Width = area.Width
 			} else {
 				bounds.Width = pref.Width
 			}
 			bounds.Height = pref.Height
 		}
-		if viewSize.Width < bounds.X+bounds.Width {
-			bounds.X = viewSize.Width - bounds.Width
+		if area.X+area.Width < bounds.X+bounds.Width {
+			bounds.X = area.X + area.Width - bounds.Width
 		}
-		if viewSize.Height < bounds.Y+bounds.Height {
+		if area.Y+area.Height < bounds.Y+bounds.Height {
 			bounds.Y = ts.avoid.Y - (bounds.Height + 1)
-			if bounds.Y < 0 {
-				bounds.Y = 0
+			if bounds.Y < area.Y {
+				bounds.Y = area.Y
 			}
 		}
 		tip.SetBounds(bounds)
@@ -59,6 +60,23 @@ func (ts *tooltipSequencer) show() {
 	}
 }
 
+// visibleArea returns the portion of the root view that lies within the
+// usable bounds of the display the window is on, in root view coordinates.
+func (ts *tooltipSequencer) visibleArea() geom.Rect {
+	frame := ts.window.ContentFrame()
+	d := display.ForRect(frame)
+	if d == nil {
+		return ts.window.root.LocalBounds()
+	}
+	area := d.UsableBounds
+	area.Intersect(frame)
+	if area.IsEmpty() {
+		return ts.window.root.LocalBounds()
+	}
+	area.Point.Subtract(frame.Point)
+	return area
+}
+
 func (ts *tooltipSequencer) close() {
 	if ts.window.tooltipSequence == ts.sequence {
 		ts.window.root.SetTooltip(nil)
@@ -8,8 +8,10 @@ import (
 	"github.com/richardwilkes/toolbox/xmath/geom"
 	"github.com/richardwilkes/ui"
 	"github.com/richardwilkes/ui/cursor"
+	"github.com/richardwilkes/ui/display"
 	"github.com/richardwilkes/ui/draw"
 	"github.com/richardwilkes/ui/event"
+	mousebutton "github.com/richardwilkes/ui/event/button"
 	"github.com/richardwilkes/ui/internal/task"
 	"github.com/richardwilkes/ui/keys"
 	"github.com/richardwilkes/ui/layout"
@@ -53,9 +55,12 @@ type commonWindow struct {
 var (
 	// LastWindowClosed will be called when the last window is closed, if not nil.
 	LastWindowClosed func()
-	windowMap        = make(map[platformWindow]*Window)
-	windowIDMap      = make(map[uint64]*Window)
-	windowList       = make([]*Window, 0)
+	// CursorBlinkRate holds the amount of time a text cursor remains visible or hidden before
+	// toggling. A value of zero or less disables blinking.
+	CursorBlinkRate = time.Millisecond * 560
+	windowMap       = make(map[platformWindow]*Window)
+	windowIDMap     = make(map[uint64]*Window)
+	windowList      = make([]*Window, 0)
 )
 
 // AllWindowsToFront attempts to bring all of the application's windows to the foreground.
@@ -86,8 +91,13 @@ func Windows() []ui.Window {
 // application's windows has the keyboard focus.
 func KeyWindow() ui.Window {
 	if window, ok := windowMap[platformGetKeyWindow()]; ok {
-		if window.owner != nil {
-			return window.owner
+		// Popups, such as menus, may be nested, so walk up to the outermost owner
+		for window.owner != nil {
+			owner, ok := window.owner.(*Window)
+			if !ok {
+				return window.owner
+			}
+			window = owner
 		}
 		return window
 	}
@@ -100,17 +110,19 @@ func NewWindow(where geom.Point, styleMask StyleMask) *Window {
 }
 
 // NewWindowWithContentSize creates a new window at the specified location with the specified style and content size.
+// The window will be moved, and if necessary shrunk, to fit within the usable area of the display it overlaps the most.
 func NewWindowWithContentSize(where geom.Point, contentSize geom.Size, styleMask StyleMask) *Window {
-	bounds := geom.Rect{Point: where, Size: contentSize}
-	wnd := newWindow(platformNewWindow(bounds, styleMask), styleMask, where)
+	bounds := display.FitRectOnto(geom.Rect{Point: where, Size: contentSize})
+	wnd := newWindow(platformNewWindow(bounds, styleMask), styleMask, bounds.Point)
 	windowList = append(windowList, wnd)
 	return wnd
 }
 
-// NewPopupWindow creates a new popup window at the specified location and content size.
+// NewPopupWindow creates a new popup window at the specified location and content size. The window will be moved, and
+// if necessary shrunk, to fit within the usable area of the display it overlaps the most.
 func NewPopupWindow(parent ui.Window, where geom.Point, contentSize geom.Size) *Window {
-	bounds := geom.Rect{Point: where, Size: contentSize}
-	wnd := newWindow(platformNewPopupWindow(parent, bounds), BorderlessWindowMask, where)
+	bounds := display.FitRectOnto(geom.Rect{Point: where, Size: contentSize})
+	wnd := newWindow(platformNewPopupWindow(parent, bounds), BorderlessWindowMask, bounds.Point)
 	wnd.owner = parent
 	return wnd
 }
@@ -255,6 +267,11 @@ func (window *Window) ContentLocalFrame() geom.Rect {
 	return bounds
 }
 
+// Scale returns the number of device pixels per logical unit for this window.
+func (window *Window) Scale() float64 {
+	return window.platformScale()
+}
+
 // Pack sets the window's content size to match the preferred size of the root widget.
 func (window *Window) Pack() {
 	_, pref, _ := ui.Sizes(window.root, layout.NoHintSize)
@@ -497,6 +514,10 @@ func (window *Window) processMouseDown(x, y float64, button, clickCount int, key
 		event.Dispatch(e)
 		if !e.Discarded() {
 			window.inMouseDown = true
+			if button == mousebutton.Right && window.showContextMenu(widget, where) {
+				// The menu now has the mouse, so we won't see the matching mouse up
+				window.inMouseDown = false
+			}
 		}
 	}
 	window.lastMouseWidget = widget
@@ -576,7 +597,9 @@ func (window *Window) processMouseWheel(x, y, dx, dy float64, keyModifiers keys.
 
 func (window *Window) processKeyDown(keyCode int, ch rune, keyModifiers keys.Modifiers, repeat bool) {
 	window.clearToolTip()
-	ch = processDiacritics(keyCode, ch, keyModifiers)
+	if !platformHandlesDiacritics() {
+		ch = processDiacritics(keyCode, ch, keyModifiers)
+	}
 	e := event.NewKeyDown(window.Focus(), keyCode, ch, keyModifiers, repeat)
 	bar := window.MenuBar()
 	if bar != nil {
@@ -584,6 +607,12 @@ func (window *Window) processKeyDown(keyCode int, ch rune, keyModifiers keys.Mod
 	}
 	if !e.Discarded() && !e.Finished() {
 		event.Dispatch(e)
+		if !e.Discarded() && !e.Finished() && (keyCode == keys.VirtualKeyMenu || (keyCode == keys.VirtualKeyF10 && keyModifiers&keys.NonStickyModifiers == keys.ShiftModifier)) {
+			if focus := window.Focus(); focus != nil {
+				window.showContextMenu(focus, contextMenuLocation(focus))
+			}
+			return
+		}
 		if !e.Discarded() && keyCode == keys.VirtualKeyTab && (keyModifiers&(keys.AllModifiers & ^keys.ShiftModifier)) == 0 {
 			if keyModifiers.ShiftDown() {
 				window.FocusPrevious()
@@ -594,8 +623,43 @@ func (window *Window) processKeyDown(keyCode int, ch rune, keyModifiers keys.Mod
 	}
 }
 
+// showContextMenu asks 'target' and its parents to assemble a context menu and, if they added any
+// items to it, shows it at 'where'. Returns true if the menu was shown.
+func (window *Window) showContextMenu(target ui.Widget, where geom.Point) bool {
+	e := event.NewContextMenu(target, where)
+	event.Dispatch(e)
+	mnu, ok := e.Menu().(menu.Menu)
+	if !ok {
+		return false
+	}
+	defer mnu.Dispose()
+	if mnu.Count() == 0 {
+		return false
+	}
+	mnu.Popup(window.ID(), where, 0, nil)
+	return true
+}
+
+// contextMenuLocation returns the location, in window coordinates, that a context menu requested
+// from the keyboard should be shown for the widget.
+func contextMenuLocation(widget ui.Widget) geom.Point {
+	if locator, ok := widget.(ui.CaretLocator); ok {
+		r := locator.CaretRect()
+		return widget.ToWindow(geom.Point{X: r.X, Y: r.Y + r.Height})
+	}
+	bounds := widget.LocalBounds()
+	return widget.ToWindow(geom.Point{X: bounds.X, Y: bounds.Y + bounds.Height})
+}
+
 func (window *Window) processKeyUp(keyCode int, keyModifiers keys.Modifiers) {
-	event.Dispatch(event.NewKeyUp(window.Focus(), keyCode, keyModifiers))
+	e := event.NewKeyUp(window.Focus(), keyCode, keyModifiers)
+	bar := window.MenuBar()
+	if bar != nil {
+		bar.ProcessKeyUp(e)
+	}
+	if !e.Finished() {
+		event.Dispatch(e)
+	}
 }
 
 // Invoke a task on the UI thread. The task is put into the system event queue and will be run at
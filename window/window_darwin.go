@@ -75,6 +75,14 @@ func (window *Window) platformContentFrame() geom.Rect {
 	return bounds
 }
 
+func platformHandlesDiacritics() bool {
+	return false
+}
+
+func (window *Window) platformScale() float64 {
+	return float64(C.getWindowScale(C.platformWindow(window.window)))
+}
+
 func (window *Window) platformToFront() {
 	C.bringWindowToFront(C.platformWindow(window.window))
 }
@@ -25,3 +25,4 @@ platformWindow newWindow(double x, double y, double width, double height, int st
 void getWindowFrame(platformWindow window, double *x, double *y, double *width, double *height);
 void setWindowFrame(platformWindow window, double x, double y, double width, double height);
 void getWindowContentFrame(platformWindow window, double *x, double *y, double *width, double *height);
+double getWindowScale(platformWindow window);
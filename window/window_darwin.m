@@ -276,3 +276,7 @@ void getWindowContentFrame(platformWindow window, double *x, double *y, double *
 	*width = frame.size.width;
 	*height = frame.size.height;
 }
+
+double getWindowScale(platformWindow window) {
+	return [(NSWindow *)window backingScaleFactor];
+}
@@ -1,12 +1,14 @@
 package window
 
 import (
+	"math"
 	"time"
 
 	"github.com/richardwilkes/toolbox/xmath/geom"
 	"github.com/richardwilkes/ui"
 	"github.com/richardwilkes/ui/color"
 	"github.com/richardwilkes/ui/cursor"
+	"github.com/richardwilkes/ui/display"
 	"github.com/richardwilkes/ui/draw"
 	"github.com/richardwilkes/ui/internal/x11"
 )
@@ -15,6 +17,7 @@ import (
 type Window struct {
 	commonWindow
 	surface   *draw.Surface
+	scale     float64
 	wasMapped bool
 }
 
@@ -45,18 +48,59 @@ func platformHideCursorUntilMouseMoves() {
 }
 
 func platformNewWindow(bounds geom.Rect, styleMask StyleMask) *Window {
-	wnd := x11.NewWindow(bounds)
+	scale := 1.0
+	if d := display.ForRect(bounds); d != nil {
+		scale = d.ScaleFactor
+	}
+	wnd := x11.NewWindow(toDevicePixels(bounds, scale))
 	return &Window{
 		commonWindow: commonWindow{window: platformWindow(uintptr(wnd))},
-		surface:      wnd.NewSurface(bounds.Size),
+		surface:      wnd.NewSurface(bounds.Size, scale),
+		scale:        scale,
 	}
 }
 
 func platformNewPopupWindow(parent ui.Window, bounds geom.Rect) *Window {
-	wnd := x11.NewPopupWindow(x11.Window(uintptr(parent.PlatformPtr())), bounds)
+	scale := parent.Scale()
+	wnd := x11.NewPopupWindow(x11.Window(uintptr(parent.PlatformPtr())), toDevicePixels(bounds, scale))
 	return &Window{
 		commonWindow: commonWindow{window: platformWindow(uintptr(wnd))},
-		surface:      wnd.NewSurface(bounds.Size),
+		surface:      wnd.NewSurface(bounds.Size, scale),
+		scale:        scale,
+	}
+}
+
+// toDevicePixels converts a rectangle in logical units to device pixels, expanding it as needed to
+// cover any partial pixels.
+func toDevicePixels(bounds geom.Rect, scale float64) geom.Rect {
+	x := math.Floor(bounds.X * scale)
+	y := math.Floor(bounds.Y * scale)
+	return geom.Rect{Point: geom.Point{X: x, Y: y}, Size: geom.Size{Width: math.Ceil((bounds.X+bounds.Width)*scale) - x, Height: math.Ceil((bounds.Y+bounds.Height)*scale) - y}}
+}
+
+// fromDevicePixels converts a rectangle in device pixels to logical units.
+func fromDevicePixels(bounds geom.Rect, scale float64) geom.Rect {
+	return geom.Rect{Point: geom.Point{X: bounds.X / scale, Y: bounds.Y / scale}, Size: geom.Size{Width: bounds.Width / scale, Height: bounds.Height / scale}}
+}
+
+// toLogical converts a point in device pixels relative to this window to logical units.
+func (window *Window) toLogical(pt geom.Point) geom.Point {
+	return geom.Point{X: pt.X / window.scale, Y: pt.Y / window.scale}
+}
+
+func (window *Window) platformScale() float64 {
+	return window.scale
+}
+
+// setScale changes the number of device pixels per logical unit, keeping the logical size of the
+// window's content the same.
+func (window *Window) setScale(scale float64) {
+	if window.scale != scale && window.Valid() {
+		frame := window.ContentFrame()
+		window.scale = scale
+		window.surface.SetScale(scale)
+		window.SetContentFrame(frame)
+		window.Repaint()
 	}
 }
 
@@ -80,7 +124,11 @@ func (window *Window) platformSetTitle(title string) {
 
 func (window *Window) frameDecorationSpace() (top, left, bottom, right float64) {
 	if window.Valid() {
-		return window.toXWindow().FrameDecorationSpace()
+		top, left, bottom, right = window.toXWindow().FrameDecorationSpace()
+		top /= window.scale
+		left /= window.scale
+		bottom /= window.scale
+		right /= window.scale
 	}
 	return
 }
@@ -96,12 +144,12 @@ func (window *Window) platformFrame() geom.Rect {
 }
 
 func (window *Window) platformSetFrame(bounds geom.Rect) {
-	window.toXWindow().SetFrame(bounds)
+	window.toXWindow().SetFrame(toDevicePixels(bounds, window.scale))
 }
 
 func (window *Window) platformContentFrame() geom.Rect {
 	if window.Valid() {
-		return window.toXWindow().ContentFrame()
+		return fromDevicePixels(window.toXWindow().ContentFrame(), window.scale)
 	}
 	return geom.Rect{}
 }
@@ -110,13 +158,14 @@ func (window *Window) platformToFront() {
 	wnd := window.toXWindow()
 	if window.wasMapped {
 		wnd.Raise()
+		wnd.RequestFocus()
 	} else {
 		wnd.Show()
 		// Wait for window to be mapped
 		for {
 			if event := wnd.NextEventOfType(x11.MapNotifyType); event != nil {
 				window.wasMapped = true
-				wnd.Move(window.initialLocationRequest)
+				wnd.Move(toDevicePixels(geom.Rect{Point: window.initialLocationRequest}, window.scale).Point)
 				if window.owner == nil {
 					// Wait for window to be configured so that we have correct placement information
 					for {
@@ -137,7 +186,7 @@ func (window *Window) platformToFront() {
 }
 
 func (window *Window) platformRepaint(bounds geom.Rect) {
-	window.toXWindow().Repaint(bounds)
+	window.toXWindow().Repaint(toDevicePixels(bounds, window.scale))
 }
 
 func (window *Window) draw(bounds geom.Rect) {
@@ -156,6 +205,7 @@ func (window *Window) draw(bounds geom.Rect) {
 	gc.Dispose()
 
 	buffer.Destroy()
+	window.updateInputSpot()
 }
 
 func (window *Window) platformFlushPainting() {
@@ -65,6 +65,16 @@ func (window *Window) platformContentFrame() geom.Rect {
 	return geom.Rect{}
 }
 
+func platformHandlesDiacritics() bool {
+	// RAW: Implement for Windows
+	return false
+}
+
+func (window *Window) platformScale() float64 {
+	// RAW: Implement for Windows
+	return 1
+}
+
 func (window *Window) platformToFront() {
 	// RAW: Implement for Windows
 }